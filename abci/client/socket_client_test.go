@@ -93,6 +93,87 @@ func TestHangingSyncCalls(t *testing.T) {
 	}
 }
 
+func TestServerStopDrainsConnections(t *testing.T) {
+	app := slowApp{}
+
+	s, c := setupClientServer(t, app)
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	reqres := c.BeginBlockAsync(types.RequestBeginBlock{})
+	flush := c.FlushAsync()
+	// wait for the request to reach the app, which takes 200 ms to respond
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, s.Stop())
+
+	// the response of the request in flight is sent before the connection is
+	// closed, with an exception
+	reqres.Wait()
+	flush.Wait()
+	assert.NotNil(t, reqres.Response.GetBeginBlock())
+	require.Error(t, c.Error())
+	assert.Contains(t, c.Error().Error(), "shutting down")
+}
+
+func TestServerPanicRespondsWithException(t *testing.T) {
+	s, c := setupClientServer(t, panicApp{})
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	_, err := c.EchoSync("hello")
+	require.NoError(t, err)
+
+	_, err = c.InfoSync(types.RequestInfo{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recovered from panic: info")
+}
+
+func TestServerMaxConnections(t *testing.T) {
+	// some port between 20k and 30k
+	port := 20000 + tmrand.Int32()%10000
+	addr := fmt.Sprintf("localhost:%d", port)
+
+	s := server.NewSocketServer(addr, types.NewBaseApplication(), server.WithMaxConnections(2))
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the connections are served concurrently
+	clients := make([]abcicli.Client, 3)
+	for i := range clients {
+		clients[i] = abcicli.NewSocketClient(addr, true)
+		require.NoError(t, clients[i].Start())
+		c := clients[i]
+		t.Cleanup(func() {
+			if err := c.Stop(); err != nil {
+				t.Log(err)
+			}
+		})
+	}
+	for _, c := range clients[:2] {
+		_, err := c.EchoSync("hello")
+		require.NoError(t, err)
+	}
+
+	_, err := clients[2].EchoSync("hello")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum of 2 connections")
+}
+
 func setupClientServer(t *testing.T, app types.Application) (
 	service.Service, abcicli.Client) {
 	// some port between 20k and 30k
@@ -111,6 +192,14 @@ func setupClientServer(t *testing.T, app types.Application) (
 	return s, c
 }
 
+type panicApp struct {
+	types.BaseApplication
+}
+
+func (panicApp) Info(req types.RequestInfo) types.ResponseInfo {
+	panic("info")
+}
+
 type slowApp struct {
 	types.BaseApplication
 }
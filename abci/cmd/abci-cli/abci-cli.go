@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -38,6 +40,7 @@ var (
 	flagAbci     string
 	flagVerbose  bool   // for the println output
 	flagLogLevel string // for the logger
+	flagJSON     bool   // print responses as JSON
 
 	// query
 	flagPath   string
@@ -47,8 +50,13 @@ var (
 	// counter
 	flagSerial bool
 
-	// kvstore
+	// counter, kvstore
 	flagPersist string
+
+	// test
+	flagTestCounter bool
+	flagTestTx      string
+	flagTestReplica string
 )
 
 var RootCmd = &cobra.Command{
@@ -89,19 +97,40 @@ var RootCmd = &cobra.Command{
 // Structure for data passed to print response.
 type response struct {
 	// generic abci response
-	Data []byte
-	Code uint32
-	Info string
-	Log  string
+	Data []byte `json:"data,omitempty"`
+	Code uint32 `json:"code"`
+	Info string `json:"info,omitempty"`
+	Log  string `json:"log,omitempty"`
 
-	Query *queryResponse
+	Query *queryResponse `json:"query,omitempty"`
 }
 
 type queryResponse struct {
-	Key      []byte
-	Value    []byte
-	Height   int64
-	ProofOps *crypto.ProofOps
+	Key      []byte           `json:"key,omitempty"`
+	Value    []byte           `json:"value,omitempty"`
+	Height   int64            `json:"height"`
+	ProofOps *crypto.ProofOps `json:"proof_ops,omitempty"`
+}
+
+// codeError is returned by the commands whose response has a non-OK code, so
+// that abci-cli exits with a non-zero status. The response is printed already.
+type codeError struct {
+	code uint32
+}
+
+func (e codeError) Error() string {
+	return fmt.Sprintf("response code %d", e.code)
+}
+
+// checkCode returns a codeError if code is not OK, silencing cobra which would
+// print it along with the usage.
+func checkCode(cmd *cobra.Command, code uint32) error {
+	if code == types.CodeTypeOK {
+		return nil
+	}
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return codeError{code: code}
 }
 
 func Execute() error {
@@ -123,6 +152,7 @@ func addGlobalFlags() {
 		false,
 		"print the command and results as if it were a console session")
 	RootCmd.PersistentFlags().StringVarP(&flagLogLevel, "log_level", "", "debug", "set the logger level")
+	RootCmd.PersistentFlags().BoolVarP(&flagJSON, "json", "", false, "print the responses as JSON")
 }
 
 func addQueryFlags() {
@@ -137,6 +167,21 @@ func addQueryFlags() {
 
 func addCounterFlags() {
 	counterCmd.PersistentFlags().BoolVarP(&flagSerial, "serial", "", false, "enforce incrementing (serial) transactions")
+	counterCmd.PersistentFlags().StringVarP(&flagPersist, "persist", "", "", "directory to use for a database")
+}
+
+func addTestFlags() {
+	testCmd.PersistentFlags().BoolVarP(&flagTestCounter,
+		"counter",
+		"",
+		false,
+		"run the integration tests of the counter example app instead")
+	testCmd.PersistentFlags().StringVarP(&flagTestTx, "tx", "", `"abci-cli-test"`, "the tx to deliver and query")
+	testCmd.PersistentFlags().StringVarP(&flagTestReplica,
+		"replica",
+		"",
+		"",
+		"address of another instance of the application, to check that it commits the same app hashes")
 }
 
 func addKVStoreFlags() {
@@ -153,6 +198,7 @@ func addCommands() {
 	RootCmd.AddCommand(checkTxCmd)
 	RootCmd.AddCommand(commitCmd)
 	RootCmd.AddCommand(versionCmd)
+	addTestFlags()
 	RootCmd.AddCommand(testCmd)
 	addQueryFlags()
 	RootCmd.AddCommand(queryCmd)
@@ -224,10 +270,18 @@ var setOptionCmd = &cobra.Command{
 	RunE:  cmdSetOption,
 }
 
+const txArgHelp = `The argument is either:
+
+    0x0102...     hex-encoded bytes
+    "abc"         a quoted string
+    @path         the contents of the file at path
+
+The command exits with a non-zero status if the response code is not OK.`
+
 var deliverTxCmd = &cobra.Command{
 	Use:   "deliver_tx",
 	Short: "deliver a new transaction to the application",
-	Long:  "deliver a new transaction to the application",
+	Long:  "deliver a new transaction to the application\n\n" + txArgHelp,
 	Args:  cobra.ExactArgs(1),
 	RunE:  cmdDeliverTx,
 }
@@ -235,7 +289,7 @@ var deliverTxCmd = &cobra.Command{
 var checkTxCmd = &cobra.Command{
 	Use:   "check_tx",
 	Short: "validate a transaction",
-	Long:  "validate a transaction",
+	Long:  "validate a transaction\n\n" + txArgHelp,
 	Args:  cobra.ExactArgs(1),
 	RunE:  cmdCheckTx,
 }
@@ -262,7 +316,7 @@ var versionCmd = &cobra.Command{
 var queryCmd = &cobra.Command{
 	Use:   "query",
 	Short: "query the application state",
-	Long:  "query the application state",
+	Long:  "query the application state\n\n" + txArgHelp,
 	Args:  cobra.ExactArgs(1),
 	RunE:  cmdQuery,
 }
@@ -285,10 +339,30 @@ var kvstoreCmd = &cobra.Command{
 
 var testCmd = &cobra.Command{
 	Use:   "test",
-	Short: "run integration tests",
-	Long:  "run integration tests",
-	Args:  cobra.ExactArgs(0),
-	RunE:  cmdTest,
+	Short: "run ABCI conformance tests against an application",
+	Long: `run ABCI conformance tests against an application
+
+This command makes the calls Tendermint would against the application, and
+checks that:
+
+    echo returns the message
+    info reports the last block committed, with the app hash of its commit
+    a block with a tx (see --tx) is executed and committed
+    the tx can be queried, at a height no higher than the last block
+    an empty block is executed and committed
+
+With --replica, the blocks are also executed against another instance of the
+application, started from the same state, which must commit the same app
+hashes.
+
+The result of each check is printed, and the command exits with a non-zero
+status if any failed. Whether the empty block changed the app hash is printed
+as a note; applications may change their state in BeginBlock or EndBlock.
+
+The state of the applications is committed, so they must not be used by a node.
+`,
+	Args: cobra.ExactArgs(0),
+	RunE: cmdTest,
 }
 
 // Generates new Args array based off of previous call args to maintain flag persistence
@@ -321,6 +395,32 @@ func compose(fs []func() error) error {
 }
 
 func cmdTest(cmd *cobra.Command, args []string) error {
+	if !flagTestCounter {
+		tx, err := stringOrHexToBytes(flagTestTx)
+		if err != nil {
+			return err
+		}
+		var replica abcicli.Client
+		if flagTestReplica != "" {
+			replica, err = abcicli.NewClient(flagTestReplica, flagAbci, false)
+			if err != nil {
+				return err
+			}
+			replica.SetLogger(logger.With("module", "abci-client", "replica", flagTestReplica))
+			if err := replica.Start(); err != nil {
+				return err
+			}
+			defer func() {
+				if err := replica.Stop(); err != nil {
+					logger.Error("Error stopping the replica client", "err", err)
+				}
+			}()
+		}
+		// the failures are printed, the usage is not relevant
+		cmd.SilenceUsage = true
+		return servertest.Conformance(client, replica, tx, os.Stdout)
+	}
+
 	return compose(
 		[]func() error{
 			func() error { return servertest.InitChain(client) },
@@ -369,9 +469,10 @@ LOOP:
 }
 
 func cmdConsole(cmd *cobra.Command, args []string) error {
+	// a single reader, so that the input it buffered is not lost
+	bufReader := bufio.NewReader(os.Stdin)
 	for {
 		fmt.Printf("> ")
-		bufReader := bufio.NewReader(os.Stdin)
 		line, more, err := bufReader.ReadLine()
 		if more {
 			return errors.New("input is too long")
@@ -426,6 +527,15 @@ func muxOnCommands(cmd *cobra.Command, pArgs []string) error {
 	}
 	cmd.Use = subCommand // for later print statements ...
 
+	// the responses with bad codes are printed, and do not stop the session
+	err := runSubCommand(cmd, subCommand, actualArgs, pArgs)
+	if errors.As(err, &codeError{}) {
+		return nil
+	}
+	return err
+}
+
+func runSubCommand(cmd *cobra.Command, subCommand string, actualArgs, pArgs []string) error {
 	switch strings.ToLower(subCommand) {
 	case "check_tx":
 		return cmdCheckTx(cmd, actualArgs)
@@ -530,7 +640,7 @@ func cmdDeliverTx(cmd *cobra.Command, args []string) error {
 			Code: codeBad,
 			Log:  "want the tx",
 		})
-		return nil
+		return checkCode(cmd, codeBad)
 	}
 	txBytes, err := stringOrHexToBytes(args[0])
 	if err != nil {
@@ -546,7 +656,7 @@ func cmdDeliverTx(cmd *cobra.Command, args []string) error {
 		Info: res.Info,
 		Log:  res.Log,
 	})
-	return nil
+	return checkCode(cmd, res.Code)
 }
 
 // Validate a tx
@@ -556,7 +666,7 @@ func cmdCheckTx(cmd *cobra.Command, args []string) error {
 			Code: codeBad,
 			Info: "want the tx",
 		})
-		return nil
+		return checkCode(cmd, codeBad)
 	}
 	txBytes, err := stringOrHexToBytes(args[0])
 	if err != nil {
@@ -572,7 +682,7 @@ func cmdCheckTx(cmd *cobra.Command, args []string) error {
 		Info: res.Info,
 		Log:  res.Log,
 	})
-	return nil
+	return checkCode(cmd, res.Code)
 }
 
 // Get application Merkle root hash
@@ -595,7 +705,7 @@ func cmdQuery(cmd *cobra.Command, args []string) error {
 			Info: "want the query",
 			Log:  "",
 		})
-		return nil
+		return checkCode(cmd, codeBad)
 	}
 	queryBytes, err := stringOrHexToBytes(args[0])
 	if err != nil {
@@ -622,13 +732,23 @@ func cmdQuery(cmd *cobra.Command, args []string) error {
 			ProofOps: resQuery.ProofOps,
 		},
 	})
-	return nil
+	return checkCode(cmd, resQuery.Code)
 }
 
 func cmdCounter(cmd *cobra.Command, args []string) error {
-	app := counter.NewApplication(flagSerial)
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
 
+	// Create the application - in memory or persisted to disk
+	app := counter.NewApplication(flagSerial)
+	if flagPersist != "" {
+		db, err := dbm.NewGoLevelDB("counter", flagPersist)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		app = counter.NewPersistentApplication(flagSerial, db)
+	}
+
 	// Start the listener
 	srv, err := server.NewServer(flagAddress, flagAbci, app)
 	if err != nil {
@@ -689,6 +809,15 @@ func cmdKVStore(cmd *cobra.Command, args []string) error {
 
 func printResponse(cmd *cobra.Command, args []string, rsp response) {
 
+	if flagJSON {
+		bz, err := json.Marshal(rsp)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(bz))
+		return
+	}
+
 	if flagVerbose {
 		fmt.Println(">", cmd.Use, strings.Join(args, " "))
 	}
@@ -729,8 +858,17 @@ func printResponse(cmd *cobra.Command, args []string, rsp response) {
 	}
 }
 
-// NOTE: s is interpreted as a string unless prefixed with 0x
+// NOTE: s is interpreted as a string unless prefixed with 0x, or as the path
+// of a file to read if prefixed with @
 func stringOrHexToBytes(s string) ([]byte, error) {
+	if len(s) > 1 && s[0] == '@' {
+		b, err := os.ReadFile(s[1:])
+		if err != nil {
+			return nil, fmt.Errorf("error reading file argument: %w", err)
+		}
+		return b, nil
+	}
+
 	if len(s) > 2 && strings.ToLower(s[:2]) == "0x" {
 		b, err := hex.DecodeString(s[2:])
 		if err != nil {
@@ -741,7 +879,7 @@ func stringOrHexToBytes(s string) ([]byte, error) {
 	}
 
 	if !strings.HasPrefix(s, "\"") || !strings.HasSuffix(s, "\"") {
-		err := fmt.Errorf("invalid string arg: \"%s\". Must be quoted or a \"0x\"-prefixed hex string", s)
+		err := fmt.Errorf("invalid string arg: \"%s\". Must be quoted, a \"0x\"-prefixed hex string or an \"@\"-prefixed file path", s)
 		return nil, err
 	}
 
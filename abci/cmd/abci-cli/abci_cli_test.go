@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/code"
+	"github.com/tendermint/tendermint/abci/example/counter"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// startClient sets the client of the commands to a local client of app.
+func startClient(t *testing.T, app types.Application) {
+	t.Helper()
+	client = abcicli.NewLocalClient(nil, app)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+		client = nil
+	})
+}
+
+// runWithIO runs f with the given standard input, and returns what it wrote
+// to the standard output.
+func runWithIO(t *testing.T, stdin string, f func() error) (string, error) {
+	t.Helper()
+
+	in := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(in, []byte(stdin), 0o600))
+	inFile, err := os.Open(in)
+	require.NoError(t, err)
+	defer inFile.Close()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	out := make(chan string)
+	go func() {
+		bz, _ := io.ReadAll(r)
+		out <- string(bz)
+	}()
+
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = inFile, w
+	err = f()
+	os.Stdin, os.Stdout = oldStdin, oldStdout
+	require.NoError(t, w.Close())
+	return <-out, err
+}
+
+func TestStringOrHexToBytes(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tx.bin")
+	require.NoError(t, os.WriteFile(file, []byte{0x00, 0xff, '\n'}, 0o600))
+
+	testCases := []struct {
+		arg     string
+		want    []byte
+		wantErr bool
+	}{
+		{`"abc"`, []byte("abc"), false},
+		{`""`, []byte{}, false},
+		{"0x00ff", []byte{0x00, 0xff}, false},
+		{"0X00FF", []byte{0x00, 0xff}, false},
+		{"0xzz", nil, true},
+		// files are read as is, without trimming
+		{"@" + file, []byte{0x00, 0xff, '\n'}, false},
+		{"@" + file + ".missing", nil, true},
+		{"@", nil, true},
+		{"abc", nil, true},
+	}
+	for _, tc := range testCases {
+		got, err := stringOrHexToBytes(tc.arg)
+		if tc.wantErr {
+			assert.Error(t, err, tc.arg)
+			continue
+		}
+		if assert.NoError(t, err, tc.arg) {
+			assert.Equal(t, tc.want, got, tc.arg)
+		}
+	}
+}
+
+func TestCommandsBadCode(t *testing.T) {
+	startClient(t, counter.NewApplication(true))
+
+	// the nonce 0 is expected first
+	out, err := runWithIO(t, "", func() error {
+		return cmdDeliverTx(&cobra.Command{Use: "deliver_tx"}, []string{"0x01"})
+	})
+	assert.True(t, errors.As(err, &codeError{}), err)
+	assert.Contains(t, out, "-> code: 2")
+
+	out, err = runWithIO(t, "", func() error {
+		return cmdCheckTx(&cobra.Command{Use: "check_tx"}, []string{"0x00"})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "-> code: OK")
+
+	// the bad code makes abci-cli exit with a non-zero status, and the
+	// response is printed as JSON
+	t.Cleanup(func() { flagJSON = false })
+	RootCmd.SetArgs([]string{"--json", "deliver_tx", "0x01"})
+	out, err = runWithIO(t, "", Execute)
+	assert.True(t, errors.As(err, &codeError{}), err)
+	var res response
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(out)), &res), out)
+	assert.Equal(t, code.CodeTypeBadNonce, res.Code)
+	assert.Contains(t, res.Log, "Invalid nonce")
+}
+
+func TestSessionContinuesAfterBadCode(t *testing.T) {
+	oldArgs := os.Args
+	t.Cleanup(func() { os.Args = oldArgs })
+
+	input := "deliver_tx 0x01\ndeliver_tx 0x00\ninfo\n"
+	for _, tc := range []struct {
+		name string
+		run  func(cmd *cobra.Command, args []string) error
+		// the error at the end of the input
+		wantErr error
+	}{
+		{"batch", cmdBatch, nil},
+		{"console", cmdConsole, io.EOF},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			startClient(t, counter.NewApplication(true))
+			os.Args = []string{"abci-cli", tc.name}
+
+			out, err := runWithIO(t, input, func() error {
+				return tc.run(&cobra.Command{Use: tc.name}, nil)
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Contains(t, out, "-> code: 2")
+			// the commands following the bad code were run
+			assert.Contains(t, out, "-> code: OK")
+			assert.Contains(t, out, `-> data: {"hashes":0,"txs":1}`)
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 )
 
 func main() {
 	err := Execute()
+	if errors.As(err, &codeError{}) {
+		os.Exit(1) // the response is printed already
+	}
 	if err != nil {
 		fmt.Print(err)
 		os.Exit(1)
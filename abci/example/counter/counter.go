@@ -2,26 +2,69 @@ package counter
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 
+	dbm "github.com/tendermint/tm-db"
+
 	"github.com/tendermint/tendermint/abci/example/code"
 	"github.com/tendermint/tendermint/abci/types"
 )
 
+var stateKey = []byte("stateKey")
+
+// state is the state of the counters as of the last block committed.
+type state struct {
+	HashCount int    `json:"hash_count"`
+	TxCount   int    `json:"tx_count"`
+	Height    int64  `json:"height"`
+	AppHash   []byte `json:"app_hash"`
+}
+
 type Application struct {
 	types.BaseApplication
 
 	hashCount int
 	txCount   int
 	serial    bool
+
+	// the last block committed, reported by Info for the handshake
+	height  int64
+	appHash []byte
+
+	db dbm.DB // nil if the counters are not persisted
 }
 
 func NewApplication(serial bool) *Application {
 	return &Application{serial: serial}
 }
 
+// NewPersistentApplication returns a counter application which saves its
+// counters to db on each commit, and resumes from them when restarted.
+func NewPersistentApplication(serial bool, db dbm.DB) *Application {
+	app := &Application{serial: serial, db: db}
+	bz, err := db.Get(stateKey)
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return app
+	}
+	var st state
+	if err := json.Unmarshal(bz, &st); err != nil {
+		panic(err)
+	}
+	app.hashCount, app.txCount = st.HashCount, st.TxCount
+	app.height, app.appHash = st.Height, st.AppHash
+	return app
+}
+
 func (app *Application) Info(req types.RequestInfo) types.ResponseInfo {
-	return types.ResponseInfo{Data: fmt.Sprintf("{\"hashes\":%v,\"txs\":%v}", app.hashCount, app.txCount)}
+	return types.ResponseInfo{
+		Data:             fmt.Sprintf("{\"hashes\":%v,\"txs\":%v}", app.hashCount, app.txCount),
+		LastBlockHeight:  app.height,
+		LastBlockAppHash: app.appHash,
+	}
 }
 
 func (app *Application) SetOption(req types.RequestSetOption) types.ResponseSetOption {
@@ -83,21 +126,58 @@ func (app *Application) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 
 func (app *Application) Commit() (resp types.ResponseCommit) {
 	app.hashCount++
-	if app.txCount == 0 {
-		return types.ResponseCommit{}
+	app.height++
+	app.appHash = nil
+	if app.txCount > 0 {
+		app.appHash = make([]byte, 8)
+		binary.BigEndian.PutUint64(app.appHash, uint64(app.txCount))
+	}
+	app.saveState()
+	return types.ResponseCommit{Data: app.appHash}
+}
+
+func (app *Application) saveState() {
+	if app.db == nil {
+		return
+	}
+	bz, err := json.Marshal(state{
+		HashCount: app.hashCount,
+		TxCount:   app.txCount,
+		Height:    app.height,
+		AppHash:   app.appHash,
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err := app.db.SetSync(stateKey, bz); err != nil {
+		panic(err)
 	}
-	hash := make([]byte, 8)
-	binary.BigEndian.PutUint64(hash, uint64(app.txCount))
-	return types.ResponseCommit{Data: hash}
 }
 
+// Query returns the counter named by the path, or by the data if the path is
+// empty: "hash" for the number of commits, or "tx" for the number of txs.
 func (app *Application) Query(reqQuery types.RequestQuery) types.ResponseQuery {
-	switch reqQuery.Path {
+	key := reqQuery.Path
+	if key == "" {
+		key = string(reqQuery.Data)
+	}
+	switch key {
 	case "hash":
-		return types.ResponseQuery{Value: []byte(fmt.Sprintf("%v", app.hashCount))}
+		return types.ResponseQuery{
+			Key:    []byte(key),
+			Value:  []byte(fmt.Sprintf("%v", app.hashCount)),
+			Height: app.height,
+		}
 	case "tx":
-		return types.ResponseQuery{Value: []byte(fmt.Sprintf("%v", app.txCount))}
+		return types.ResponseQuery{
+			Key:    []byte(key),
+			Value:  []byte(fmt.Sprintf("%v", app.txCount)),
+			Height: app.height,
+		}
 	default:
-		return types.ResponseQuery{Log: fmt.Sprintf("Invalid query path. Expected hash or tx, got %v", reqQuery.Path)}
+		return types.ResponseQuery{
+			Code: code.CodeTypeUnknownError,
+			Log:  fmt.Sprintf("Invalid query path. Expected hash or tx, got %v", key),
+		}
 	}
 }
@@ -0,0 +1,45 @@
+package counter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/code"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestPersistentApplication(t *testing.T) {
+	db := dbm.NewMemDB()
+	app := NewPersistentApplication(true, db)
+
+	info := app.Info(types.RequestInfo{})
+	assert.EqualValues(t, 0, info.LastBlockHeight)
+	assert.Empty(t, info.LastBlockAppHash)
+
+	// an empty block, then a block of two txs
+	app.Commit()
+	require.Equal(t, code.CodeTypeOK, app.DeliverTx(types.RequestDeliverTx{Tx: []byte{0x00}}).Code)
+	require.Equal(t, code.CodeTypeOK, app.DeliverTx(types.RequestDeliverTx{Tx: []byte{0x01}}).Code)
+	appHash := app.Commit().Data
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, appHash)
+
+	// the counters and the last block are restored after a restart
+	app = NewPersistentApplication(true, db)
+	info = app.Info(types.RequestInfo{})
+	assert.EqualValues(t, 2, info.LastBlockHeight)
+	assert.Equal(t, appHash, info.LastBlockAppHash)
+	assert.Equal(t, code.CodeTypeBadNonce, app.DeliverTx(types.RequestDeliverTx{Tx: []byte{0x01}}).Code)
+	assert.Equal(t, code.CodeTypeOK, app.DeliverTx(types.RequestDeliverTx{Tx: []byte{0x02}}).Code)
+
+	res := app.Query(types.RequestQuery{Data: []byte("hash")})
+	assert.Equal(t, code.CodeTypeOK, res.Code)
+	assert.Equal(t, []byte("2"), res.Value)
+	assert.EqualValues(t, 2, res.Height)
+	res = app.Query(types.RequestQuery{Path: "tx"})
+	assert.Equal(t, []byte("3"), res.Value)
+	res = app.Query(types.RequestQuery{Data: []byte("other")})
+	assert.NotEqual(t, code.CodeTypeOK, res.Code)
+}
@@ -59,6 +59,16 @@ func prefixKey(key []byte) []byte {
 	return append(kvPairPrefixKey, key...)
 }
 
+// parseTx returns the key and value set by a tx, which is either "key=value"
+// or just arbitrary bytes, used as both.
+func parseTx(tx []byte) (key, value []byte) {
+	parts := bytes.Split(tx, []byte("="))
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return tx, tx
+}
+
 //---------------------------------------------------
 
 var _ types.Application = (*Application)(nil)
@@ -87,13 +97,7 @@ func (app *Application) Info(req types.RequestInfo) (resInfo types.ResponseInfo)
 
 // tx is either "key=value" or just arbitrary bytes
 func (app *Application) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
-	var key, value []byte
-	parts := bytes.Split(req.Tx, []byte("="))
-	if len(parts) == 2 {
-		key, value = parts[0], parts[1]
-	} else {
-		key, value = req.Tx, req.Tx
-	}
+	key, value := parseTx(req.Tx)
 
 	err := app.state.db.Set(prefixKey(key), value)
 	if err != nil {
@@ -102,15 +106,12 @@ func (app *Application) DeliverTx(req types.RequestDeliverTx) types.ResponseDeli
 	app.state.Size++
 
 	events := []types.Event{
-		{
-			Type: "app",
-			Attributes: []types.EventAttribute{
-				{Key: []byte("creator"), Value: []byte("Cosmoshi Netowoko"), Index: true},
-				{Key: []byte("key"), Value: key, Index: true},
-				{Key: []byte("index_key"), Value: []byte("index is working"), Index: true},
-				{Key: []byte("noindex_key"), Value: []byte("index is working"), Index: false},
-			},
-		},
+		types.NewEvent("app",
+			types.NewAttribute("creator", "Cosmoshi Netowoko"),
+			types.NewAttribute("key", string(key)),
+			types.NewAttribute("index_key", "index is working"),
+			types.NewUnindexedAttribute("noindex_key", "index is working"),
+		),
 	}
 
 	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Events: events}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -116,6 +117,45 @@ func TestPersistentKVStoreInfo(t *testing.T) {
 	}
 }
 
+func TestPersistentKVStoreHistoricalQuery(t *testing.T) {
+	dir, err := os.MkdirTemp("", "abci-kvstore-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	kvstore := NewPersistentKVStoreApplication(dir)
+	InitKVStore(kvstore)
+
+	// the value of "abc" is "1" at height 1, unchanged at 2, and "3" at 3
+	blocks := [][][]byte{{[]byte("abc=1")}, {[]byte("def")}, {[]byte("abc=3")}}
+	for i, txs := range blocks {
+		header := tmproto.Header{Height: int64(i + 1)}
+		kvstore.BeginBlock(types.RequestBeginBlock{Header: header})
+		for _, tx := range txs {
+			require.True(t, kvstore.DeliverTx(types.RequestDeliverTx{Tx: tx}).IsOK())
+		}
+		kvstore.EndBlock(types.RequestEndBlock{Height: header.Height})
+		kvstore.Commit()
+	}
+
+	for height, value := range map[int64]string{0: "3", 1: "1", 2: "1", 3: "3"} {
+		res := kvstore.Query(types.RequestQuery{Data: []byte("abc"), Height: height})
+		require.True(t, res.IsOK(), "height %d", height)
+		assert.Equal(t, value, string(res.Value), "height %d", height)
+	}
+	res := kvstore.Query(types.RequestQuery{Data: []byte("def"), Height: 1})
+	assert.Nil(t, res.Value)
+	assert.Equal(t, "does not exist", res.Log)
+	res = kvstore.Query(types.RequestQuery{Data: []byte("abc"), Height: 4})
+	assert.False(t, res.IsOK())
+
+	// the values are returned without proof
+	for _, height := range []int64{0, 1} {
+		res = kvstore.Query(types.RequestQuery{Data: []byte("abc"), Height: height, Prove: true})
+		require.True(t, res.IsOK(), "height %d", height)
+		assert.Nil(t, res.ProofOps, "height %d", height)
+		assert.Contains(t, res.Log, "proofs are not supported", "height %d", height)
+	}
+}
+
 // add a validator, remove a validator, update a validator
 func TestValUpdates(t *testing.T) {
 	dir, err := os.MkdirTemp("/tmp", "abci-kvstore-test") // TODO
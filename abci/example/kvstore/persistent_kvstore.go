@@ -3,6 +3,7 @@ package kvstore
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"strconv"
 	"strings"
@@ -20,6 +21,8 @@ const (
 	ValidatorSetChangePrefix string = "val:"
 )
 
+var kvHistoryPrefixKey = []byte("kvHistory:")
+
 //-----------------------------------------
 
 var _ types.Application = (*PersistentKVStoreApplication)(nil)
@@ -77,7 +80,17 @@ func (app *PersistentKVStoreApplication) DeliverTx(req types.RequestDeliverTx) t
 	}
 
 	// otherwise, update the key-value store
-	return app.app.DeliverTx(req)
+	res := app.app.DeliverTx(req)
+	if res.IsOK() {
+		// and record the value as of the block being executed. Like Info, the
+		// history counts heights from 1, so they are the heights of the chain
+		// only if its initial_height is 1.
+		key, value := parseTx(req.Tx)
+		if err := app.app.state.db.Set(historyKey(key, app.app.state.Height+1), value); err != nil {
+			panic(err)
+		}
+	}
+	return res
 }
 
 func (app *PersistentKVStoreApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
@@ -90,7 +103,9 @@ func (app *PersistentKVStoreApplication) Commit() types.ResponseCommit {
 }
 
 // When path=/val and data={validator address}, returns the validator update (types.ValidatorUpdate) varint encoded.
-// For any other path, returns an associated value or nil if missing.
+// For any other path, returns an associated value or nil if missing, as of the
+// given height if any. Proofs are not supported: the Log of the response says
+// so if one is requested.
 func (app *PersistentKVStoreApplication) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
 	switch reqQuery.Path {
 	case "/val":
@@ -104,8 +119,61 @@ func (app *PersistentKVStoreApplication) Query(reqQuery types.RequestQuery) (res
 		resQuery.Value = value
 		return
 	default:
-		return app.app.Query(reqQuery)
+		if reqQuery.Height != 0 && reqQuery.Height != app.app.state.Height {
+			resQuery = app.queryHistory(reqQuery)
+		} else {
+			resQuery = app.app.Query(reqQuery)
+		}
+		if reqQuery.Prove && resQuery.IsOK() {
+			resQuery.Log += " (no proof: proofs are not supported)"
+		}
+		return resQuery
+	}
+}
+
+// queryHistory returns the value of a key as of a past height. Values set
+// before the history was recorded are not found. The heights are those of the
+// app, which are the heights of the chain only if its initial_height is 1.
+func (app *PersistentKVStoreApplication) queryHistory(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
+	resQuery.Key = reqQuery.Data
+	resQuery.Height = reqQuery.Height
+	if reqQuery.Height < 0 || reqQuery.Height > app.app.state.Height {
+		resQuery.Code = code.CodeTypeUnknownError
+		resQuery.Log = fmt.Sprintf("height %d is not between 1 and the latest height %d",
+			reqQuery.Height, app.app.state.Height)
+		return
 	}
+
+	// the latest value recorded up to the height
+	it, err := app.app.state.db.ReverseIterator(historyKey(reqQuery.Data, 0), historyKey(reqQuery.Data, reqQuery.Height+1))
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+	if it.Valid() {
+		resQuery.Value = it.Value()
+		resQuery.Log = "exists"
+	} else {
+		resQuery.Log = "does not exist"
+	}
+	if err := it.Error(); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// historyKey is the key of the value of key set at height: the prefix, the
+// length of key and key, so that no key is a prefix of another, and the big
+// endian height.
+func historyKey(key []byte, height int64) []byte {
+	bz := make([]byte, 0, len(kvHistoryPrefixKey)+4+len(key)+8)
+	bz = append(bz, kvHistoryPrefixKey...)
+	bz = append(bz, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(bz[len(kvHistoryPrefixKey):], uint32(len(key)))
+	bz = append(bz, key...)
+	bz = append(bz, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(bz[len(bz)-8:], uint64(height))
+	return bz
 }
 
 // Save the validators in the merkle tree
@@ -2,6 +2,7 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -55,7 +56,18 @@ func (s *GRPCServer) OnStart() error {
 	return nil
 }
 
-// OnStop stops the gRPC server.
+// OnStop stops the gRPC server, letting the pending calls complete for up to
+// shutdownTimeout.
 func (s *GRPCServer) OnStop() {
-	s.server.Stop()
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.Logger.Error("Timed out waiting for pending calls, stopping gRPC server")
+		s.server.Stop()
+	}
 }
@@ -2,11 +2,14 @@ package server
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/tendermint/tendermint/abci/types"
 	tmlog "github.com/tendermint/tendermint/libs/log"
@@ -15,37 +18,70 @@ import (
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 )
 
-// var maxNumberConnections = 2
+// shutdownTimeout is how long OnStop waits for the connections to drain before
+// closing them.
+const shutdownTimeout = 5 * time.Second
 
+// SocketServer serves an application over the socket protocol. Each connection,
+// e.g. the consensus, mempool, query and snapshot connections of Tendermint,
+// is served by its own routines, while the calls into the application are
+// serialized.
 type SocketServer struct {
 	service.BaseService
 	isLoggerSet bool
 
-	proto    string
-	addr     string
-	listener net.Listener
+	proto          string
+	addr           string
+	listener       net.Listener
+	maxConnections int
 
 	connsMtx   tmsync.Mutex
-	conns      map[int]net.Conn
+	conns      map[int]*socketConn
 	nextConnID int
+	// the routines accepting and serving connections, waited for by OnStop
+	routines sync.WaitGroup
 
 	appMtx tmsync.Mutex
 	app    types.Application
 }
 
-func NewSocketServer(protoAddr string, app types.Application) service.Service {
+// SocketServerOption sets an optional parameter on the SocketServer.
+type SocketServerOption func(*SocketServer)
+
+// WithMaxConnections sets the maximum number of connections served at once.
+// The connections beyond it are sent an exception and closed. 0 means no
+// limit, the default.
+func WithMaxConnections(n int) SocketServerOption {
+	return func(s *SocketServer) { s.maxConnections = n }
+}
+
+func NewSocketServer(protoAddr string, app types.Application, options ...SocketServerOption) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &SocketServer{
 		proto:    proto,
 		addr:     addr,
 		listener: nil,
 		app:      app,
-		conns:    make(map[int]net.Conn),
+		conns:    make(map[int]*socketConn),
+	}
+	for _, option := range options {
+		option(s)
 	}
 	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
 	return s
 }
 
+// socketConn is the state of a connection, shared by the routines serving it.
+type socketConn struct {
+	id   int
+	conn net.Conn
+
+	closeConn     chan error           // Push to signal connection closed
+	responses     chan *types.Response // A channel to buffer responses
+	requestsDone  chan struct{}        // Closed once no more responses are pushed
+	responsesDone chan struct{}        // Closed once the responses are written
+}
+
 func (s *SocketServer) SetLogger(l tmlog.Logger) {
 	s.BaseService.SetLogger(l)
 	s.isLoggerSet = true
@@ -58,35 +94,78 @@ func (s *SocketServer) OnStart() error {
 	}
 
 	s.listener = ln
+	s.routines.Add(1)
 	go s.acceptConnectionsRoutine()
 
 	return nil
 }
 
+// OnStop stops accepting connections and drains those open: the requests read
+// already are served, their responses are flushed to the client followed by an
+// exception, and the connections are closed. Connections which are not done
+// draining after shutdownTimeout are closed abruptly.
 func (s *SocketServer) OnStop() {
 	if err := s.listener.Close(); err != nil {
 		s.Logger.Error("Error closing listener", "err", err)
 	}
 
+	// stop reading requests, see handleRequests
 	s.connsMtx.Lock()
-	defer s.connsMtx.Unlock()
-	for id, conn := range s.conns {
-		delete(s.conns, id)
-		if err := conn.Close(); err != nil {
-			s.Logger.Error("Error closing connection", "id", id, "conn", conn, "err", err)
+	for id, c := range s.conns {
+		if err := c.conn.SetReadDeadline(time.Now()); err != nil {
+			s.Logger.Error("Error interrupting connection", "id", id, "err", err)
+		}
+	}
+	s.connsMtx.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		s.routines.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(shutdownTimeout):
+		s.Logger.Error("Timed out draining connections, closing them")
+		s.connsMtx.Lock()
+		defer s.connsMtx.Unlock()
+		for id, c := range s.conns {
+			delete(s.conns, id)
+			if err := c.conn.Close(); err != nil {
+				s.Logger.Error("Error closing connection", "id", id, "conn", c.conn, "err", err)
+			}
 		}
 	}
 }
 
-func (s *SocketServer) addConn(conn net.Conn) int {
+// addConn registers a connection to serve. It returns an error if the server
+// is stopping or serves the maximum number of connections already.
+func (s *SocketServer) addConn(conn net.Conn) (*socketConn, error) {
 	s.connsMtx.Lock()
 	defer s.connsMtx.Unlock()
 
-	connID := s.nextConnID
+	// OnStop interrupts the connections registered before it, and those
+	// accepted since are not served.
+	if !s.IsRunning() {
+		return nil, errors.New("ABCI server is shutting down")
+	}
+	if s.maxConnections > 0 && len(s.conns) >= s.maxConnections {
+		return nil, fmt.Errorf("ABCI server serves the maximum of %d connections already", s.maxConnections)
+	}
+
+	c := &socketConn{
+		id:            s.nextConnID,
+		conn:          conn,
+		closeConn:     make(chan error, 2),
+		responses:     make(chan *types.Response, 1000),
+		requestsDone:  make(chan struct{}),
+		responsesDone: make(chan struct{}),
+	}
 	s.nextConnID++
-	s.conns[connID] = conn
+	s.conns[c.id] = c
+	s.routines.Add(1)
 
-	return connID
+	return c, nil
 }
 
 // deletes conn even if close errs
@@ -94,16 +173,18 @@ func (s *SocketServer) rmConn(connID int) error {
 	s.connsMtx.Lock()
 	defer s.connsMtx.Unlock()
 
-	conn, ok := s.conns[connID]
+	c, ok := s.conns[connID]
 	if !ok {
 		return fmt.Errorf("connection %d does not exist", connID)
 	}
 
 	delete(s.conns, connID)
-	return conn.Close()
+	return c.conn.Close()
 }
 
 func (s *SocketServer) acceptConnectionsRoutine() {
+	defer s.routines.Done()
+
 	for {
 		// Accept a connection
 		s.Logger.Info("Waiting for new connection...")
@@ -116,45 +197,81 @@ func (s *SocketServer) acceptConnectionsRoutine() {
 			continue
 		}
 
-		s.Logger.Info("Accepted a new connection")
-
-		connID := s.addConn(conn)
-
-		closeConn := make(chan error, 2)              // Push to signal connection closed
-		responses := make(chan *types.Response, 1000) // A channel to buffer responses
+		c, err := s.addConn(conn)
+		if err != nil {
+			s.Logger.Error("Rejected a new connection", "err", err)
+			rejectConn(conn, err)
+			if !s.IsRunning() {
+				return
+			}
+			continue
+		}
+		s.Logger.Info("Accepted a new connection", "id", c.id)
 
 		// Read requests from conn and deal with them
-		go s.handleRequests(closeConn, conn, responses)
+		go func() {
+			defer close(c.requestsDone)
+			s.handleRequests(c)
+		}()
 		// Pull responses from 'responses' and write them to conn.
-		go s.handleResponses(closeConn, conn, responses)
+		go func() {
+			defer close(c.responsesDone)
+			s.handleResponses(c)
+		}()
 
 		// Wait until signal to close connection
-		go s.waitForClose(closeConn, connID)
+		go s.waitForClose(c)
 	}
 }
 
-func (s *SocketServer) waitForClose(closeConn chan error, connID int) {
-	err := <-closeConn
+// rejectConn sends an exception explaining why a connection is not served,
+// and closes it.
+func rejectConn(conn net.Conn, reason error) {
+	// the client may not read it, so do not wait for long
+	_ = conn.SetWriteDeadline(time.Now().Add(time.Second))
+	_ = types.WriteMessage(types.ToResponseException(reason.Error()), conn)
+	conn.Close()
+}
+
+func (s *SocketServer) waitForClose(c *socketConn) {
+	defer s.routines.Done()
+
+	err := <-c.closeConn
 	switch {
+	case !s.IsRunning():
+		s.Logger.Info("Connection was closed by server shutdown", "id", c.id)
 	case err == io.EOF:
-		s.Logger.Error("Connection was closed by client")
+		s.Logger.Error("Connection was closed by client", "id", c.id)
 	case err != nil:
-		s.Logger.Error("Connection error", "err", err)
+		s.Logger.Error("Connection error", "id", c.id, "err", err)
 	default:
 		// never happens
-		s.Logger.Error("Connection was closed")
+		s.Logger.Error("Connection was closed", "id", c.id)
 	}
 
-	// Close the connection
-	if err := s.rmConn(connID); err != nil {
-		s.Logger.Error("Error closing connection", "err", err)
+	// Close the connection once the responses are flushed, which also
+	// interrupts the routine reading requests if it is still running. OnStop
+	// may have closed it already.
+	<-c.responsesDone
+	if err := s.rmConn(c.id); err != nil && s.IsRunning() {
+		s.Logger.Error("Error closing connection", "id", c.id, "err", err)
+	}
+	// discard the responses the routine reading requests still pushes, so
+	// that it does not block on a full channel
+	for {
+		select {
+		case <-c.responses:
+		case <-c.requestsDone:
+			return
+		}
 	}
 }
 
-// Read requests from conn and deal with them
-func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, responses chan<- *types.Response) {
+// Read requests from conn and deal with them. A panic of the application is
+// answered with an exception, and closes the connection.
+func (s *SocketServer) handleRequests(c *socketConn) {
 	var count int
-	var bufReader = bufio.NewReader(conn)
+	var bufReader = bufio.NewReader(c.conn)
 
 	defer func() {
 		// make sure to recover from any app-related panics to allow proper socket cleanup
@@ -167,8 +284,8 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 			if !s.isLoggerSet {
 				fmt.Fprintln(os.Stderr, err)
 			}
-			closeConn <- err
-			s.appMtx.Unlock()
+			c.responses <- types.ToResponseException(fmt.Sprintf("recovered from panic: %v", r))
+			c.closeConn <- err
 		}
 	}()
 
@@ -178,20 +295,21 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 		err := types.ReadMessage(bufReader, req)
 		if err != nil {
 			if err == io.EOF {
-				closeConn <- err
+				c.closeConn <- err
 			} else {
-				closeConn <- fmt.Errorf("error reading message: %w", err)
+				c.closeConn <- fmt.Errorf("error reading message: %w", err)
 			}
 			return
 		}
-		s.appMtx.Lock()
 		count++
-		s.handleRequest(req, responses)
-		s.appMtx.Unlock()
+		s.handleRequest(req, c.responses)
 	}
 }
 
 func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types.Response) {
+	s.appMtx.Lock()
+	defer s.appMtx.Unlock()
+
 	switch r := req.Value.(type) {
 	case *types.Request_Echo:
 		responses <- types.ToResponseEcho(r.Echo.Message)
@@ -241,24 +359,55 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	}
 }
 
-// Pull responses from 'responses' and write them to conn.
-func (s *SocketServer) handleResponses(closeConn chan error, conn io.Writer, responses <-chan *types.Response) {
+// Pull responses from 'responses' and write them to conn, until requestsDone
+// is closed. The responses left are then written and flushed, followed by an
+// exception if the server is stopping.
+func (s *SocketServer) handleResponses(c *socketConn) {
 	var count int
-	var bufWriter = bufio.NewWriter(conn)
+	var bufWriter = bufio.NewWriter(c.conn)
 	for {
-		var res = <-responses
-		err := types.WriteMessage(res, bufWriter)
-		if err != nil {
-			closeConn <- fmt.Errorf("error writing message: %w", err)
-			return
-		}
-		if _, ok := res.Value.(*types.Response_Flush); ok {
-			err = bufWriter.Flush()
-			if err != nil {
-				closeConn <- fmt.Errorf("error flushing write buffer: %w", err)
+		select {
+		case res := <-c.responses:
+			if err := writeResponse(bufWriter, res); err != nil {
+				c.closeConn <- err
 				return
 			}
+			count++
+
+		case <-c.requestsDone:
+			// no more responses are pushed
+			for len(c.responses) > 0 {
+				if err := writeResponse(bufWriter, <-c.responses); err != nil {
+					c.closeConn <- err
+					return
+				}
+				count++
+			}
+			if !s.IsRunning() {
+				res := types.ToResponseException("ABCI server is shutting down")
+				if err := types.WriteMessage(res, bufWriter); err != nil {
+					c.closeConn <- fmt.Errorf("error writing message: %w", err)
+					return
+				}
+			}
+			if err := bufWriter.Flush(); err != nil {
+				c.closeConn <- fmt.Errorf("error flushing write buffer: %w", err)
+			}
+			return
 		}
-		count++
 	}
 }
+
+// writeResponse writes a response, and flushes the write buffer after a flush
+// response.
+func writeResponse(bufWriter *bufio.Writer, res *types.Response) error {
+	if err := types.WriteMessage(res, bufWriter); err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	if _, ok := res.Value.(*types.Response_Flush); ok {
+		if err := bufWriter.Flush(); err != nil {
+			return fmt.Errorf("error flushing write buffer: %w", err)
+		}
+	}
+	return nil
+}
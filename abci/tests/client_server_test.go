@@ -1,13 +1,18 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	abciserver "github.com/tendermint/tendermint/abci/server"
+	testsuite "github.com/tendermint/tendermint/abci/tests/server"
+	"github.com/tendermint/tendermint/abci/types"
 )
 
 func TestClientServerNoAddrPrefix(t *testing.T) {
@@ -25,3 +30,126 @@ func TestClientServerNoAddrPrefix(t *testing.T) {
 	err = client.Start()
 	assert.NoError(t, err, "expected no error on client.Start")
 }
+
+func TestConformance(t *testing.T) {
+	client := abciclient.NewLocalClient(nil, kvstore.NewApplication())
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, testsuite.Conformance(client, nil, []byte("abc"), &out), out.String())
+	assert.NotContains(t, out.String(), "Failed")
+
+	// an app whose Info does not report the last block fails
+	baseClient := abciclient.NewLocalClient(nil, types.NewBaseApplication())
+	require.NoError(t, baseClient.Start())
+	t.Cleanup(func() {
+		if err := baseClient.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	out.Reset()
+	require.Error(t, testsuite.Conformance(baseClient, nil, []byte("abc"), &out))
+	assert.Contains(t, out.String(), "Failed test: Info after Commit")
+}
+
+// heightApp changes its app hash on every block, empty or not, as apps
+// counting blocks or minting rewards in BeginBlock do.
+type heightApp struct {
+	types.BaseApplication
+	height int64
+}
+
+func (app *heightApp) Info(types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{LastBlockHeight: app.height, LastBlockAppHash: app.appHash()}
+}
+
+func (app *heightApp) Commit() types.ResponseCommit {
+	app.height++
+	return types.ResponseCommit{Data: app.appHash()}
+}
+
+func (app *heightApp) appHash() []byte {
+	appHash := make([]byte, 8)
+	binary.BigEndian.PutUint64(appHash, uint64(app.height))
+	return appHash
+}
+
+func TestConformanceEmptyBlockChangingAppHash(t *testing.T) {
+	client := abciclient.NewLocalClient(nil, &heightApp{})
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, testsuite.Conformance(client, nil, []byte("abc"), &out), out.String())
+	assert.NotContains(t, out.String(), "Failed")
+	assert.Contains(t, out.String(), "Note: the empty block changed the app hash")
+}
+
+// startClient starts a local client of app, stopped when the test ends.
+func startClient(t *testing.T, app types.Application) abciclient.Client {
+	t.Helper()
+	client := abciclient.NewLocalClient(nil, app)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return client
+}
+
+func TestConformanceReplica(t *testing.T) {
+	client := startClient(t, kvstore.NewApplication())
+	replica := startClient(t, kvstore.NewApplication())
+
+	var out bytes.Buffer
+	require.NoError(t, testsuite.Conformance(client, replica, []byte("abc"), &out), out.String())
+	assert.NotContains(t, out.String(), "Failed")
+	assert.Contains(t, out.String(), "Passed test: Determinism at height 1")
+	assert.Contains(t, out.String(), "Passed test: Determinism at height 2")
+
+	// a replica which is not at the same state is not used
+	out.Reset()
+	require.Error(t, testsuite.Conformance(startClient(t, kvstore.NewApplication()), replica, []byte("abc"), &out))
+	assert.Contains(t, out.String(), "Failed test: Replica Info")
+	assert.NotContains(t, out.String(), "Determinism")
+}
+
+// saltedApp commits app hashes depending on its salt, as an app whose state
+// depends on the node running it, e.g. on the local time, would.
+type saltedApp struct {
+	types.BaseApplication
+	salt    byte
+	height  int64
+	appHash []byte
+}
+
+func (app *saltedApp) Info(types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{LastBlockHeight: app.height, LastBlockAppHash: app.appHash}
+}
+
+func (app *saltedApp) Commit() types.ResponseCommit {
+	app.height++
+	app.appHash = []byte{app.salt, byte(app.height)}
+	return types.ResponseCommit{Data: app.appHash}
+}
+
+func TestConformanceNonDeterministic(t *testing.T) {
+	client := startClient(t, &saltedApp{salt: 1})
+	replica := startClient(t, &saltedApp{salt: 2})
+
+	var out bytes.Buffer
+	require.Error(t, testsuite.Conformance(client, replica, []byte("abc"), &out))
+	assert.Contains(t, out.String(), "Passed test: Replica Info")
+	assert.Contains(t, out.String(), "Failed test: Determinism at height 1")
+}
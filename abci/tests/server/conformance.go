@@ -0,0 +1,171 @@
+package testsuite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// Conformance runs a sequence of calls against the application, as Tendermint
+// would make them, and checks the assertions of the ABCI spec which hold for
+// any application: echo, info reporting the last committed block, a block with
+// tx committed, the tx queried, and an empty block committed. Whether the
+// empty block changed the app hash is only reported, since applications may
+// change their state in BeginBlock or EndBlock.
+//
+// If replica is not nil, it must be a client of another instance of the
+// application, with the same state. The blocks are executed against it too,
+// and it must commit the same app hashes, as the nodes of a network do.
+//
+// The result of each assertion is written to w. Conformance returns an error
+// if any of them failed.
+//
+// NOTE: the applications must not be used by a node, since their state is
+// committed.
+func Conformance(client, replica abcicli.Client, tx []byte, w io.Writer) error {
+	c := &conformance{client: client, w: w}
+
+	res, err := client.EchoSync("hello")
+	c.check("Echo", err, func() error {
+		if res.Message != "hello" {
+			return fmt.Errorf("echoed %q, expected %q", res.Message, "hello")
+		}
+		return nil
+	})
+
+	info, err := client.InfoSync(types.RequestInfo{})
+	if !c.check("Info", err, nil) {
+		return c.result()
+	}
+	height := info.LastBlockHeight
+
+	if replica != nil {
+		replicaInfo, err := replica.InfoSync(types.RequestInfo{})
+		if c.check("Replica Info", err, func() error {
+			if replicaInfo.LastBlockHeight != height || !bytes.Equal(replicaInfo.LastBlockAppHash, info.LastBlockAppHash) {
+				return fmt.Errorf("the replica is at height %d with app hash %X, expected height %d with app hash %X",
+					replicaInfo.LastBlockHeight, replicaInfo.LastBlockAppHash, height, info.LastBlockAppHash)
+			}
+			return nil
+		}) {
+			c.replica = replica
+		}
+	}
+
+	appHash, ok := c.commitBlock(height+1, [][]byte{tx})
+	if !ok {
+		return c.result()
+	}
+	height++
+
+	info, err = client.InfoSync(types.RequestInfo{})
+	c.check("Info after Commit", err, func() error {
+		if info.LastBlockHeight != height {
+			return fmt.Errorf("last block height is %d, expected %d", info.LastBlockHeight, height)
+		}
+		if !bytes.Equal(info.LastBlockAppHash, appHash) {
+			return fmt.Errorf("last block app hash is %X, expected the hash %X returned by Commit",
+				info.LastBlockAppHash, appHash)
+		}
+		return nil
+	})
+
+	query, err := client.QuerySync(types.RequestQuery{Data: tx})
+	c.check("Query", err, func() error {
+		if query.Height > height {
+			return fmt.Errorf("query height %d is above the last block height %d", query.Height, height)
+		}
+		return nil
+	})
+
+	emptyAppHash, ok := c.commitBlock(height+1, nil)
+	if ok && !bytes.Equal(emptyAppHash, appHash) {
+		fmt.Fprintf(c.w, "Note: the empty block changed the app hash from %X to %X\n", appHash, emptyAppHash)
+	}
+
+	return c.result()
+}
+
+type conformance struct {
+	client  abcicli.Client
+	replica abcicli.Client // nil if there is none, or it is not usable
+	w       io.Writer
+	failed  int
+}
+
+// check reports the assertion name as failed if err is not nil or assert
+// returns an error, and as passed otherwise.
+func (c *conformance) check(name string, err error, assert func() error) bool {
+	if err == nil && assert != nil {
+		err = assert()
+	}
+	if err != nil {
+		c.failed++
+		fmt.Fprintf(c.w, "Failed test: %s - %v\n", name, err)
+		return false
+	}
+	fmt.Fprintf(c.w, "Passed test: %s\n", name)
+	return true
+}
+
+// commitBlock executes a block of txs at height and commits it, returning the
+// app hash. The block is executed against the replica too, if any, which must
+// commit the same app hash.
+func (c *conformance) commitBlock(height int64, txs [][]byte) ([]byte, bool) {
+	appHash, ok := c.executeBlock(c.client, "", height, txs)
+	if !ok || c.replica == nil {
+		return appHash, ok
+	}
+
+	replicaAppHash, ok := c.executeBlock(c.replica, "Replica ", height, txs)
+	if !ok {
+		// the replica is behind from now on
+		c.replica = nil
+		return appHash, true
+	}
+	c.check(fmt.Sprintf("Determinism at height %d", height), nil, func() error {
+		if !bytes.Equal(replicaAppHash, appHash) {
+			return fmt.Errorf("the replica committed app hash %X, expected %X", replicaAppHash, appHash)
+		}
+		return nil
+	})
+	return appHash, true
+}
+
+// executeBlock executes a block of txs at height against client and commits
+// it, returning the app hash. The names of the assertions start with prefix.
+func (c *conformance) executeBlock(client abcicli.Client, prefix string, height int64, txs [][]byte) ([]byte, bool) {
+	_, err := client.BeginBlockSync(types.RequestBeginBlock{Header: tmproto.Header{Height: height}})
+	if !c.check(fmt.Sprintf("%sBeginBlock at height %d", prefix, height), err, nil) {
+		return nil, false
+	}
+	for _, tx := range txs {
+		res, err := client.DeliverTxSync(types.RequestDeliverTx{Tx: tx})
+		if !c.check(fmt.Sprintf("%sDeliverTx at height %d", prefix, height), err, nil) {
+			return nil, false
+		}
+		if res.IsErr() {
+			fmt.Fprintf(c.w, "  (DeliverTx returned code %d: %s)\n", res.Code, res.Log)
+		}
+	}
+	_, err = client.EndBlockSync(types.RequestEndBlock{Height: height})
+	if !c.check(fmt.Sprintf("%sEndBlock at height %d", prefix, height), err, nil) {
+		return nil, false
+	}
+	res, err := client.CommitSync()
+	if !c.check(fmt.Sprintf("%sCommit at height %d", prefix, height), err, nil) {
+		return nil, false
+	}
+	return res.Data, true
+}
+
+func (c *conformance) result() error {
+	if c.failed > 0 {
+		return fmt.Errorf("%d conformance tests failed", c.failed)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Events returned by DeliverTx, BeginBlock and EndBlock are indexed by the tx
+// and block indexers, and matched by the event subscriptions, under the
+// composite key "<event type>.<attribute key>", e.g. "transfer.sender". For an
+// event to be indexable, see ValidateEvent:
+//
+//   - its type, and the keys of its attributes, must not be empty;
+//   - its type, the keys and the values of its indexed attributes must not
+//     contain "/", which the kv indexer uses as a separator;
+//   - the composite keys "tx.hash" and "tx.height" are reserved.
+//
+// Values are strings. To be compared as numbers or times by queries, e.g.
+// "transfer.amount > 5", they must be formatted as NewIntAttribute and
+// NewTimeAttribute do.
+
+// NewEvent returns an event of the given type, whose attributes are indexed
+// under "<typ>.<key>".
+func NewEvent(typ string, attrs ...EventAttribute) Event {
+	return Event{Type: typ, Attributes: attrs}
+}
+
+// NewAttribute returns an attribute which is indexed.
+func NewAttribute(key, value string) EventAttribute {
+	return EventAttribute{Key: []byte(key), Value: []byte(value), Index: true}
+}
+
+// NewUnindexedAttribute returns an attribute which is emitted with the event,
+// but not indexed.
+func NewUnindexedAttribute(key, value string) EventAttribute {
+	return EventAttribute{Key: []byte(key), Value: []byte(value), Index: false}
+}
+
+// NewIntAttribute returns an indexed attribute with an integer value, which
+// queries compare numerically.
+func NewIntAttribute(key string, value int64) EventAttribute {
+	return NewAttribute(key, strconv.FormatInt(value, 10))
+}
+
+// NewTimeAttribute returns an indexed attribute with a time value, which
+// queries compare with TIME operands.
+func NewTimeAttribute(key string, value time.Time) EventAttribute {
+	return NewAttribute(key, value.UTC().Format(time.RFC3339))
+}
+
+// ValidateEvent returns an error if the event breaks the indexing contract
+// described above. Such events are not rejected, but may not be indexed or
+// found as expected.
+func ValidateEvent(event Event) error {
+	if event.Type == "" {
+		return errors.New("empty event type")
+	}
+	if strings.Contains(event.Type, "/") {
+		return fmt.Errorf("event type %q contains \"/\"", event.Type)
+	}
+	for _, attr := range event.Attributes {
+		key := string(attr.Key)
+		if key == "" {
+			return fmt.Errorf("empty attribute key in event %q", event.Type)
+		}
+		if !attr.Index {
+			continue
+		}
+		switch composite := event.Type + "." + key; {
+		case composite == "tx.hash" || composite == "tx.height":
+			return fmt.Errorf("reserved composite key %q", composite)
+		case strings.Contains(key, "/"):
+			return fmt.Errorf("attribute key %q contains \"/\"", composite)
+		case strings.Contains(string(attr.Value), "/"):
+			return fmt.Errorf("value of attribute %q contains \"/\"", composite)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBuilders(t *testing.T) {
+	ev := NewEvent("transfer",
+		NewAttribute("sender", "alice"),
+		NewIntAttribute("amount", -10),
+		NewTimeAttribute("at", time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("", 3600))),
+		NewUnindexedAttribute("memo", "a/b"),
+	)
+	assert.Equal(t, Event{
+		Type: "transfer",
+		Attributes: []EventAttribute{
+			{Key: []byte("sender"), Value: []byte("alice"), Index: true},
+			{Key: []byte("amount"), Value: []byte("-10"), Index: true},
+			{Key: []byte("at"), Value: []byte("2020-01-02T02:04:05Z"), Index: true},
+			{Key: []byte("memo"), Value: []byte("a/b"), Index: false},
+		},
+	}, ev)
+	assert.NoError(t, ValidateEvent(ev))
+}
+
+func TestValidateEvent(t *testing.T) {
+	testCases := map[string]Event{
+		"empty type":         NewEvent("", NewAttribute("key", "value")),
+		"slash in type":      NewEvent("a/b", NewAttribute("key", "value")),
+		"empty key":          NewEvent("app", NewUnindexedAttribute("", "value")),
+		"slash in key":       NewEvent("app", NewAttribute("a/b", "value")),
+		"slash in value":     NewEvent("app", NewAttribute("key", "a/b")),
+		"reserved tx.hash":   NewEvent("tx", NewAttribute("hash", "value")),
+		"reserved tx.height": NewEvent("tx", NewIntAttribute("height", 1)),
+	}
+	for name, ev := range testCases {
+		assert.Error(t, ValidateEvent(ev), name)
+	}
+}
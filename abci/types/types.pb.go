@@ -830,6 +830,13 @@ func (m *RequestBeginBlock) GetByzantineValidators() []Evidence {
 type RequestCheckTx struct {
 	Tx   []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=tendermint.abci.CheckTxType" json:"type,omitempty"`
+	// trace_id identifies the external request (e.g. an RPC call) which caused
+	// this transaction to be checked. It is empty for txs received via p2p.
+	TraceId string `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// local is true if the transaction was submitted to this node (e.g. via
+	// RPC), and false if it was received from a peer. Rechecks keep the origin
+	// of the first check.
+	Local bool `protobuf:"varint,4,opt,name=local,proto3" json:"local,omitempty"`
 }
 
 func (m *RequestCheckTx) Reset()         { *m = RequestCheckTx{} }
@@ -879,6 +886,20 @@ func (m *RequestCheckTx) GetType() CheckTxType {
 	return CheckTxType_New
 }
 
+func (m *RequestCheckTx) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+func (m *RequestCheckTx) GetLocal() bool {
+	if m != nil {
+		return m.Local
+	}
+	return false
+}
+
 type RequestDeliverTx struct {
 	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 }
@@ -3225,181 +3246,182 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2782 bytes of a gzipped FileDescriptorProto
+	// 2798 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x77, 0x23, 0xc5,
-	0xf5, 0xd7, 0x5b, 0xea, 0x2b, 0xeb, 0xe1, 0x1a, 0x33, 0x08, 0x31, 0xd8, 0x43, 0x73, 0xe0, 0x0f,
-	0x03, 0xd8, 0x7f, 0xcc, 0x81, 0x40, 0x20, 0x01, 0x4b, 0x68, 0x90, 0xb1, 0xb1, 0x9c, 0xb6, 0x66,
-	0xc8, 0x8b, 0x69, 0x5a, 0xea, 0xb2, 0xd4, 0x8c, 0xd4, 0xdd, 0x74, 0x97, 0x8c, 0xc5, 0x32, 0x8f,
-	0x0d, 0xd9, 0x90, 0x5d, 0x36, 0x7c, 0x8f, 0xac, 0xb2, 0xc9, 0x86, 0x73, 0xb2, 0x61, 0x99, 0x45,
-	0x0e, 0xc9, 0x99, 0x39, 0xd9, 0xe4, 0x0b, 0x64, 0x95, 0x93, 0x9c, 0x7a, 0xf4, 0x4b, 0x52, 0x4b,
-	0x32, 0x64, 0x97, 0x5d, 0xd5, 0xed, 0x7b, 0x6f, 0xab, 0xaa, 0xeb, 0xfe, 0xee, 0xef, 0xde, 0x12,
-	0x3c, 0x4e, 0xb0, 0xa9, 0x63, 0x67, 0x6c, 0x98, 0x64, 0x4f, 0xeb, 0xf5, 0x8d, 0x3d, 0x32, 0xb5,
-	0xb1, 0xbb, 0x6b, 0x3b, 0x16, 0xb1, 0x50, 0x25, 0x78, 0xb8, 0x4b, 0x1f, 0xd6, 0x9f, 0x08, 0x69,
-	0xf7, 0x9d, 0xa9, 0x4d, 0xac, 0x3d, 0xdb, 0xb1, 0xac, 0x73, 0xae, 0x5f, 0xbf, 0x11, 0x7a, 0xcc,
-	0xfc, 0x84, 0xbd, 0x45, 0x9e, 0x0a, 0xe3, 0xfb, 0x78, 0xea, 0x3d, 0x7d, 0x62, 0xce, 0xd6, 0xd6,
-	0x1c, 0x6d, 0xec, 0x3d, 0xde, 0x19, 0x58, 0xd6, 0x60, 0x84, 0xf7, 0xd8, 0xac, 0x37, 0x39, 0xdf,
-	0x23, 0xc6, 0x18, 0xbb, 0x44, 0x1b, 0xdb, 0x42, 0x61, 0x6b, 0x60, 0x0d, 0x2c, 0x36, 0xdc, 0xa3,
-	0x23, 0x2e, 0x95, 0x7f, 0x5b, 0x80, 0xbc, 0x82, 0x3f, 0x99, 0x60, 0x97, 0xa0, 0x7d, 0xc8, 0xe0,
-	0xfe, 0xd0, 0xaa, 0x25, 0x6f, 0x26, 0x9f, 0x2d, 0xee, 0xdf, 0xd8, 0x9d, 0x59, 0xdc, 0xae, 0xd0,
-	0x6b, 0xf5, 0x87, 0x56, 0x3b, 0xa1, 0x30, 0x5d, 0xf4, 0x0a, 0x64, 0xcf, 0x47, 0x13, 0x77, 0x58,
-	0x4b, 0x31, 0xa3, 0x27, 0xe2, 0x8c, 0x6e, 0x53, 0xa5, 0x76, 0x42, 0xe1, 0xda, 0xf4, 0x55, 0x86,
-	0x79, 0x6e, 0xd5, 0xd2, 0xcb, 0x5f, 0x75, 0x68, 0x9e, 0xb3, 0x57, 0x51, 0x5d, 0xd4, 0x00, 0x70,
-	0x31, 0x51, 0x2d, 0x9b, 0x18, 0x96, 0x59, 0xcb, 0x30, 0xcb, 0x27, 0xe3, 0x2c, 0xcf, 0x30, 0xe9,
-	0x30, 0xc5, 0x76, 0x42, 0x91, 0x5c, 0x6f, 0x42, 0x7d, 0x18, 0xa6, 0x41, 0xd4, 0xfe, 0x50, 0x33,
-	0xcc, 0x5a, 0x76, 0xb9, 0x8f, 0x43, 0xd3, 0x20, 0x4d, 0xaa, 0x48, 0x7d, 0x18, 0xde, 0x84, 0x2e,
-	0xf9, 0x93, 0x09, 0x76, 0xa6, 0xb5, 0xdc, 0xf2, 0x25, 0xff, 0x88, 0x2a, 0xd1, 0x25, 0x33, 0x6d,
-	0xd4, 0x82, 0x62, 0x0f, 0x0f, 0x0c, 0x53, 0xed, 0x8d, 0xac, 0xfe, 0xfd, 0x5a, 0x9e, 0x19, 0xcb,
-	0x71, 0xc6, 0x0d, 0xaa, 0xda, 0xa0, 0x9a, 0xed, 0x84, 0x02, 0x3d, 0x7f, 0x86, 0xde, 0x84, 0x42,
-	0x7f, 0x88, 0xfb, 0xf7, 0x55, 0x72, 0x59, 0x2b, 0x30, 0x1f, 0x3b, 0x71, 0x3e, 0x9a, 0x54, 0xaf,
-	0x7b, 0xd9, 0x4e, 0x28, 0xf9, 0x3e, 0x1f, 0xd2, 0xf5, 0xeb, 0x78, 0x64, 0x5c, 0x60, 0x87, 0xda,
-	0x4b, 0xcb, 0xd7, 0xff, 0x0e, 0xd7, 0x64, 0x1e, 0x24, 0xdd, 0x9b, 0xa0, 0xb7, 0x40, 0xc2, 0xa6,
-	0x2e, 0x96, 0x01, 0xcc, 0xc5, 0xcd, 0xd8, 0xb3, 0x62, 0xea, 0xde, 0x22, 0x0a, 0x58, 0x8c, 0xd1,
-	0x6b, 0x90, 0xeb, 0x5b, 0xe3, 0xb1, 0x41, 0x6a, 0x45, 0x66, 0xbd, 0x1d, 0xbb, 0x00, 0xa6, 0xd5,
-	0x4e, 0x28, 0x42, 0x1f, 0x9d, 0x40, 0x79, 0x64, 0xb8, 0x44, 0x75, 0x4d, 0xcd, 0x76, 0x87, 0x16,
-	0x71, 0x6b, 0x1b, 0xcc, 0xc3, 0xd3, 0x71, 0x1e, 0x8e, 0x0d, 0x97, 0x9c, 0x79, 0xca, 0xed, 0x84,
-	0x52, 0x1a, 0x85, 0x05, 0xd4, 0x9f, 0x75, 0x7e, 0x8e, 0x1d, 0xdf, 0x61, 0xad, 0xb4, 0xdc, 0x5f,
-	0x87, 0x6a, 0x7b, 0xf6, 0xd4, 0x9f, 0x15, 0x16, 0xa0, 0x9f, 0xc1, 0xb5, 0x91, 0xa5, 0xe9, 0xbe,
-	0x3b, 0xb5, 0x3f, 0x9c, 0x98, 0xf7, 0x6b, 0x65, 0xe6, 0xf4, 0xb9, 0xd8, 0x1f, 0x69, 0x69, 0xba,
-	0xe7, 0xa2, 0x49, 0x0d, 0xda, 0x09, 0x65, 0x73, 0x34, 0x2b, 0x44, 0xf7, 0x60, 0x4b, 0xb3, 0xed,
-	0xd1, 0x74, 0xd6, 0x7b, 0x85, 0x79, 0xbf, 0x15, 0xe7, 0xfd, 0x80, 0xda, 0xcc, 0xba, 0x47, 0xda,
-	0x9c, 0xb4, 0x91, 0x87, 0xec, 0x85, 0x36, 0x9a, 0x60, 0xf9, 0xff, 0xa0, 0x18, 0x0a, 0x75, 0x54,
-	0x83, 0xfc, 0x18, 0xbb, 0xae, 0x36, 0xc0, 0x0c, 0x19, 0x24, 0xc5, 0x9b, 0xca, 0x65, 0xd8, 0x08,
-	0x87, 0xb7, 0x3c, 0xf6, 0x0d, 0x69, 0xe0, 0x52, 0xc3, 0x0b, 0xec, 0xb8, 0x34, 0x5a, 0x85, 0xa1,
-	0x98, 0xa2, 0xa7, 0xa0, 0xc4, 0x8e, 0x8f, 0xea, 0x3d, 0xa7, 0xe8, 0x91, 0x51, 0x36, 0x98, 0xf0,
-	0xae, 0x50, 0xda, 0x81, 0xa2, 0xbd, 0x6f, 0xfb, 0x2a, 0x69, 0xa6, 0x02, 0xf6, 0xbe, 0x2d, 0x14,
-	0xe4, 0xef, 0x43, 0x75, 0x36, 0xda, 0x51, 0x15, 0xd2, 0xf7, 0xf1, 0x54, 0xbc, 0x8f, 0x0e, 0xd1,
-	0x96, 0x58, 0x16, 0x7b, 0x87, 0xa4, 0x88, 0x35, 0xfe, 0x29, 0xe5, 0x1b, 0xfb, 0x61, 0x8e, 0x5e,
-	0x83, 0x0c, 0x45, 0x4d, 0x01, 0x80, 0xf5, 0x5d, 0x0e, 0xa9, 0xbb, 0x1e, 0xa4, 0xee, 0x76, 0x3d,
-	0x48, 0x6d, 0x14, 0xbe, 0xfa, 0x66, 0x27, 0xf1, 0xc5, 0x5f, 0x77, 0x92, 0x0a, 0xb3, 0x40, 0x8f,
-	0xd1, 0xa8, 0xd4, 0x0c, 0x53, 0x35, 0x74, 0xf1, 0x9e, 0x3c, 0x9b, 0x1f, 0xea, 0xe8, 0x08, 0xaa,
-	0x7d, 0xcb, 0x74, 0xb1, 0xe9, 0x4e, 0x5c, 0x95, 0x43, 0xb6, 0x80, 0xbd, 0xf9, 0xa8, 0x69, 0x7a,
-	0x8a, 0xa7, 0x4c, 0x4f, 0xa9, 0xf4, 0xa3, 0x02, 0x74, 0x1b, 0xe0, 0x42, 0x1b, 0x19, 0xba, 0x46,
-	0x2c, 0xc7, 0xad, 0x65, 0x6e, 0xa6, 0x17, 0xba, 0xb9, 0xeb, 0xa9, 0xdc, 0xb1, 0x75, 0x8d, 0xe0,
-	0x46, 0x86, 0xfe, 0x5a, 0x25, 0x64, 0x89, 0x9e, 0x81, 0x8a, 0x66, 0xdb, 0xaa, 0x4b, 0x34, 0x82,
-	0xd5, 0xde, 0x94, 0x60, 0x97, 0x81, 0xe1, 0x86, 0x52, 0xd2, 0x6c, 0xfb, 0x8c, 0x4a, 0x1b, 0x54,
-	0x88, 0x9e, 0x86, 0x32, 0x05, 0x3e, 0x43, 0x1b, 0xa9, 0x43, 0x6c, 0x0c, 0x86, 0x84, 0x81, 0x5e,
-	0x5a, 0x29, 0x09, 0x69, 0x9b, 0x09, 0x65, 0xdd, 0x3f, 0x08, 0x0c, 0xf4, 0x10, 0x82, 0x8c, 0xae,
-	0x11, 0x8d, 0x6d, 0xe4, 0x86, 0xc2, 0xc6, 0x54, 0x66, 0x6b, 0x64, 0x28, 0xb6, 0x87, 0x8d, 0xd1,
-	0x75, 0xc8, 0x09, 0xb7, 0x69, 0xe6, 0x56, 0xcc, 0xe8, 0x37, 0xb3, 0x1d, 0xeb, 0x02, 0x33, 0x94,
-	0x2f, 0x28, 0x7c, 0x22, 0xff, 0x2a, 0x05, 0x9b, 0x73, 0xf0, 0x48, 0xfd, 0x0e, 0x35, 0x77, 0xe8,
-	0xbd, 0x8b, 0x8e, 0xd1, 0xab, 0xd4, 0xaf, 0xa6, 0x63, 0x47, 0xa4, 0xa5, 0x5a, 0x78, 0x8b, 0x78,
-	0xca, 0x6d, 0xb3, 0xe7, 0x62, 0x6b, 0x84, 0x36, 0xea, 0x40, 0x75, 0xa4, 0xb9, 0x44, 0xe5, 0x70,
-	0xa3, 0x86, 0x52, 0xd4, 0x3c, 0xc8, 0x1e, 0x6b, 0x1e, 0x40, 0xd1, 0xc3, 0x2e, 0x1c, 0x95, 0x47,
-	0x11, 0x29, 0x52, 0x60, 0xab, 0x37, 0xfd, 0x4c, 0x33, 0x89, 0x61, 0x62, 0x75, 0xee, 0xcb, 0x3d,
-	0x36, 0xe7, 0xb4, 0x75, 0x61, 0xe8, 0xd8, 0xec, 0x7b, 0x9f, 0xec, 0x9a, 0x6f, 0xec, 0x7f, 0x52,
-	0x57, 0x56, 0xa0, 0x1c, 0x05, 0x78, 0x54, 0x86, 0x14, 0xb9, 0x14, 0x1b, 0x90, 0x22, 0x97, 0xe8,
-	0xff, 0x21, 0x43, 0x17, 0xc9, 0x16, 0x5f, 0x5e, 0x90, 0x5d, 0x85, 0x5d, 0x77, 0x6a, 0x63, 0x85,
-	0x69, 0xca, 0xb2, 0x1f, 0x0d, 0x3e, 0xe8, 0xcf, 0x7a, 0x95, 0x9f, 0x83, 0xca, 0x0c, 0xaa, 0x87,
-	0xbe, 0x5f, 0x32, 0xfc, 0xfd, 0xe4, 0x0a, 0x94, 0x22, 0x10, 0x2e, 0x5f, 0x87, 0xad, 0x45, 0x88,
-	0x2c, 0x0f, 0x7d, 0x79, 0x04, 0x59, 0xd1, 0x2b, 0x50, 0xf0, 0x21, 0x99, 0x47, 0xe3, 0xfc, 0x5e,
-	0x79, 0xca, 0x8a, 0xaf, 0x4a, 0xc3, 0x90, 0x1e, 0x6b, 0x76, 0x1e, 0x52, 0xec, 0x87, 0xe7, 0x35,
-	0xdb, 0x6e, 0x6b, 0xee, 0x50, 0xfe, 0x08, 0x6a, 0x71, 0x70, 0x3b, 0xb3, 0x8c, 0x8c, 0x7f, 0x0c,
-	0xaf, 0x43, 0xee, 0xdc, 0x72, 0xc6, 0x1a, 0x61, 0xce, 0x4a, 0x8a, 0x98, 0xd1, 0xe3, 0xc9, 0xa1,
-	0x37, 0xcd, 0xc4, 0x7c, 0x22, 0xab, 0xf0, 0x58, 0x2c, 0xe4, 0x52, 0x13, 0xc3, 0xd4, 0x31, 0xdf,
-	0xcf, 0x92, 0xc2, 0x27, 0x81, 0x23, 0xfe, 0x63, 0xf9, 0x84, 0xbe, 0xd6, 0x65, 0x6b, 0x65, 0xfe,
-	0x25, 0x45, 0xcc, 0xe4, 0xbf, 0x17, 0xa0, 0xa0, 0x60, 0xd7, 0xa6, 0x98, 0x80, 0x1a, 0x20, 0xe1,
-	0xcb, 0x3e, 0xe6, 0x64, 0x28, 0x19, 0x4b, 0x26, 0xb8, 0x76, 0xcb, 0xd3, 0xa4, 0x99, 0xdc, 0x37,
-	0x43, 0x2f, 0x0b, 0xc2, 0x17, 0xcf, 0xdd, 0x84, 0x79, 0x98, 0xf1, 0xbd, 0xea, 0x31, 0xbe, 0x74,
-	0x6c, 0xf2, 0xe6, 0x56, 0x33, 0x94, 0xef, 0x65, 0x41, 0xf9, 0x32, 0x2b, 0x5e, 0x16, 0xe1, 0x7c,
-	0xcd, 0x08, 0xe7, 0xcb, 0xae, 0x58, 0x66, 0x0c, 0xe9, 0x6b, 0x46, 0x48, 0x5f, 0x6e, 0x85, 0x93,
-	0x18, 0xd6, 0xf7, 0xaa, 0xc7, 0xfa, 0xf2, 0x2b, 0x96, 0x3d, 0x43, 0xfb, 0x6e, 0x47, 0x69, 0x1f,
-	0xa7, 0x6c, 0x4f, 0xc5, 0x5a, 0xc7, 0xf2, 0xbe, 0x1f, 0x84, 0x78, 0x9f, 0x14, 0x4b, 0xba, 0xb8,
-	0x93, 0x05, 0xc4, 0xaf, 0x19, 0x21, 0x7e, 0xb0, 0x62, 0x0f, 0x62, 0x98, 0xdf, 0xdb, 0x61, 0xe6,
-	0x57, 0x8c, 0x25, 0x8f, 0xe2, 0xd0, 0x2c, 0xa2, 0x7e, 0xaf, 0xfb, 0xd4, 0x6f, 0x23, 0x96, 0xbb,
-	0x8a, 0x35, 0xcc, 0x72, 0xbf, 0xce, 0x1c, 0xf7, 0xe3, 0x5c, 0xed, 0x99, 0x58, 0x17, 0x2b, 0xc8,
-	0x5f, 0x67, 0x8e, 0xfc, 0x95, 0x57, 0x38, 0x5c, 0xc1, 0xfe, 0x7e, 0xbe, 0x98, 0xfd, 0xc5, 0xf3,
-	0x33, 0xf1, 0x33, 0xd7, 0xa3, 0x7f, 0x6a, 0x0c, 0xfd, 0xab, 0x32, 0xf7, 0xcf, 0xc7, 0xba, 0xbf,
-	0x3a, 0xff, 0x7b, 0x8e, 0xa6, 0xd9, 0x19, 0xe0, 0xa0, 0x50, 0x85, 0x1d, 0xc7, 0x72, 0x04, 0xb5,
-	0xe2, 0x13, 0xf9, 0x59, 0x9a, 0xf8, 0x03, 0x90, 0x58, 0xc2, 0x15, 0x59, 0x4a, 0x08, 0x01, 0x83,
-	0xfc, 0xfb, 0x64, 0x60, 0xcb, 0x72, 0x65, 0x98, 0x34, 0x48, 0x82, 0x34, 0x84, 0x28, 0x64, 0x2a,
-	0x4a, 0x21, 0x77, 0xa0, 0x48, 0xa1, 0x7e, 0x86, 0x1d, 0x6a, 0xb6, 0xc7, 0x0e, 0xd1, 0x2d, 0xd8,
-	0x64, 0xb9, 0x9c, 0x13, 0x4d, 0x81, 0xef, 0x19, 0x96, 0xa6, 0x2a, 0xf4, 0x01, 0x3f, 0x9c, 0x1c,
-	0xe8, 0x5f, 0x84, 0x6b, 0x21, 0x5d, 0x3f, 0x85, 0x70, 0x4a, 0x54, 0xf5, 0xb5, 0x0f, 0x44, 0x2e,
-	0x79, 0x3f, 0xd8, 0xa0, 0x80, 0x79, 0x22, 0xc8, 0xf4, 0x2d, 0x1d, 0x0b, 0x80, 0x67, 0x63, 0xca,
-	0x46, 0x47, 0xd6, 0x40, 0xc0, 0x38, 0x1d, 0x52, 0x2d, 0x1f, 0x05, 0x25, 0x0e, 0x72, 0xf2, 0x1f,
-	0x93, 0x81, 0xbf, 0x80, 0x8c, 0x2e, 0xe2, 0x8d, 0xc9, 0xff, 0x0e, 0x6f, 0x4c, 0x7d, 0x6b, 0xde,
-	0x18, 0x4e, 0xb0, 0xe9, 0x68, 0x82, 0xfd, 0x67, 0x32, 0xf8, 0xc2, 0x3e, 0x0b, 0xfc, 0x76, 0x3b,
-	0x12, 0x64, 0xcb, 0x2c, 0xfb, 0x5e, 0x22, 0x5b, 0x0a, 0x6e, 0x9f, 0x63, 0xef, 0x8d, 0x72, 0xfb,
-	0x3c, 0xcf, 0x9f, 0x6c, 0x82, 0x5e, 0x03, 0x89, 0x35, 0x5d, 0x54, 0xcb, 0x76, 0x05, 0xe0, 0x3e,
-	0x1e, 0x5e, 0x2b, 0xef, 0xad, 0xec, 0x9e, 0x52, 0x9d, 0x8e, 0xed, 0x2a, 0x05, 0x5b, 0x8c, 0x42,
-	0x44, 0x40, 0x8a, 0xf0, 0xd1, 0x1b, 0x20, 0xd1, 0x5f, 0xef, 0xda, 0x5a, 0x1f, 0x33, 0xf0, 0x94,
-	0x94, 0x40, 0x20, 0xdf, 0x03, 0x34, 0x0f, 0xdf, 0xa8, 0x0d, 0x39, 0x7c, 0x81, 0x4d, 0x42, 0xbf,
-	0x1a, 0xdd, 0xee, 0xeb, 0x0b, 0xc8, 0x1e, 0x36, 0x49, 0xa3, 0x46, 0x37, 0xf9, 0x1f, 0xdf, 0xec,
-	0x54, 0xb9, 0xf6, 0x0b, 0xd6, 0xd8, 0x20, 0x78, 0x6c, 0x93, 0xa9, 0x22, 0xec, 0xe5, 0xbf, 0xa4,
-	0x28, 0xf3, 0x8a, 0x40, 0xfb, 0xc2, 0xbd, 0xf5, 0x02, 0x28, 0x15, 0x62, 0xdd, 0xeb, 0xed, 0xf7,
-	0x36, 0xc0, 0x40, 0x73, 0xd5, 0x4f, 0x35, 0x93, 0x60, 0x5d, 0x6c, 0x7a, 0x48, 0x82, 0xea, 0x50,
-	0xa0, 0xb3, 0x89, 0x8b, 0x75, 0x51, 0x00, 0xf8, 0xf3, 0xd0, 0x3a, 0xf3, 0xdf, 0x6d, 0x9d, 0xd1,
-	0x5d, 0x2e, 0xcc, 0xec, 0x72, 0x88, 0x15, 0x49, 0x61, 0x56, 0x44, 0x7f, 0x9b, 0xed, 0x18, 0x96,
-	0x63, 0x90, 0x29, 0xfb, 0x34, 0x69, 0xc5, 0x9f, 0xd3, 0x3a, 0x73, 0x8c, 0xc7, 0xb6, 0x65, 0x8d,
-	0x54, 0x0e, 0x5e, 0x45, 0x66, 0xba, 0x21, 0x84, 0x2d, 0x86, 0x61, 0xbf, 0x4e, 0x05, 0xe1, 0x17,
-	0xb0, 0xdf, 0xff, 0xb9, 0x0d, 0x96, 0x7f, 0xc3, 0x4a, 0xe2, 0x68, 0xf2, 0x46, 0x67, 0xb0, 0xe9,
-	0x87, 0xbf, 0x3a, 0x61, 0xb0, 0xe0, 0x1d, 0xe8, 0x75, 0xf1, 0xa3, 0x7a, 0x11, 0x15, 0xbb, 0xe8,
-	0xc7, 0xf0, 0xe8, 0x0c, 0xb4, 0xf9, 0xae, 0x53, 0x6b, 0x22, 0xdc, 0x23, 0x51, 0x84, 0xf3, 0x3c,
-	0x07, 0x7b, 0x95, 0xfe, 0x8e, 0x41, 0x77, 0x48, 0xab, 0xac, 0x30, 0x15, 0x59, 0xf8, 0xf5, 0x9f,
-	0x82, 0x92, 0x83, 0x09, 0x2d, 0xfc, 0x23, 0x75, 0xec, 0x06, 0x17, 0x8a, 0xea, 0xf8, 0x14, 0x1e,
-	0x59, 0x48, 0x49, 0xd0, 0xf7, 0x40, 0x0a, 0xd8, 0x4c, 0x32, 0xa6, 0x24, 0xf4, 0xcb, 0x9c, 0x40,
-	0x57, 0xfe, 0x43, 0x32, 0x70, 0x19, 0x2d, 0x9c, 0x5a, 0x90, 0x73, 0xb0, 0x3b, 0x19, 0xf1, 0x52,
-	0xa6, 0xbc, 0xff, 0xe2, 0x7a, 0x64, 0x86, 0x4a, 0x27, 0x23, 0xa2, 0x08, 0x63, 0xf9, 0x1e, 0xe4,
-	0xb8, 0x04, 0x15, 0x21, 0x7f, 0xe7, 0xe4, 0xe8, 0xa4, 0xf3, 0xc1, 0x49, 0x35, 0x81, 0x00, 0x72,
-	0x07, 0xcd, 0x66, 0xeb, 0xb4, 0x5b, 0x4d, 0x22, 0x09, 0xb2, 0x07, 0x8d, 0x8e, 0xd2, 0xad, 0xa6,
-	0xa8, 0x58, 0x69, 0xbd, 0xd7, 0x6a, 0x76, 0xab, 0x69, 0xb4, 0x09, 0x25, 0x3e, 0x56, 0x6f, 0x77,
-	0x94, 0xf7, 0x0f, 0xba, 0xd5, 0x4c, 0x48, 0x74, 0xd6, 0x3a, 0x79, 0xa7, 0xa5, 0x54, 0xb3, 0xf2,
-	0x4b, 0xb4, 0x56, 0x8a, 0xa1, 0x3f, 0x41, 0x55, 0x94, 0x0c, 0x55, 0x45, 0xf2, 0xef, 0x52, 0x50,
-	0x8f, 0xe7, 0x34, 0xe8, 0xbd, 0x99, 0x85, 0xef, 0x5f, 0x81, 0x10, 0xcd, 0xac, 0x1e, 0x3d, 0x0d,
-	0x65, 0x07, 0x9f, 0x63, 0xd2, 0x1f, 0x72, 0x8e, 0xc5, 0x33, 0x66, 0x49, 0x29, 0x09, 0x29, 0x33,
-	0x72, 0xb9, 0xda, 0xc7, 0xb8, 0x4f, 0x54, 0x0e, 0x45, 0xfc, 0xd0, 0x49, 0x54, 0x8d, 0x4a, 0xcf,
-	0xb8, 0x50, 0xfe, 0xe8, 0x4a, 0x7b, 0x29, 0x41, 0x56, 0x69, 0x75, 0x95, 0x9f, 0x54, 0xd3, 0x08,
-	0x41, 0x99, 0x0d, 0xd5, 0xb3, 0x93, 0x83, 0xd3, 0xb3, 0x76, 0x87, 0xee, 0xe5, 0x35, 0xa8, 0x78,
-	0x7b, 0xe9, 0x09, 0xb3, 0xf2, 0xbf, 0x93, 0x50, 0x99, 0x09, 0x10, 0xb4, 0x0f, 0x59, 0xce, 0xd3,
-	0xe3, 0xba, 0xf9, 0x2c, 0xbe, 0x45, 0x34, 0x71, 0x55, 0xf4, 0x26, 0x14, 0xb0, 0x68, 0x40, 0x2c,
-	0x0a, 0x44, 0xde, 0x38, 0xf1, 0x5a, 0x14, 0xc2, 0xd4, 0xb7, 0x40, 0x6f, 0x81, 0xe4, 0x47, 0xba,
-	0x28, 0x0e, 0x9f, 0x9c, 0x37, 0xf7, 0x31, 0x42, 0xd8, 0x07, 0x36, 0xe8, 0xf5, 0x80, 0xec, 0x65,
-	0xe6, 0xab, 0x03, 0x61, 0xce, 0x15, 0x84, 0xb1, 0xa7, 0x2f, 0x37, 0xa1, 0x18, 0x5a, 0x0f, 0x7a,
-	0x1c, 0xa4, 0xb1, 0x76, 0x29, 0x1a, 0x5b, 0xbc, 0x35, 0x51, 0x18, 0x6b, 0x97, 0xbc, 0xa7, 0xf5,
-	0x28, 0xe4, 0xe9, 0xc3, 0x81, 0xc6, 0xd1, 0x26, 0xad, 0xe4, 0xc6, 0xda, 0xe5, 0xbb, 0x9a, 0x2b,
-	0x7f, 0x08, 0xe5, 0x68, 0x53, 0x87, 0x9e, 0x44, 0xc7, 0x9a, 0x98, 0x3a, 0xf3, 0x91, 0x55, 0xf8,
-	0x04, 0xbd, 0x02, 0xd9, 0x0b, 0x8b, 0x83, 0xd5, 0xe2, 0x90, 0xbd, 0x6b, 0x11, 0x1c, 0x6a, 0x0a,
-	0x71, 0x6d, 0xf9, 0x33, 0xc8, 0x32, 0xf0, 0xa1, 0x40, 0xc2, 0xda, 0x33, 0x82, 0xe8, 0xd2, 0x31,
-	0xfa, 0x10, 0x40, 0x23, 0xc4, 0x31, 0x7a, 0x93, 0xc0, 0xf1, 0xce, 0x62, 0xf0, 0x3a, 0xf0, 0xf4,
-	0x1a, 0x37, 0x04, 0x8a, 0x6d, 0x05, 0xa6, 0x21, 0x24, 0x0b, 0x39, 0x94, 0x4f, 0xa0, 0x1c, 0xb5,
-	0x0d, 0x37, 0x4a, 0x37, 0x16, 0x34, 0x4a, 0x7d, 0x32, 0xe5, 0x53, 0xb1, 0x34, 0x6f, 0xc5, 0xb1,
-	0x89, 0xfc, 0x79, 0x12, 0x0a, 0xdd, 0x4b, 0x71, 0xac, 0x63, 0xba, 0x40, 0x81, 0x69, 0x2a, 0xdc,
-	0xf3, 0xe0, 0x6d, 0xa5, 0xb4, 0xdf, 0xac, 0x7a, 0xdb, 0x0f, 0xdc, 0xcc, 0xba, 0x55, 0xa9, 0xd7,
-	0xb5, 0x13, 0x60, 0xf5, 0x06, 0x48, 0xfe, 0xa9, 0xa2, 0x15, 0x83, 0xa6, 0xeb, 0x0e, 0x76, 0x5d,
-	0xb1, 0x36, 0x6f, 0xca, 0x9a, 0x8a, 0xd6, 0xa7, 0xa2, 0xab, 0x92, 0x56, 0xf8, 0x44, 0xd6, 0xa1,
-	0x32, 0x93, 0xb6, 0xd0, 0x1b, 0x90, 0xb7, 0x27, 0x3d, 0xd5, 0xdb, 0x9e, 0x99, 0xe0, 0xf1, 0xd8,
-	0xe3, 0xa4, 0x37, 0x32, 0xfa, 0x47, 0x78, 0xea, 0xfd, 0x18, 0x7b, 0xd2, 0x3b, 0xe2, 0xbb, 0xc8,
-	0xdf, 0x92, 0x0a, 0xbf, 0xe5, 0x02, 0x0a, 0xde, 0xa1, 0x40, 0x3f, 0x0c, 0xc7, 0x89, 0xd7, 0x6a,
-	0x8e, 0x4d, 0xa5, 0xc2, 0x7d, 0x28, 0x4c, 0x6e, 0xc1, 0xa6, 0x6b, 0x0c, 0x4c, 0xac, 0xab, 0x41,
-	0xcd, 0xc2, 0xde, 0x56, 0x50, 0x2a, 0xfc, 0xc1, 0xb1, 0x57, 0xb0, 0xc8, 0xff, 0x4a, 0x42, 0xc1,
-	0x0b, 0x58, 0xf4, 0x52, 0xe8, 0xdc, 0x95, 0x17, 0x74, 0x60, 0x3c, 0xc5, 0xa0, 0x2f, 0x18, 0xfd,
-	0xad, 0xa9, 0xab, 0xff, 0xd6, 0xb8, 0x06, 0xaf, 0xd7, 0x69, 0xcf, 0x5c, 0xb9, 0xd3, 0xfe, 0x02,
-	0x20, 0x62, 0x11, 0x6d, 0xa4, 0x5e, 0x58, 0xc4, 0x30, 0x07, 0x2a, 0xdf, 0x6c, 0xce, 0xa8, 0xaa,
-	0xec, 0xc9, 0x5d, 0xf6, 0xe0, 0x94, 0xed, 0xfb, 0x2f, 0x92, 0x50, 0xf0, 0x73, 0xe3, 0x55, 0xdb,
-	0x7c, 0xd7, 0x21, 0x27, 0xe0, 0x9f, 0xf7, 0xf9, 0xc4, 0xcc, 0xef, 0x38, 0x67, 0x42, 0x1d, 0xe7,
-	0x3a, 0x14, 0xc6, 0x98, 0x68, 0x8c, 0x20, 0xf0, 0xb2, 0xd1, 0x9f, 0xdf, 0x7a, 0x1d, 0x8a, 0xa1,
-	0x8e, 0x2b, 0x8d, 0xbc, 0x93, 0xd6, 0x07, 0xd5, 0x44, 0x3d, 0xff, 0xf9, 0x97, 0x37, 0xd3, 0x27,
-	0xf8, 0x53, 0x7a, 0x66, 0x95, 0x56, 0xb3, 0xdd, 0x6a, 0x1e, 0x55, 0x93, 0xf5, 0xe2, 0xe7, 0x5f,
-	0xde, 0xcc, 0x2b, 0x98, 0x35, 0x6e, 0x6e, 0xb5, 0x61, 0x23, 0xfc, 0x55, 0xa2, 0x19, 0x04, 0x41,
-	0xf9, 0x9d, 0x3b, 0xa7, 0xc7, 0x87, 0xcd, 0x83, 0x6e, 0x4b, 0xbd, 0xdb, 0xe9, 0xb6, 0xaa, 0x49,
-	0xf4, 0x28, 0x5c, 0x3b, 0x3e, 0x7c, 0xb7, 0xdd, 0x55, 0x9b, 0xc7, 0x87, 0xad, 0x93, 0xae, 0x7a,
-	0xd0, 0xed, 0x1e, 0x34, 0x8f, 0xaa, 0xa9, 0xfd, 0x5f, 0x02, 0x54, 0x0e, 0x1a, 0xcd, 0x43, 0x9a,
-	0xfd, 0x8c, 0xbe, 0x26, 0x1a, 0x63, 0x19, 0x56, 0xb5, 0x2f, 0xbd, 0xea, 0xad, 0x2f, 0xef, 0x0b,
-	0xa2, 0xdb, 0x90, 0x65, 0x05, 0x3d, 0x5a, 0x7e, 0xf7, 0x5b, 0x5f, 0xd1, 0x28, 0xa4, 0x3f, 0x86,
-	0x85, 0xc7, 0xd2, 0xcb, 0xe0, 0xfa, 0xf2, 0xbe, 0x21, 0x52, 0x40, 0x0a, 0x2a, 0xf2, 0xd5, 0x97,
-	0xc3, 0xf5, 0x35, 0x7a, 0x89, 0xd4, 0x67, 0x50, 0x16, 0xac, 0xbe, 0x2c, 0xad, 0xaf, 0x01, 0x60,
-	0xe8, 0x18, 0xf2, 0x5e, 0x25, 0xb7, 0xea, 0xfa, 0xb6, 0xbe, 0xb2, 0xcf, 0x47, 0x3f, 0x01, 0xaf,
-	0xb8, 0x97, 0xdf, 0x45, 0xd7, 0x57, 0x34, 0x2d, 0xd1, 0x21, 0xe4, 0x04, 0xd7, 0x5d, 0x71, 0x25,
-	0x5b, 0x5f, 0xd5, 0xb7, 0xa3, 0x9b, 0x16, 0xb4, 0x32, 0x56, 0xdf, 0xb0, 0xd7, 0xd7, 0xe8, 0xc7,
-	0xa2, 0x3b, 0x00, 0xa1, 0xfa, 0x7a, 0x8d, 0xab, 0xf3, 0xfa, 0x3a, 0x7d, 0x56, 0xd4, 0x81, 0x82,
-	0x5f, 0xee, 0xac, 0xbc, 0xc8, 0xae, 0xaf, 0x6e, 0x78, 0xa2, 0x7b, 0x50, 0x8a, 0xf2, 0xfc, 0xf5,
-	0xae, 0xa7, 0xeb, 0x6b, 0x76, 0x32, 0xa9, 0xff, 0x28, 0xe9, 0x5f, 0xef, 0xba, 0xba, 0xbe, 0x66,
-	0x63, 0x13, 0x7d, 0x0c, 0x9b, 0xf3, 0xa4, 0x7c, 0xfd, 0xdb, 0xeb, 0xfa, 0x15, 0x5a, 0x9d, 0x68,
-	0x0c, 0x68, 0x01, 0x99, 0xbf, 0xc2, 0x65, 0x76, 0xfd, 0x2a, 0x9d, 0xcf, 0x46, 0xeb, 0xab, 0x07,
-	0xdb, 0xc9, 0xaf, 0x1f, 0x6c, 0x27, 0xff, 0xf6, 0x60, 0x3b, 0xf9, 0xc5, 0xc3, 0xed, 0xc4, 0xd7,
-	0x0f, 0xb7, 0x13, 0x7f, 0x7e, 0xb8, 0x9d, 0xf8, 0xe9, 0xf3, 0x03, 0x83, 0x0c, 0x27, 0xbd, 0xdd,
-	0xbe, 0x35, 0xde, 0x0b, 0xff, 0xd3, 0x66, 0xd1, 0xbf, 0x7f, 0x7a, 0x39, 0x96, 0xa8, 0x5e, 0xfe,
-	0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4e, 0x2d, 0x07, 0xd8, 0x1d, 0x24, 0x00, 0x00,
+	0xf5, 0xd7, 0xfb, 0x71, 0xf5, 0x74, 0x8d, 0x19, 0x34, 0x62, 0xb0, 0xe7, 0xdf, 0x1c, 0xf8, 0xc3,
+	0x00, 0x76, 0x30, 0x07, 0x02, 0x21, 0x0f, 0x2c, 0xa1, 0x41, 0x66, 0x8c, 0xed, 0xb4, 0x35, 0x43,
+	0x5e, 0x4c, 0xd3, 0xea, 0x2e, 0x4b, 0xcd, 0x48, 0xdd, 0x4d, 0x77, 0xc9, 0xd8, 0x2c, 0x43, 0xb2,
+	0x21, 0x1b, 0xb2, 0xcb, 0x86, 0xef, 0x91, 0x55, 0x36, 0xd9, 0x70, 0x4e, 0x36, 0x2c, 0xb3, 0xc8,
+	0x21, 0x39, 0x70, 0xb2, 0xc9, 0x17, 0xc8, 0x2a, 0x27, 0x39, 0xf5, 0x6a, 0x75, 0x4b, 0x6a, 0x49,
+	0x86, 0xec, 0xb2, 0xeb, 0x5b, 0xba, 0xf7, 0x56, 0xd5, 0xad, 0xaa, 0x5f, 0xfd, 0xee, 0x2d, 0xc1,
+	0x63, 0x04, 0xdb, 0x26, 0xf6, 0xc6, 0x96, 0x4d, 0x76, 0xf5, 0xbe, 0x61, 0xed, 0x92, 0x4b, 0x17,
+	0xfb, 0x3b, 0xae, 0xe7, 0x10, 0x07, 0xd5, 0xa6, 0x3f, 0xee, 0xd0, 0x1f, 0x9b, 0x8f, 0x87, 0xb4,
+	0x0d, 0xef, 0xd2, 0x25, 0xce, 0xae, 0xeb, 0x39, 0xce, 0x19, 0xd7, 0x6f, 0xde, 0x0c, 0xfd, 0xcc,
+	0xfc, 0x84, 0xbd, 0x35, 0x6f, 0xce, 0x1b, 0x3f, 0xc4, 0x97, 0xf2, 0xd7, 0xc7, 0xe7, 0x6c, 0x5d,
+	0xdd, 0xd3, 0xc7, 0xf2, 0xe7, 0xed, 0x81, 0xe3, 0x0c, 0x46, 0x78, 0x97, 0x49, 0xfd, 0xc9, 0xd9,
+	0x2e, 0xb1, 0xc6, 0xd8, 0x27, 0xfa, 0xd8, 0x15, 0x0a, 0x9b, 0x03, 0x67, 0xe0, 0xb0, 0xcf, 0x5d,
+	0xfa, 0xc5, 0x5b, 0x95, 0xdf, 0x16, 0x20, 0xaf, 0xe2, 0x0f, 0x26, 0xd8, 0x27, 0x68, 0x0f, 0x32,
+	0xd8, 0x18, 0x3a, 0x8d, 0xe4, 0xad, 0xe4, 0xd3, 0xa5, 0xbd, 0x9b, 0x3b, 0x33, 0x93, 0xdb, 0x11,
+	0x7a, 0x1d, 0x63, 0xe8, 0x74, 0x13, 0x2a, 0xd3, 0x45, 0x2f, 0x41, 0xf6, 0x6c, 0x34, 0xf1, 0x87,
+	0x8d, 0x14, 0x33, 0x7a, 0x3c, 0xce, 0xe8, 0x0e, 0x55, 0xea, 0x26, 0x54, 0xae, 0x4d, 0xbb, 0xb2,
+	0xec, 0x33, 0xa7, 0x91, 0x5e, 0xde, 0xd5, 0x81, 0x7d, 0xc6, 0xba, 0xa2, 0xba, 0xa8, 0x05, 0xe0,
+	0x63, 0xa2, 0x39, 0x2e, 0xb1, 0x1c, 0xbb, 0x91, 0x61, 0x96, 0xff, 0x17, 0x67, 0x79, 0x8a, 0xc9,
+	0x31, 0x53, 0xec, 0x26, 0xd4, 0xa2, 0x2f, 0x05, 0xea, 0xc3, 0xb2, 0x2d, 0xa2, 0x19, 0x43, 0xdd,
+	0xb2, 0x1b, 0xd9, 0xe5, 0x3e, 0x0e, 0x6c, 0x8b, 0xb4, 0xa9, 0x22, 0xf5, 0x61, 0x49, 0x81, 0x4e,
+	0xf9, 0x83, 0x09, 0xf6, 0x2e, 0x1b, 0xb9, 0xe5, 0x53, 0xfe, 0x31, 0x55, 0xa2, 0x53, 0x66, 0xda,
+	0xa8, 0x03, 0xa5, 0x3e, 0x1e, 0x58, 0xb6, 0xd6, 0x1f, 0x39, 0xc6, 0xc3, 0x46, 0x9e, 0x19, 0x2b,
+	0x71, 0xc6, 0x2d, 0xaa, 0xda, 0xa2, 0x9a, 0xdd, 0x84, 0x0a, 0xfd, 0x40, 0x42, 0xdf, 0x87, 0x82,
+	0x31, 0xc4, 0xc6, 0x43, 0x8d, 0x5c, 0x34, 0x0a, 0xcc, 0xc7, 0x76, 0x9c, 0x8f, 0x36, 0xd5, 0xeb,
+	0x5d, 0x74, 0x13, 0x6a, 0xde, 0xe0, 0x9f, 0x74, 0xfe, 0x26, 0x1e, 0x59, 0xe7, 0xd8, 0xa3, 0xf6,
+	0xc5, 0xe5, 0xf3, 0x7f, 0x83, 0x6b, 0x32, 0x0f, 0x45, 0x53, 0x0a, 0xe8, 0x47, 0x50, 0xc4, 0xb6,
+	0x29, 0xa6, 0x01, 0xcc, 0xc5, 0xad, 0xd8, 0xbd, 0x62, 0x9b, 0x72, 0x12, 0x05, 0x2c, 0xbe, 0xd1,
+	0x2b, 0x90, 0x33, 0x9c, 0xf1, 0xd8, 0x22, 0x8d, 0x12, 0xb3, 0xde, 0x8a, 0x9d, 0x00, 0xd3, 0xea,
+	0x26, 0x54, 0xa1, 0x8f, 0x8e, 0xa0, 0x3a, 0xb2, 0x7c, 0xa2, 0xf9, 0xb6, 0xee, 0xfa, 0x43, 0x87,
+	0xf8, 0x8d, 0x32, 0xf3, 0xf0, 0x64, 0x9c, 0x87, 0x43, 0xcb, 0x27, 0xa7, 0x52, 0xb9, 0x9b, 0x50,
+	0x2b, 0xa3, 0x70, 0x03, 0xf5, 0xe7, 0x9c, 0x9d, 0x61, 0x2f, 0x70, 0xd8, 0xa8, 0x2c, 0xf7, 0x77,
+	0x4c, 0xb5, 0xa5, 0x3d, 0xf5, 0xe7, 0x84, 0x1b, 0xd0, 0xcf, 0xe1, 0xda, 0xc8, 0xd1, 0xcd, 0xc0,
+	0x9d, 0x66, 0x0c, 0x27, 0xf6, 0xc3, 0x46, 0x95, 0x39, 0x7d, 0x26, 0x76, 0x90, 0x8e, 0x6e, 0x4a,
+	0x17, 0x6d, 0x6a, 0xd0, 0x4d, 0xa8, 0x1b, 0xa3, 0xd9, 0x46, 0xf4, 0x00, 0x36, 0x75, 0xd7, 0x1d,
+	0x5d, 0xce, 0x7a, 0xaf, 0x31, 0xef, 0xb7, 0xe3, 0xbc, 0xef, 0x53, 0x9b, 0x59, 0xf7, 0x48, 0x9f,
+	0x6b, 0x6d, 0xe5, 0x21, 0x7b, 0xae, 0x8f, 0x26, 0x58, 0xf9, 0x7f, 0x28, 0x85, 0x8e, 0x3a, 0x6a,
+	0x40, 0x7e, 0x8c, 0x7d, 0x5f, 0x1f, 0x60, 0x86, 0x0c, 0x45, 0x55, 0x8a, 0x4a, 0x15, 0xca, 0xe1,
+	0xe3, 0xad, 0x8c, 0xa1, 0x14, 0x3a, 0xb8, 0xd4, 0xf0, 0x1c, 0x7b, 0x3e, 0x3d, 0xad, 0xc2, 0x50,
+	0x88, 0xe8, 0x09, 0xa8, 0xb0, 0xed, 0xa3, 0xc9, 0xdf, 0x29, 0x7a, 0x64, 0xd4, 0x32, 0x6b, 0xbc,
+	0x2f, 0x94, 0xb6, 0xa1, 0xe4, 0xee, 0xb9, 0x81, 0x4a, 0x9a, 0xa9, 0x80, 0xbb, 0xe7, 0x0a, 0x05,
+	0xe5, 0x7b, 0x50, 0x9f, 0x3d, 0xed, 0xa8, 0x0e, 0xe9, 0x87, 0xf8, 0x52, 0xf4, 0x47, 0x3f, 0xd1,
+	0xa6, 0x98, 0x16, 0xeb, 0xa3, 0xa8, 0x8a, 0x39, 0xfe, 0x29, 0x05, 0xf5, 0xd9, 0x63, 0x8e, 0x5e,
+	0x81, 0x0c, 0x45, 0x4d, 0x01, 0x80, 0xcd, 0x1d, 0x0e, 0xa9, 0x3b, 0x12, 0x52, 0x77, 0x7a, 0x12,
+	0x52, 0x5b, 0x85, 0xcf, 0xbf, 0xdc, 0x4e, 0x7c, 0xfa, 0xd7, 0xed, 0xa4, 0xca, 0x2c, 0xd0, 0x0d,
+	0x7a, 0x2a, 0x75, 0xcb, 0xd6, 0x2c, 0x53, 0xf4, 0x93, 0x67, 0xf2, 0x81, 0x89, 0xee, 0x42, 0xdd,
+	0x70, 0x6c, 0x1f, 0xdb, 0xfe, 0xc4, 0xd7, 0x38, 0x64, 0x37, 0xd2, 0x31, 0xa7, 0xa6, 0x2d, 0x15,
+	0x4f, 0x98, 0x9e, 0x5a, 0x33, 0xa2, 0x0d, 0xe8, 0x0e, 0xc0, 0xb9, 0x3e, 0xb2, 0x4c, 0x9d, 0x38,
+	0x9e, 0xdf, 0xc8, 0xdc, 0x4a, 0x2f, 0x74, 0x73, 0x5f, 0xaa, 0xdc, 0x73, 0x4d, 0x9d, 0xe0, 0x56,
+	0x86, 0x8e, 0x56, 0x0d, 0x59, 0xa2, 0xa7, 0xa0, 0xa6, 0xbb, 0xae, 0xe6, 0x13, 0x9d, 0x60, 0xad,
+	0x7f, 0x49, 0xb0, 0xcf, 0xc0, 0xb0, 0xac, 0x56, 0x74, 0xd7, 0x3d, 0xa5, 0xad, 0x2d, 0xda, 0x88,
+	0x9e, 0x84, 0x2a, 0x05, 0x3e, 0x4b, 0x1f, 0x69, 0x43, 0x6c, 0x0d, 0x86, 0x84, 0x81, 0x5e, 0x5a,
+	0xad, 0x88, 0xd6, 0x2e, 0x6b, 0x54, 0x4c, 0x28, 0x87, 0x41, 0x0f, 0x21, 0xc8, 0x98, 0x3a, 0xd1,
+	0x59, 0x20, 0xcb, 0x2a, 0xfb, 0xa6, 0x6d, 0xae, 0x4e, 0x86, 0x22, 0x3c, 0xec, 0x1b, 0x5d, 0x87,
+	0x9c, 0x70, 0x9b, 0x66, 0x6e, 0x85, 0x44, 0xd7, 0xcc, 0xf5, 0x9c, 0x73, 0xcc, 0x50, 0xbe, 0xa0,
+	0x72, 0x41, 0xf9, 0x55, 0x0a, 0x36, 0xe6, 0xe0, 0x91, 0xfa, 0x1d, 0xea, 0xfe, 0x50, 0xf6, 0x45,
+	0xbf, 0xd1, 0xcb, 0xd4, 0xaf, 0x6e, 0x62, 0x4f, 0x5c, 0x4b, 0x8d, 0x70, 0x88, 0xf8, 0x95, 0xdb,
+	0x65, 0xbf, 0x8b, 0xd0, 0x08, 0x6d, 0x74, 0x0c, 0xf5, 0x91, 0xee, 0x13, 0x8d, 0xc3, 0x8d, 0x16,
+	0xba, 0xa2, 0xe6, 0x41, 0xf6, 0x50, 0x97, 0x00, 0x45, 0x37, 0xbb, 0x70, 0x54, 0x1d, 0x45, 0x5a,
+	0x91, 0x0a, 0x9b, 0xfd, 0xcb, 0x8f, 0x74, 0x9b, 0x58, 0x36, 0xd6, 0xe6, 0x56, 0xee, 0xc6, 0x9c,
+	0xd3, 0xce, 0xb9, 0x65, 0x62, 0xdb, 0x90, 0x4b, 0x76, 0x2d, 0x30, 0x0e, 0x96, 0xd4, 0x57, 0x3e,
+	0x4e, 0x42, 0x35, 0x8a, 0xf0, 0xa8, 0x0a, 0x29, 0x72, 0x21, 0x22, 0x90, 0x22, 0x17, 0xe8, 0x3b,
+	0x90, 0xa1, 0xb3, 0x64, 0xb3, 0xaf, 0x2e, 0xb8, 0x5e, 0x85, 0x5d, 0xef, 0xd2, 0xc5, 0x2a, 0xd3,
+	0xa4, 0x1b, 0x98, 0x78, 0xba, 0x81, 0xe9, 0x06, 0x4e, 0xf3, 0x0d, 0xcc, 0xe4, 0x03, 0x93, 0x2e,
+	0xc6, 0xc8, 0x31, 0xf4, 0x91, 0x5c, 0x0c, 0x26, 0x28, 0x0a, 0xd4, 0x67, 0xaf, 0x89, 0xd9, 0x61,
+	0x28, 0xcf, 0x40, 0x6d, 0xe6, 0x1e, 0x08, 0xad, 0x78, 0x32, 0xbc, 0xe2, 0x4a, 0x0d, 0x2a, 0x11,
+	0xd0, 0x57, 0xae, 0xc3, 0xe6, 0x22, 0x0c, 0x57, 0x86, 0xb0, 0xb9, 0x08, 0x8b, 0xd1, 0x4b, 0x50,
+	0x08, 0x40, 0x9c, 0x9f, 0xdf, 0xf9, 0xe8, 0x4a, 0x65, 0x35, 0x50, 0xa5, 0xf3, 0xa6, 0x07, 0x81,
+	0xed, 0xa0, 0x14, 0x1b, 0x78, 0x5e, 0x77, 0xdd, 0xae, 0xee, 0x0f, 0x95, 0xf7, 0xa0, 0x11, 0x07,
+	0xd0, 0x33, 0xd3, 0xc8, 0x04, 0x1b, 0xf7, 0x3a, 0xe4, 0xce, 0x1c, 0x6f, 0xac, 0x13, 0xe6, 0xac,
+	0xa2, 0x0a, 0x89, 0xc6, 0x90, 0x83, 0x75, 0x9a, 0x35, 0x73, 0x41, 0xd1, 0xe0, 0x46, 0x2c, 0x48,
+	0x53, 0x13, 0xcb, 0x36, 0x31, 0x8f, 0x67, 0x45, 0xe5, 0xc2, 0xd4, 0x11, 0x1f, 0x2c, 0x17, 0x68,
+	0xb7, 0x3e, 0x9b, 0xab, 0x58, 0x3b, 0x21, 0x29, 0x7f, 0x2f, 0x40, 0x41, 0xc5, 0xbe, 0x4b, 0x51,
+	0x04, 0xb5, 0xa0, 0x88, 0x2f, 0x0c, 0xcc, 0xe9, 0x53, 0x32, 0x96, 0x7e, 0x70, 0xed, 0x8e, 0xd4,
+	0xa4, 0x77, 0x7f, 0x60, 0x86, 0x5e, 0x14, 0x14, 0x31, 0x9e, 0xed, 0x09, 0xf3, 0x30, 0x47, 0x7c,
+	0x59, 0x72, 0xc4, 0x74, 0xec, 0x75, 0xcf, 0xad, 0x66, 0x48, 0xe2, 0x8b, 0x82, 0x24, 0x66, 0x56,
+	0x74, 0x16, 0x61, 0x89, 0xed, 0x08, 0x4b, 0xcc, 0xae, 0x98, 0x66, 0x0c, 0x4d, 0x6c, 0x47, 0x68,
+	0x62, 0x6e, 0x85, 0x93, 0x18, 0x9e, 0xf8, 0xb2, 0xe4, 0x89, 0xf9, 0x15, 0xd3, 0x9e, 0x21, 0x8a,
+	0x77, 0xa2, 0x44, 0x91, 0x93, 0xbc, 0x27, 0x62, 0xad, 0x63, 0x99, 0xe2, 0x0f, 0x42, 0x4c, 0xb1,
+	0x18, 0x4b, 0xd3, 0xb8, 0x93, 0x05, 0x54, 0xb1, 0x1d, 0xa1, 0x8a, 0xb0, 0x22, 0x06, 0x31, 0x5c,
+	0xf1, 0xf5, 0x30, 0x57, 0x2c, 0xc5, 0xd2, 0x4d, 0xb1, 0x69, 0x16, 0x91, 0xc5, 0x57, 0x03, 0xb2,
+	0x58, 0x8e, 0x65, 0xbb, 0x62, 0x0e, 0xb3, 0x6c, 0xf1, 0x78, 0x8e, 0x2d, 0x72, 0x76, 0xf7, 0x54,
+	0xac, 0x8b, 0x15, 0x74, 0xf1, 0x78, 0x8e, 0x2e, 0x56, 0x57, 0x38, 0x5c, 0xc1, 0x17, 0x7f, 0xb1,
+	0x98, 0x2f, 0xc6, 0x33, 0x3a, 0x31, 0xcc, 0xf5, 0x08, 0xa3, 0x16, 0x43, 0x18, 0xeb, 0xcc, 0xfd,
+	0xb3, 0xb1, 0xee, 0xaf, 0xce, 0x18, 0x9f, 0x81, 0x0d, 0x69, 0x1c, 0x00, 0x07, 0x85, 0x2a, 0xec,
+	0x79, 0x8e, 0x27, 0xc8, 0x18, 0x17, 0x94, 0xa7, 0xa1, 0x1c, 0xa8, 0x2e, 0x67, 0x97, 0xec, 0x4a,
+	0x08, 0x01, 0x83, 0xf2, 0xfb, 0x24, 0x94, 0xc3, 0x67, 0x3e, 0x42, 0x33, 0x8a, 0x82, 0x66, 0x84,
+	0x48, 0x67, 0x2a, 0x4a, 0x3a, 0xb7, 0xa1, 0x44, 0xa1, 0x7e, 0x86, 0x4f, 0xea, 0xae, 0xe4, 0x93,
+	0xe8, 0x36, 0x6c, 0xb0, 0xdb, 0x9f, 0x53, 0x53, 0x81, 0xef, 0x19, 0x76, 0x4d, 0xd5, 0xe8, 0x0f,
+	0x7c, 0x73, 0xb2, 0x66, 0xf4, 0x3c, 0x5c, 0x0b, 0xe9, 0x06, 0x57, 0x08, 0x27, 0x51, 0xf5, 0x40,
+	0x7b, 0x5f, 0xdc, 0x25, 0x6f, 0xc3, 0xc6, 0x1c, 0xe4, 0xd0, 0xe1, 0x1b, 0x8e, 0x89, 0x05, 0xc0,
+	0xb3, 0x6f, 0xca, 0x5f, 0x47, 0xce, 0x40, 0xc0, 0x38, 0xfd, 0xa4, 0x5a, 0x01, 0x0a, 0x16, 0x39,
+	0xc8, 0x29, 0x7f, 0x4c, 0xc2, 0xc6, 0x1c, 0xfa, 0x2c, 0x64, 0x9a, 0xc9, 0xff, 0x0e, 0xd3, 0x4c,
+	0x7d, 0x63, 0xa6, 0x19, 0xbe, 0x60, 0xd3, 0xd1, 0x0b, 0xf6, 0x9f, 0x49, 0xa8, 0x44, 0x30, 0xf0,
+	0x9b, 0x47, 0x64, 0x7a, 0x5b, 0x66, 0xd9, 0x7a, 0x71, 0x41, 0x66, 0x03, 0x39, 0xd6, 0x6f, 0x34,
+	0x1b, 0xc8, 0xf3, 0xfb, 0x93, 0x09, 0xe8, 0x15, 0x28, 0xb2, 0x32, 0x8d, 0xe6, 0xb8, 0xbe, 0x00,
+	0xdc, 0xc7, 0xc2, 0x73, 0xe5, 0xd5, 0x98, 0x9d, 0x13, 0xaa, 0x73, 0xec, 0xfa, 0x6a, 0xc1, 0x15,
+	0x5f, 0x21, 0x22, 0x50, 0x8c, 0x30, 0xd8, 0x9b, 0x50, 0xa4, 0xa3, 0xf7, 0x5d, 0xdd, 0xc0, 0x0c,
+	0x3c, 0x8b, 0xea, 0xb4, 0x41, 0x79, 0x00, 0x68, 0x1e, 0xbe, 0x51, 0x17, 0x72, 0xf8, 0x1c, 0xdb,
+	0x84, 0xae, 0x1a, 0x0d, 0xf7, 0xf5, 0x05, 0xf4, 0x10, 0xdb, 0xa4, 0xd5, 0xa0, 0x41, 0xfe, 0xc7,
+	0x97, 0xdb, 0x75, 0xae, 0xfd, 0x9c, 0x33, 0xb6, 0x08, 0x1e, 0xbb, 0xe4, 0x52, 0x15, 0xf6, 0xca,
+	0x5f, 0x52, 0x50, 0x93, 0x1d, 0x48, 0x8e, 0xb8, 0x28, 0xb6, 0xf2, 0x00, 0xa5, 0x42, 0x3c, 0x7d,
+	0xbd, 0x78, 0x6f, 0x01, 0x0c, 0x74, 0x5f, 0xfb, 0x50, 0xb7, 0x09, 0x36, 0x45, 0xd0, 0x43, 0x2d,
+	0xa8, 0x09, 0x05, 0x2a, 0x4d, 0x7c, 0x6c, 0x8a, 0x94, 0x21, 0x90, 0x43, 0xf3, 0xcc, 0x7f, 0xbb,
+	0x79, 0x46, 0xa3, 0x5c, 0x98, 0x89, 0x72, 0x88, 0x15, 0x15, 0xc3, 0xac, 0x88, 0x8e, 0xcd, 0xf5,
+	0x2c, 0xc7, 0xb3, 0xc8, 0x25, 0x5b, 0x9a, 0xb4, 0x1a, 0xc8, 0x34, 0x33, 0x1d, 0xe3, 0xb1, 0xeb,
+	0x38, 0x23, 0x8d, 0x83, 0x57, 0x89, 0x99, 0x96, 0x45, 0x63, 0x87, 0x61, 0xd8, 0xaf, 0x53, 0xb0,
+	0x31, 0x77, 0xf1, 0xfd, 0xef, 0x05, 0x58, 0xf9, 0x0d, 0x4b, 0xa2, 0xa3, 0x97, 0x37, 0x3a, 0x85,
+	0x8d, 0xe0, 0xf8, 0x6b, 0x13, 0x06, 0x0b, 0x72, 0x43, 0xaf, 0x8b, 0x1f, 0xf5, 0xf3, 0x68, 0xb3,
+	0x8f, 0x7e, 0x02, 0x8f, 0xce, 0x40, 0x5b, 0xe0, 0x3a, 0xb5, 0x26, 0xc2, 0x3d, 0x12, 0x45, 0x38,
+	0xe9, 0x79, 0x1a, 0xab, 0xf4, 0xb7, 0x3c, 0x74, 0x07, 0x50, 0x95, 0xc1, 0xe0, 0x54, 0x64, 0xe1,
+	0xea, 0x3f, 0x01, 0x15, 0x0f, 0x13, 0x5a, 0x2a, 0x88, 0x64, 0xbe, 0x65, 0xde, 0x28, 0xf2, 0xe9,
+	0x13, 0x78, 0x64, 0x21, 0x25, 0x41, 0xdf, 0x85, 0xe2, 0x94, 0xcd, 0x24, 0x63, 0x92, 0x48, 0xa9,
+	0xae, 0x4e, 0x75, 0x95, 0x3f, 0x24, 0xe1, 0x91, 0x85, 0xa4, 0x04, 0x75, 0x20, 0xe7, 0x61, 0x7f,
+	0x32, 0xe2, 0xa9, 0x4c, 0x75, 0xef, 0xf9, 0xf5, 0xc8, 0x0c, 0x6d, 0x9d, 0x8c, 0x88, 0x2a, 0x8c,
+	0x95, 0x07, 0x90, 0xe3, 0x2d, 0xa8, 0x04, 0xf9, 0x7b, 0x47, 0x77, 0x8f, 0x8e, 0xdf, 0x39, 0xaa,
+	0x27, 0x10, 0x40, 0x6e, 0xbf, 0xdd, 0xee, 0x9c, 0xf4, 0xea, 0x49, 0x54, 0x84, 0xec, 0x7e, 0xeb,
+	0x58, 0xed, 0xd5, 0x53, 0xb4, 0x59, 0xed, 0xbc, 0xd5, 0x69, 0xf7, 0xea, 0x69, 0xb4, 0x01, 0x15,
+	0xfe, 0xad, 0xdd, 0x39, 0x56, 0xdf, 0xde, 0xef, 0xd5, 0x33, 0xa1, 0xa6, 0xd3, 0xce, 0xd1, 0x1b,
+	0x1d, 0xb5, 0x9e, 0x55, 0x5e, 0x80, 0x1b, 0x72, 0x1c, 0xf3, 0xe9, 0x58, 0x90, 0x15, 0x25, 0x43,
+	0x59, 0x91, 0xf2, 0xbb, 0x14, 0x34, 0xe3, 0x39, 0x0d, 0x7a, 0x6b, 0x66, 0xe2, 0x7b, 0x57, 0x20,
+	0x44, 0x33, 0xb3, 0xa7, 0x75, 0x12, 0x0f, 0x9f, 0x61, 0x62, 0x0c, 0x39, 0xc7, 0xe2, 0x37, 0x66,
+	0x45, 0xad, 0x88, 0x56, 0x66, 0xe4, 0x73, 0xb5, 0xf7, 0xb1, 0x41, 0x34, 0x0e, 0x45, 0x7c, 0xd3,
+	0x15, 0xd5, 0x0a, 0x6f, 0x3d, 0xe5, 0x8d, 0xca, 0x7b, 0x57, 0x8a, 0x65, 0x11, 0xb2, 0x6a, 0xa7,
+	0xa7, 0xfe, 0xb4, 0x9e, 0x46, 0x08, 0xaa, 0xec, 0x53, 0x3b, 0x3d, 0xda, 0x3f, 0x39, 0xed, 0x1e,
+	0xd3, 0x58, 0x5e, 0x83, 0x9a, 0x8c, 0xa5, 0x6c, 0xcc, 0x2a, 0xff, 0x4e, 0x42, 0x6d, 0xe6, 0x80,
+	0xa0, 0x3d, 0xc8, 0x72, 0x9e, 0x1e, 0x57, 0xff, 0x67, 0xe7, 0x5b, 0x9c, 0xa6, 0x6c, 0x5f, 0x56,
+	0xa3, 0xb1, 0x28, 0x59, 0x2c, 0x3a, 0x88, 0xbc, 0xd4, 0x22, 0x8b, 0x1a, 0xc2, 0x34, 0xb0, 0xa0,
+	0x95, 0xe4, 0xe0, 0xa4, 0x37, 0xd2, 0xf3, 0xd9, 0x01, 0x37, 0x0f, 0x30, 0x42, 0xd8, 0x4f, 0x6d,
+	0xd0, 0xab, 0x53, 0xb2, 0x97, 0x99, 0xcf, 0x0e, 0x84, 0x39, 0x57, 0x10, 0xc6, 0x52, 0x5f, 0x69,
+	0x43, 0x29, 0x34, 0x1f, 0xf4, 0x18, 0x14, 0xc7, 0xfa, 0x85, 0x28, 0x85, 0xf1, 0xd2, 0x44, 0x61,
+	0xac, 0x5f, 0xf0, 0x2a, 0xd8, 0xa3, 0x90, 0xa7, 0x3f, 0x0e, 0x74, 0x8e, 0x36, 0x69, 0x35, 0x37,
+	0xd6, 0x2f, 0xde, 0xd4, 0x7d, 0xe5, 0x5d, 0xa8, 0x46, 0xcb, 0x40, 0x74, 0x27, 0x7a, 0xce, 0xc4,
+	0x36, 0x99, 0x8f, 0xac, 0xca, 0x05, 0xfa, 0x64, 0x70, 0xee, 0x70, 0xb0, 0x5a, 0x7c, 0x64, 0xef,
+	0x3b, 0x04, 0x87, 0xca, 0x48, 0x5c, 0x5b, 0xf9, 0x08, 0xb2, 0x0c, 0x7c, 0x28, 0x90, 0xb0, 0x7a,
+	0x8e, 0x20, 0xba, 0xf4, 0x1b, 0xbd, 0x0b, 0xa0, 0x13, 0xe2, 0x59, 0xfd, 0xc9, 0xd4, 0xf1, 0xf6,
+	0x62, 0xf0, 0xda, 0x97, 0x7a, 0xad, 0x9b, 0x02, 0xc5, 0x36, 0xa7, 0xa6, 0x21, 0x24, 0x0b, 0x39,
+	0x54, 0x8e, 0xa0, 0x1a, 0xb5, 0x0d, 0x97, 0x56, 0xcb, 0x0b, 0x4a, 0xab, 0x01, 0x99, 0x0a, 0xa8,
+	0x58, 0x9a, 0xd7, 0x8b, 0x98, 0xa0, 0x7c, 0x92, 0x84, 0x42, 0xef, 0x42, 0x6c, 0xeb, 0x98, 0x2a,
+	0xd0, 0xd4, 0x34, 0x15, 0xae, 0x79, 0xf0, 0xb2, 0x52, 0x3a, 0xa8, 0x6e, 0xbd, 0x1e, 0x1c, 0xdc,
+	0xcc, 0xba, 0x59, 0xa9, 0xac, 0xf3, 0x09, 0xb0, 0x7a, 0x0d, 0x8a, 0xc1, 0xae, 0xa2, 0x19, 0x83,
+	0x6e, 0x9a, 0x1e, 0xf6, 0x7d, 0x31, 0x37, 0x29, 0xd2, 0xe1, 0xb8, 0xce, 0x87, 0xa2, 0xaa, 0x92,
+	0x56, 0xb9, 0xa0, 0x98, 0x50, 0x9b, 0xb9, 0xb6, 0xd0, 0x6b, 0x90, 0x77, 0x27, 0x7d, 0x4d, 0x86,
+	0x67, 0xe6, 0xf0, 0x48, 0xf6, 0x38, 0xe9, 0x8f, 0x2c, 0xe3, 0x2e, 0xbe, 0x94, 0x83, 0x71, 0x27,
+	0xfd, 0xbb, 0x3c, 0x8a, 0xbc, 0x97, 0x54, 0xb8, 0x97, 0x73, 0x28, 0xc8, 0x4d, 0x81, 0x7e, 0x18,
+	0x3e, 0x27, 0xb2, 0x38, 0x1d, 0x7b, 0x95, 0x0a, 0xf7, 0x53, 0x13, 0x9a, 0xd8, 0xf8, 0xd6, 0xc0,
+	0xc6, 0xa6, 0x36, 0xcd, 0x59, 0x58, 0x6f, 0x05, 0xb5, 0xc6, 0x7f, 0x38, 0x94, 0x09, 0x8b, 0xf2,
+	0xaf, 0x24, 0x14, 0xe4, 0x81, 0x45, 0x2f, 0x84, 0xf6, 0x5d, 0x75, 0x41, 0x05, 0x46, 0x2a, 0x86,
+	0x0a, 0x89, 0x91, 0xb1, 0xa6, 0xae, 0x3e, 0xd6, 0xb8, 0x92, 0xb0, 0xac, 0xcd, 0x67, 0xae, 0x5c,
+	0x9b, 0x7f, 0x0e, 0x10, 0x71, 0x88, 0x3e, 0xd2, 0xce, 0x1d, 0x62, 0xd9, 0x03, 0x8d, 0x07, 0x9b,
+	0x33, 0xaa, 0x3a, 0xfb, 0xe5, 0x3e, 0xfb, 0xe1, 0x84, 0xc5, 0xfd, 0x97, 0x49, 0x28, 0x04, 0x77,
+	0xe3, 0x55, 0xcb, 0x7c, 0xd7, 0x21, 0x27, 0xe0, 0x9f, 0xd7, 0xf9, 0x84, 0x14, 0xd4, 0xa8, 0x33,
+	0xa1, 0x1a, 0x75, 0x13, 0x0a, 0x63, 0x4c, 0x74, 0x46, 0x10, 0x78, 0xda, 0x18, 0xc8, 0xb7, 0x5f,
+	0x85, 0x52, 0xa8, 0x44, 0x4b, 0x4f, 0xde, 0x51, 0xe7, 0x9d, 0x7a, 0xa2, 0x99, 0xff, 0xe4, 0xb3,
+	0x5b, 0xe9, 0x23, 0xfc, 0x21, 0xdd, 0xb3, 0x6a, 0xa7, 0xdd, 0xed, 0xb4, 0xef, 0xd6, 0x93, 0xcd,
+	0xd2, 0x27, 0x9f, 0xdd, 0xca, 0xab, 0x98, 0x15, 0x6e, 0x6e, 0x77, 0xa1, 0x1c, 0x5e, 0x95, 0xe8,
+	0x0d, 0x82, 0xa0, 0xfa, 0xc6, 0xbd, 0x93, 0xc3, 0x83, 0xf6, 0x7e, 0xaf, 0xa3, 0xdd, 0x3f, 0xee,
+	0x75, 0xea, 0x49, 0xf4, 0x28, 0x5c, 0x3b, 0x3c, 0x78, 0xb3, 0xdb, 0xd3, 0xda, 0x87, 0x07, 0x9d,
+	0xa3, 0x9e, 0xb6, 0xdf, 0xeb, 0xed, 0xb7, 0xef, 0xd6, 0x53, 0x7b, 0x1f, 0x03, 0xd4, 0xf6, 0x5b,
+	0xed, 0x03, 0x7a, 0xfb, 0x59, 0x86, 0x2e, 0x0a, 0x63, 0x19, 0x96, 0xb5, 0x2f, 0x7d, 0x1c, 0x6e,
+	0x2e, 0xaf, 0x0b, 0xa2, 0x3b, 0x90, 0x65, 0x09, 0x3d, 0x5a, 0xfe, 0x5a, 0xdc, 0x5c, 0x51, 0x28,
+	0xa4, 0x83, 0x61, 0xc7, 0x63, 0xe9, 0xf3, 0x71, 0x73, 0x79, 0xdd, 0x10, 0xa9, 0x50, 0x9c, 0x66,
+	0xe4, 0xab, 0x9f, 0x93, 0x9b, 0x6b, 0xd4, 0x12, 0xa9, 0xcf, 0x69, 0x5a, 0xb0, 0xfa, 0x79, 0xb5,
+	0xb9, 0x06, 0x80, 0xa1, 0x43, 0xc8, 0xcb, 0x4c, 0x6e, 0xd5, 0x83, 0x6f, 0x73, 0x65, 0x9d, 0x8f,
+	0x2e, 0x01, 0xcf, 0xb8, 0x97, 0xbf, 0x5e, 0x37, 0x57, 0x14, 0x2d, 0xd1, 0x01, 0xe4, 0x04, 0xd7,
+	0x5d, 0xf1, 0x88, 0xdb, 0x5c, 0x55, 0xb7, 0xa3, 0x41, 0x9b, 0x96, 0x32, 0x56, 0xbf, 0xc9, 0x37,
+	0xd7, 0xa8, 0xc7, 0xa2, 0x7b, 0x00, 0xa1, 0xfc, 0x7a, 0x8d, 0xc7, 0xf6, 0xe6, 0x3a, 0x75, 0x56,
+	0x74, 0x0c, 0x85, 0x20, 0xdd, 0x59, 0xf9, 0xf4, 0xdd, 0x5c, 0x5d, 0xf0, 0x44, 0x0f, 0xa0, 0x12,
+	0xe5, 0xf9, 0xeb, 0x3d, 0x68, 0x37, 0xd7, 0xac, 0x64, 0x52, 0xff, 0x51, 0xd2, 0xbf, 0xde, 0x03,
+	0x77, 0x73, 0xcd, 0xc2, 0x26, 0x7a, 0x1f, 0x36, 0xe6, 0x49, 0xf9, 0xfa, 0xef, 0xdd, 0xcd, 0x2b,
+	0x94, 0x3a, 0xd1, 0x18, 0xd0, 0x02, 0x32, 0x7f, 0x85, 0xe7, 0xef, 0xe6, 0x55, 0x2a, 0x9f, 0xad,
+	0xce, 0xe7, 0x5f, 0x6d, 0x25, 0xbf, 0xf8, 0x6a, 0x2b, 0xf9, 0xb7, 0xaf, 0xb6, 0x92, 0x9f, 0x7e,
+	0xbd, 0x95, 0xf8, 0xe2, 0xeb, 0xad, 0xc4, 0x9f, 0xbf, 0xde, 0x4a, 0xfc, 0xec, 0xd9, 0x81, 0x45,
+	0x86, 0x93, 0xfe, 0x8e, 0xe1, 0x8c, 0x77, 0xc3, 0xff, 0xcd, 0x59, 0xf4, 0x7f, 0xa1, 0x7e, 0x8e,
+	0x5d, 0x54, 0x2f, 0xfe, 0x67, 0x00, 0x7f, 0xff, 0xbd, 0x88, 0x4f, 0x24, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4675,6 +4697,23 @@ func (m *RequestCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Local {
+		i--
+		if m.Local {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.TraceId) > 0 {
+		i -= len(m.TraceId)
+		copy(dAtA[i:], m.TraceId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TraceId)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Type != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
 		i--
@@ -6933,6 +6972,13 @@ func (m *RequestCheckTx) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovTypes(uint64(m.Type))
 	}
+	l = len(m.TraceId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Local {
+		n += 2
+	}
 	return n
 }
 
@@ -9413,6 +9459,58 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Local", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Local = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -47,3 +47,13 @@ type blockPart struct {
 func BlockPart(peerID p2p.ID, explanation string) PeerBehaviour {
 	return PeerBehaviour{peerID: peerID, reason: blockPart{explanation}}
 }
+
+type badBlock struct {
+	explanation string
+}
+
+// BadBlock returns a badBlock PeerBehaviour. Unlike a bad message, a bad
+// block gets the peer banned.
+func BadBlock(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: badBlock{explanation}}
+}
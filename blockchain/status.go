@@ -0,0 +1,18 @@
+package blockchain
+
+import (
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// SyncStatus describes the progress of fast sync.
+type SyncStatus struct {
+	// Syncing is true while the node is fast syncing.
+	Syncing bool
+	// Height is the height of the latest block in the block store.
+	Height int64
+	// TargetHeight is the highest height reported by peers, or Height if it
+	// is higher.
+	TargetHeight int64
+	// Peers are the peers blocks are downloaded from.
+	Peers []p2p.ID
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -286,6 +287,19 @@ func (pool *BlockPool) MaxPeerHeight() int64 {
 	return pool.maxPeerHeight
 }
 
+// PeerIDs returns the IDs of the peers blocks are requested from, sorted.
+func (pool *BlockPool) PeerIDs() []p2p.ID {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	ids := make([]p2p.ID, 0, len(pool.peers))
+	for id := range pool.peers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 // SetPeerRange sets the peer's alleged blockchain base and height.
 func (pool *BlockPool) SetPeerRange(peerID p2p.ID, base int64, height int64) {
 	pool.mtx.Lock()
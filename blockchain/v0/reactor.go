@@ -128,6 +128,21 @@ func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
 	return nil
 }
 
+// SyncStatus returns the progress of fast sync.
+func (bcR *BlockchainReactor) SyncStatus() bc.SyncStatus {
+	height := bcR.store.Height()
+	targetHeight := bcR.pool.MaxPeerHeight()
+	if targetHeight < height {
+		targetHeight = height
+	}
+	return bc.SyncStatus{
+		Syncing:      bcR.pool.IsRunning(),
+		Height:       height,
+		TargetHeight: targetHeight,
+		Peers:        bcR.pool.PeerIDs(),
+	}
+}
+
 // OnStop implements service.Service.
 func (bcR *BlockchainReactor) OnStop() {
 	if bcR.fastSync {
@@ -198,7 +213,7 @@ func (bcR *BlockchainReactor) respondToPeer(msg *bcproto.BlockRequest,
 func (bcR *BlockchainReactor) ReceiveEnvelope(e p2p.Envelope) {
 	if err := bc.ValidateMsg(e.Message); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", e.Message, "err", err)
-		bcR.Switch.StopPeerForError(e.Src, err)
+		bcR.Switch.StopPeerForMisbehavior(e.Src, err)
 		return
 	}
 
@@ -366,6 +381,9 @@ FOR_LOOP:
 			err := state.Validators.VerifyCommitLight(
 				chainID, firstID, first.Height, second.LastCommit)
 
+			if err == nil {
+				err = sm.ValidateBlockSize(state, firstParts)
+			}
 			if err == nil {
 				// validate the block before we persist it
 				err = bcR.blockExec.ValidateBlock(state, first)
@@ -378,8 +396,10 @@ FOR_LOOP:
 				if peer != nil {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer, fmt.Errorf("blockchainReactor validation error: %v", err))
+					bcR.Switch.StopPeerForMisbehavior(peer, fmt.Errorf("blockchainReactor validation error: %v", err))
 				}
+				// the second peer only sent the commit, which may be the one at
+				// fault, so it is disconnected without being scored
 				peerID2 := bcR.pool.RedoRequest(second.Height)
 				peer2 := bcR.Switch.Peers().Get(peerID2)
 				if peer2 != nil && peer2 != peer {
@@ -184,6 +184,10 @@ func TestNoBlockResponse(t *testing.T) {
 
 	assert.Equal(t, maxBlockHeight, reactorPairs[0].reactor.store.Height())
 
+	status := reactorPairs[1].reactor.SyncStatus()
+	assert.Equal(t, maxBlockHeight, status.TargetHeight)
+	assert.Equal(t, []p2p.ID{reactorPairs[0].reactor.Switch.NodeInfo().ID()}, status.Peers)
+
 	for _, tt := range tests {
 		block := reactorPairs[1].reactor.store.LoadBlock(tt.height)
 		if tt.existent {
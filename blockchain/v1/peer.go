@@ -31,6 +31,7 @@ type BpPeer struct {
 	Height                  int64                  // the peer reported height
 	NumPendingBlockRequests int                    // number of requests still waiting for block responses
 	blocks                  map[int64]*types.Block // blocks received or expected to be received from this peer
+	requestTimes            map[int64]time.Time    // times the pending requests were sent
 	blockResponseTimer      *time.Timer
 	recvMonitor             *flow.Monitor
 	params                  *BpPeerParams // parameters for timer and monitor
@@ -46,13 +47,14 @@ func NewBpPeer(peerID p2p.ID, base int64, height int64,
 		params = BpPeerDefaultParams()
 	}
 	return &BpPeer{
-		ID:     peerID,
-		Base:   base,
-		Height: height,
-		blocks: make(map[int64]*types.Block, maxRequestsPerPeer),
-		logger: log.NewNopLogger(),
-		onErr:  onErr,
-		params: params,
+		ID:           peerID,
+		Base:         base,
+		Height:       height,
+		blocks:       make(map[int64]*types.Block, maxRequestsPerPeer),
+		requestTimes: make(map[int64]time.Time, maxRequestsPerPeer),
+		logger:       log.NewNopLogger(),
+		onErr:        onErr,
+		params:       params,
 	}
 }
 
@@ -80,6 +82,9 @@ func (peer *BpPeer) Cleanup() {
 	for h := range peer.blocks {
 		delete(peer.blocks, h)
 	}
+	for h := range peer.requestTimes {
+		delete(peer.requestTimes, h)
+	}
 	peer.NumPendingBlockRequests = 0
 	peer.recvMonitor = nil
 }
@@ -115,6 +120,7 @@ func (peer *BpPeer) AddBlock(block *types.Block, recvSize int) error {
 		panic("peer does not have pending requests")
 	}
 	peer.blocks[block.Height] = block
+	delete(peer.requestTimes, block.Height)
 	peer.NumPendingBlockRequests--
 	if peer.NumPendingBlockRequests == 0 {
 		peer.stopMonitor()
@@ -129,11 +135,14 @@ func (peer *BpPeer) AddBlock(block *types.Block, recvSize int) error {
 // RemoveBlock removes the block of given height
 func (peer *BpPeer) RemoveBlock(height int64) {
 	delete(peer.blocks, height)
+	delete(peer.requestTimes, height)
 }
 
 // RequestSent records that a request was sent, and starts the peer timer and monitor if needed.
+// Every request must be answered within the peer timeout.
 func (peer *BpPeer) RequestSent(height int64) {
 	peer.blocks[height] = nil
+	peer.requestTimes[height] = time.Now()
 
 	if peer.NumPendingBlockRequests == 0 {
 		peer.startMonitor()
@@ -175,12 +184,27 @@ func (peer *BpPeer) startMonitor() {
 	peer.recvMonitor.SetREMA(initialValue)
 }
 
+// resetBlockResponseTimer sets the timer to fire when the oldest pending
+// request times out.
 func (peer *BpPeer) resetBlockResponseTimer() {
+	timeout := peer.params.timeout
+	if oldest, ok := peer.oldestRequestTime(); ok {
+		timeout = time.Until(oldest.Add(peer.params.timeout))
+	}
 	if peer.blockResponseTimer == nil {
-		peer.blockResponseTimer = time.AfterFunc(peer.params.timeout, peer.onTimeout)
+		peer.blockResponseTimer = time.AfterFunc(timeout, peer.onTimeout)
 	} else {
-		peer.blockResponseTimer.Reset(peer.params.timeout)
+		peer.blockResponseTimer.Reset(timeout)
+	}
+}
+
+func (peer *BpPeer) oldestRequestTime() (oldest time.Time, ok bool) {
+	for _, t := range peer.requestTimes {
+		if !ok || t.Before(oldest) {
+			oldest, ok = t, true
+		}
 	}
+	return oldest, ok
 }
 
 func (peer *BpPeer) stopBlockResponseTimer() bool {
@@ -92,6 +92,32 @@ func TestPeerRequestSent(t *testing.T) {
 	assert.Equal(t, 2, peer.NumPendingBlockRequests)
 }
 
+func TestPeerTimesOutEveryRequest(t *testing.T) {
+	params := &BpPeerParams{timeout: time.Hour}
+	timedOut := make(chan error, 1)
+
+	peer := NewBpPeer(
+		p2p.ID(tmrand.Str(12)), 0, 10,
+		func(err error, _ p2p.ID) { timedOut <- err },
+		params)
+	peer.SetLogger(log.TestingLogger())
+
+	peer.RequestSent(1)
+	peer.RequestSent(2)
+	// pretend the request for block 2 has been pending for the whole timeout
+	peer.requestTimes[2] = time.Now().Add(-params.timeout)
+
+	// receiving block 1 must not give the request for block 2 more time
+	require.NoError(t, peer.AddBlock(makeSmallBlock(1), 10))
+	select {
+	case err := <-timedOut:
+		assert.Equal(t, errNoPeerResponse, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected the request for block 2 to time out")
+	}
+	peer.Cleanup()
+}
+
 func TestPeerGetAndRemoveBlock(t *testing.T) {
 	peer := NewBpPeer(
 		p2p.ID(tmrand.Str(12)), 0, 100,
@@ -209,15 +209,11 @@ func (pool *BlockPool) makeRequestBatch(maxNumRequests int) []int {
 	return heights
 }
 
+// sendRequest sends the request for the block at height to the peer with the
+// fewest pending requests, so that blocks are downloaded from all peers in
+// parallel.
 func (pool *BlockPool) sendRequest(height int64) bool {
-	for _, peer := range pool.peers {
-		if peer.NumPendingBlockRequests >= maxRequestsPerPeer {
-			continue
-		}
-		if peer.Base > height || peer.Height < height {
-			continue
-		}
-
+	for _, peer := range pool.requestCandidates(height) {
 		err := pool.toBcR.sendBlockRequest(peer.ID, height)
 		if err == errNilPeerForBlockRequest {
 			// Switch does not have this peer, remove it and continue to look for another peer.
@@ -243,6 +239,28 @@ func (pool *BlockPool) sendRequest(height int64) bool {
 	return false
 }
 
+// requestCandidates returns the peers that have the block at height and can
+// take another request, least busy first.
+func (pool *BlockPool) requestCandidates(height int64) []*BpPeer {
+	candidates := make([]*BpPeer, 0, len(pool.peers))
+	for _, peer := range pool.peers {
+		if peer.NumPendingBlockRequests >= maxRequestsPerPeer {
+			continue
+		}
+		if peer.Base > height || peer.Height < height {
+			continue
+		}
+		candidates = append(candidates, peer)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].NumPendingBlockRequests != candidates[j].NumPendingBlockRequests {
+			return candidates[i].NumPendingBlockRequests < candidates[j].NumPendingBlockRequests
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates
+}
+
 // AddBlock validates that the block comes from the peer it was expected from and stores it in the 'blocks' map.
 func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int) error {
 	peer, ok := pool.peers[peerID]
@@ -359,12 +377,23 @@ func (pool *BlockPool) Cleanup() {
 	pool.MaxPeerHeight = 0
 }
 
+// PeerIDs returns the IDs of the peers in the pool, sorted.
+func (pool *BlockPool) PeerIDs() []p2p.ID {
+	ids := make([]p2p.ID, 0, len(pool.peers))
+	for id := range pool.peers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 // NumPeers returns the number of peers in the pool
 func (pool *BlockPool) NumPeers() int {
 	return len(pool.peers)
 }
 
-// NeedsBlocks returns true if more blocks are required.
-func (pool *BlockPool) NeedsBlocks() bool {
+// NeedsBlocks returns true if more blocks are required, given the maximum
+// number of pending requests.
+func (pool *BlockPool) NeedsBlocks(maxNumRequests int) bool {
 	return len(pool.blocks) < maxNumRequests
 }
@@ -372,6 +372,26 @@ func TestBlockPoolSendRequestBatch(t *testing.T) {
 	}
 }
 
+func TestBlockPoolSendRequestToLeastBusyPeer(t *testing.T) {
+	testBcR := newTestBcR()
+	resetPoolTestResults()
+
+	pool := makeBlockPool(testBcR, 10,
+		[]BpPeer{{ID: "P1", Height: 100}, {ID: "P2", Height: 100}, {ID: "P3", Height: 100}},
+		map[int64]tPBlocks{10: {"P1", false}, 11: {"P1", false}, 12: {"P2", false}})
+	pool.nextRequestHeight = 13
+	maxRequestsPerPeer = 20
+
+	pool.MakeNextRequests(15)
+
+	// the requests are spread so that all peers end up equally busy
+	for _, id := range []p2p.ID{"P1", "P2", "P3"} {
+		assert.Equal(t, 5, pool.peers[id].NumPendingBlockRequests, "peer %v", id)
+	}
+	assert.Equal(t, "P3", string(pool.blocks[13]))
+	assert.Equal(t, "P2", string(pool.blocks[14]))
+}
+
 func TestBlockPoolAddBlock(t *testing.T) {
 	testBcR := newTestBcR()
 	txs := []types.Tx{types.Tx("foo"), types.Tx("bar")}
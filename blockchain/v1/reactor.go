@@ -30,7 +30,7 @@ var (
 	// Maximum number of requests that can be pending per peer, i.e. for which requests have been sent but blocks
 	// have not been received.
 	maxRequestsPerPeer = 20
-	// Maximum number of block requests for the reactor, pending or for which blocks have been received.
+	// Default maximum number of block requests for the reactor, pending or for which blocks have been received.
 	maxNumRequests = 64
 )
 
@@ -56,6 +56,9 @@ type BlockchainReactor struct {
 	fsm          *BcReactorFSM
 	blocksSynced uint64
 
+	// Maximum number of block requests, pending or for which blocks have been received.
+	maxPendingRequests int
+
 	// Receive goroutine forwards messages to this channel to be processed in the context of the poolRoutine.
 	messagesForFSMCh chan bcReactorMessage
 
@@ -70,9 +73,18 @@ type BlockchainReactor struct {
 	swReporter *behaviour.SwitchReporter
 }
 
+// ReactorOption sets an optional parameter on the BlockchainReactor.
+type ReactorOption func(*BlockchainReactor)
+
+// WithMaxPendingRequests sets the maximum number of block requests that are
+// pending or for which blocks have been received, but not yet applied.
+func WithMaxPendingRequests(n int) ReactorOption {
+	return func(bcR *BlockchainReactor) { bcR.maxPendingRequests = n }
+}
+
 // NewBlockchainReactor returns new reactor instance.
 func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
-	fastSync bool) *BlockchainReactor {
+	fastSync bool, options ...ReactorOption) *BlockchainReactor {
 
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
@@ -89,14 +101,18 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		startHeight = state.InitialHeight
 	}
 	bcR := &BlockchainReactor{
-		initialState:     state,
-		state:            state,
-		blockExec:        blockExec,
-		fastSync:         fastSync,
-		store:            store,
-		messagesForFSMCh: messagesForFSMCh,
-		eventsFromFSMCh:  eventsFromFSMCh,
-		errorsForFSMCh:   errorsForFSMCh,
+		initialState:       state,
+		state:              state,
+		blockExec:          blockExec,
+		fastSync:           fastSync,
+		store:              store,
+		maxPendingRequests: maxNumRequests,
+		messagesForFSMCh:   messagesForFSMCh,
+		eventsFromFSMCh:    eventsFromFSMCh,
+		errorsForFSMCh:     errorsForFSMCh,
+	}
+	for _, option := range options {
+		option(bcR)
 	}
 	fsm := NewFSM(startHeight, bcR)
 	bcR.fsm = fsm
@@ -396,13 +412,13 @@ ForLoop:
 		select {
 
 		case <-sendBlockRequestTicker.C:
-			if !bcR.fsm.NeedsBlocks() {
+			if !bcR.fsm.NeedsBlocks(bcR.maxPendingRequests) {
 				continue
 			}
 			_ = bcR.fsm.Handle(&bcReactorMessage{
 				event: makeRequestsEv,
 				data: bReactorEventData{
-					maxNumRequests: maxNumRequests}})
+					maxNumRequests: bcR.maxPendingRequests}})
 
 		case <-statusUpdateTicker.C:
 			// Ask for status updates.
@@ -453,11 +469,33 @@ ForLoop:
 
 func (bcR *BlockchainReactor) reportPeerErrorToSwitch(err error, peerID p2p.ID) {
 	peer := bcR.Switch.Peers().Get(peerID)
-	if peer != nil {
+	if peer == nil {
+		return
+	}
+	switch err {
+	case errBadDataFromPeer, errDuplicateBlock:
+		// The peer provably sent a bad block, ban it.
+		_ = bcR.swReporter.Report(behaviour.BadBlock(peerID, err.Error()))
+	default:
 		_ = bcR.swReporter.Report(behaviour.BadMessage(peerID, err.Error()))
 	}
 }
 
+// SyncStatus returns the progress of fast sync.
+func (bcR *BlockchainReactor) SyncStatus() bc.SyncStatus {
+	syncing, maxPeerHeight, peers := bcR.fsm.Progress()
+	height := bcR.store.Height()
+	if maxPeerHeight < height {
+		maxPeerHeight = height
+	}
+	return bc.SyncStatus{
+		Syncing:      syncing,
+		Height:       height,
+		TargetHeight: maxPeerHeight,
+		Peers:        peers,
+	}
+}
+
 func (bcR *BlockchainReactor) processBlock() error {
 
 	first, second, err := bcR.fsm.FirstTwoBlocks()
@@ -476,6 +514,9 @@ func (bcR *BlockchainReactor) processBlock() error {
 	// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 	// currently necessary.
 	err = bcR.state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
+	if err == nil {
+		err = sm.ValidateBlockSize(bcR.state, firstParts)
+	}
 	if err != nil {
 		bcR.Logger.Error("error during commit verification", "err", err,
 			"first", first.Height, "second", second.Height)
@@ -284,10 +284,16 @@ func init() {
 					first, second, _ := fsm.pool.FirstTwoBlocksAndPeers()
 					fsm.logger.Error("error processing block", "err", data.err,
 						"first", first.block.Height, "second", second.block.Height)
-					fsm.logger.Error("send peer error for", "peer", first.peer.ID)
-					fsm.toBcR.sendPeerError(data.err, first.peer.ID)
-					fsm.logger.Error("send peer error for", "peer", second.peer.ID)
-					fsm.toBcR.sendPeerError(data.err, second.peer.ID)
+					if first.peer.ID == second.peer.ID {
+						// Only the peer that sent both blocks can be at fault.
+						fsm.logger.Error("send peer error for", "peer", first.peer.ID)
+						fsm.toBcR.sendPeerError(errBadDataFromPeer, first.peer.ID)
+					} else {
+						fsm.logger.Error("send peer error for", "peer", first.peer.ID)
+						fsm.toBcR.sendPeerError(data.err, first.peer.ID)
+						fsm.logger.Error("send peer error for", "peer", second.peer.ID)
+						fsm.toBcR.sendPeerError(data.err, second.peer.ID)
+					}
 					// Remove the first two blocks. This will also remove the peers
 					fsm.pool.InvalidateFirstTwoBlocks(data.err)
 				} else {
@@ -435,11 +441,12 @@ func (fsm *BcReactorFSM) cleanup() {
 	fsm.pool.Cleanup()
 }
 
-// NeedsBlocks checks if more block requests are required.
-func (fsm *BcReactorFSM) NeedsBlocks() bool {
+// NeedsBlocks checks if more block requests are required, given the maximum
+// number of pending requests.
+func (fsm *BcReactorFSM) NeedsBlocks(maxNumRequests int) bool {
 	fsm.mtx.Lock()
 	defer fsm.mtx.Unlock()
-	return fsm.state.name == "waitForBlock" && fsm.pool.NeedsBlocks()
+	return fsm.state.name == "waitForBlock" && fsm.pool.NeedsBlocks(maxNumRequests)
 }
 
 // FirstTwoBlocks returns the two blocks at pool height and height+1
@@ -460,3 +467,12 @@ func (fsm *BcReactorFSM) Status() (height, maxPeerHeight int64) {
 	defer fsm.mtx.Unlock()
 	return fsm.pool.Height, fsm.pool.MaxPeerHeight
 }
+
+// Progress returns whether the FSM is syncing, the maximum peer height and the
+// IDs of the peers blocks are downloaded from.
+func (fsm *BcReactorFSM) Progress() (syncing bool, maxPeerHeight int64, peers []p2p.ID) {
+	fsm.mtx.Lock()
+	defer fsm.mtx.Unlock()
+	syncing = fsm.state == waitForPeer || fsm.state == waitForBlock
+	return syncing, fsm.pool.MaxPeerHeight, fsm.pool.PeerIDs()
+}
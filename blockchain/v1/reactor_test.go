@@ -238,6 +238,11 @@ func TestFastSyncNoBlockResponse(t *testing.T) {
 
 	assert.Equal(t, maxBlockHeight, reactorPairs[0].bcR.store.Height())
 
+	status := reactorPairs[1].bcR.SyncStatus()
+	assert.False(t, status.Syncing)
+	assert.Equal(t, reactorPairs[1].bcR.store.Height(), status.Height)
+	assert.GreaterOrEqual(t, status.TargetHeight, status.Height)
+
 	for _, tt := range tests {
 		block := reactorPairs[1].bcR.store.LoadBlock(tt.height)
 		if tt.existent {
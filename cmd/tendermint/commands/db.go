@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	nm "github.com/tendermint/tendermint/node"
+)
+
+// sharedDBName is the name of the DB shared by the stores of a node using
+// node.SharedDBProvider, or empty if they have a DB each.
+var sharedDBName string
+
+// addSharedDBFlag adds the flag of commands opening the DBs of a node which
+// tells them whether it shares one DB between its stores.
+func addSharedDBFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&sharedDBName, "shared-db", "",
+		"name of the DB shared by the stores of the node, if it uses node.SharedDBProvider")
+}
+
+// dbProvider returns the DBProvider opening the DBs of the node as it does.
+// All of the DBs of a command must be opened with the same provider, since a
+// shared DB can only be opened once.
+func dbProvider() nm.DBProvider {
+	if sharedDBName != "" {
+		return nm.SharedDBProvider(sharedDBName)
+	}
+	return nm.DefaultDBProvider
+}
+
+// dbExists tells whether the DB of the node with the given ID, or the DB it
+// shares, exists.
+func dbExists(config *cfg.Config, id string) bool {
+	if sharedDBName != "" {
+		id = sharedDBName
+	}
+	return tmos.FileExists(filepath.Join(config.DBDir(), id+".db"))
+}
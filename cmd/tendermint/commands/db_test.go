@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	nm "github.com/tendermint/tendermint/node"
+)
+
+func TestDBProviderSharedDB(t *testing.T) {
+	config := cfg.TestConfig()
+	config.DBBackend = "goleveldb"
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+
+	// the node saves its stores to a shared DB
+	db, err := nm.SharedDBProvider("tendermint")(&nm.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	assert.False(t, dbExists(config, "blockstore"))
+
+	sharedDBName = "tendermint"
+	t.Cleanup(func() { sharedDBName = "" })
+	require.True(t, dbExists(config, "blockstore"))
+
+	provider := dbProvider()
+	db, err = provider(&nm.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	// the other stores are opened from the same DB
+	stateDB, err := provider(&nm.DBContext{ID: "state", Config: config})
+	require.NoError(t, err)
+	require.NoError(t, stateDB.Close())
+	require.NoError(t, db.Close())
+}
+
+func TestLoadStateAndBlockStoreSharedDB(t *testing.T) {
+	config := cfg.TestConfig()
+	config.DBBackend = "goleveldb"
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+
+	db, err := nm.SharedDBProvider("tendermint")(&nm.DBContext{ID: "state", Config: config})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// without the flag, the DBs of the stores are looked for
+	_, _, err = loadStateAndBlockStore(config, nm.DefaultDBProvider)
+	require.Error(t, err)
+
+	sharedDBName = "tendermint"
+	t.Cleanup(func() { sharedDBName = "" })
+	blockStore, stateStore, err := loadStateAndBlockStore(config, dbProvider())
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Close())
+	require.NoError(t, blockStore.Close())
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/protoio"
+	nm "github.com/tendermint/tendermint/node"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	exportBlocksStartHeight int64
+	exportBlocksEndHeight   int64
+)
+
+// ExportBlocksCmd writes a range of blocks of the block store, with their
+// commits, to a file which import-blocks reads.
+var ExportBlocksCmd = &cobra.Command{
+	Use:   "export-blocks [file]",
+	Short: "Export blocks and their commits to a file",
+	Long: `
+export-blocks writes a range of the blocks stored by this node, each followed by
+the commit for it, to a file. Another node of the same chain can import them
+with import-blocks, to back them up or to bootstrap from them without fetching
+them from peers. The default start-height is 0, meaning the base of the block
+store, and the default end-height is 0, meaning the latest block.
+
+The node must be stopped before running this command.
+`,
+	Example: `
+	tendermint export-blocks blocks.bin
+	tendermint export-blocks blocks.bin --start-height 2 --end-height 10
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockStore, err := loadBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer blockStore.Close()
+
+		from, to := exportBlocksStartHeight, exportBlocksEndHeight
+		if from == 0 {
+			from = blockStore.Base()
+		}
+		if to == 0 {
+			to = blockStore.Height()
+		}
+
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := exportBlocks(blockStore, from, to, f); err != nil {
+			return fmt.Errorf("failed to export blocks: %w", err)
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Exported blocks %d to %d to %s\n", from, to, args[0])
+		return nil
+	},
+}
+
+func init() {
+	ExportBlocksCmd.Flags().Int64Var(&exportBlocksStartHeight, "start-height", 0, "height of the first block to export")
+	ExportBlocksCmd.Flags().Int64Var(&exportBlocksEndHeight, "end-height", 0, "height of the last block to export")
+	addSharedDBFlag(ExportBlocksCmd)
+}
+
+func loadBlockStore(config *cfg.Config) (*store.BlockStore, error) {
+	if !dbExists(config, "blockstore") {
+		return nil, fmt.Errorf("no blockstore found in %v", config.DBDir())
+	}
+	db, err := dbProvider()(&nm.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return nil, err
+	}
+	return store.NewBlockStore(db), nil
+}
+
+// exportBlocks writes the blocks of the range of heights [from, to], each
+// followed by its commit, as length-delimited tmproto.Block and tmproto.Commit
+// messages.
+func exportBlocks(blockStore *store.BlockStore, from, to int64, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	pw := protoio.NewDelimitedWriter(bw)
+	err := blockStore.LoadBlockRange(from, to, func(block *types.Block, commit *types.Commit) error {
+		pbb, err := block.ToProto()
+		if err != nil {
+			return err
+		}
+		if _, err := pw.WriteMsg(pbb); err != nil {
+			return err
+		}
+		_, err = pw.WriteMsg(commit.ToProto())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// readExportedBlock reads the next block and its commit written by
+// exportBlocks. It returns io.EOF at the end of the blocks.
+func readExportedBlock(r protoio.Reader) (*types.Block, *types.Commit, error) {
+	var pbb tmproto.Block
+	if _, err := r.ReadMsg(&pbb); err != nil {
+		return nil, nil, err
+	}
+	block, err := types.BlockFromProto(&pbb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pbc tmproto.Commit
+	if _, err := r.ReadMsg(&pbc); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, nil, fmt.Errorf("failed to read the commit of block %d: %w", block.Height, err)
+	}
+	commit, err := types.CommitFromProto(&pbc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, commit, nil
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool/mock"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// newTestChain returns the state, block executor and block store of a node of
+// a chain with a single validator, at genesis.
+func newTestChain(t *testing.T, genDoc *types.GenesisDoc) (sm.State, *sm.BlockExecutor, *store.BlockStore) {
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(state))
+
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	evidencePool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mock.Mempool{}, evidencePool)
+	return state, blockExec, blockStore
+}
+
+func TestExportImportBlocks(t *testing.T) {
+	privVal := types.NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	genDoc := &types.GenesisDoc{
+		ChainID:     "test-chain",
+		GenesisTime: tmtime.Now(),
+		Validators:  []types.GenesisValidator{{PubKey: pubKey, Power: 10}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+
+	// commit a few blocks
+	const height = 5
+	state, blockExec, blockStore := newTestChain(t, genDoc)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for h := int64(1); h <= height; h++ {
+		txs := []types.Tx{types.Tx([]byte{byte(h)})}
+		block, parts := state.MakeBlock(h, txs, lastCommit, nil, pubKey.Address())
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		vote, err := types.MakeVote(h, blockID, state.Validators, privVal, genDoc.ChainID,
+			block.Time.Add(time.Second))
+		require.NoError(t, err)
+		commit := types.NewCommit(h, 0, blockID, []types.CommitSig{vote.CommitSig()})
+
+		blockStore.SaveBlock(block, parts, commit)
+		state, _, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+		lastCommit = commit
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, exportBlocks(blockStore, 1, height, &buf))
+	exported := buf.Bytes()
+
+	// a new node imports them
+	newState, newBlockExec, newBlockStore := newTestChain(t, genDoc)
+	newState, imported, err := importBlocks(bytes.NewReader(exported), newState, newBlockExec, newBlockStore)
+	require.NoError(t, err)
+	assert.EqualValues(t, height, imported)
+	assert.EqualValues(t, height, newState.LastBlockHeight)
+	assert.Equal(t, state.AppHash, newState.AppHash)
+	assert.EqualValues(t, height, newBlockStore.Height())
+	assert.Equal(t, blockStore.LoadBlockMeta(height).BlockID, newBlockStore.LoadBlockMeta(height).BlockID)
+
+	// importing them again is a no-op
+	_, imported, err = importBlocks(bytes.NewReader(exported), newState, newBlockExec, newBlockStore)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, imported)
+
+	// blocks must follow the state without gaps
+	buf.Reset()
+	require.NoError(t, exportBlocks(blockStore, 3, height, &buf))
+	otherState, otherBlockExec, otherBlockStore := newTestChain(t, genDoc)
+	_, _, err = importBlocks(&buf, otherState, otherBlockExec, otherBlockStore)
+	assert.Error(t, err)
+
+	// and be committed by the validators
+	otherPrivVal := types.NewMockPV()
+	otherPubKey, err := otherPrivVal.GetPubKey()
+	require.NoError(t, err)
+	otherGenDoc := *genDoc
+	otherGenDoc.Validators = []types.GenesisValidator{{PubKey: otherPubKey, Power: 10}}
+	require.NoError(t, otherGenDoc.ValidateAndComplete())
+	otherState, otherBlockExec, otherBlockStore = newTestChain(t, &otherGenDoc)
+	_, imported, err = importBlocks(bytes.NewReader(exported), otherState, otherBlockExec, otherBlockStore)
+	assert.Error(t, err)
+	assert.EqualValues(t, 0, imported)
+
+	// a truncated file is detected
+	_, _, err = importBlocks(bytes.NewReader(exported[:len(exported)-10]), newState, newBlockExec, newBlockStore)
+	assert.Error(t, err)
+}
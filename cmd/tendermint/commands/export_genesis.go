@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+var (
+	exportChainID  string
+	exportAppState string
+	exportOutput   string
+)
+
+// ExportGenesisCmd creates a genesis file for restarting the chain under a
+// new chain ID from the latest committed state.
+var ExportGenesisCmd = &cobra.Command{
+	Use:   "export-genesis",
+	Short: "Export a genesis file for restarting the chain from its latest state",
+	Long: `
+export-genesis creates a genesis file from the latest state stored by this node,
+for restarting the chain after a coordinated upgrade. The new chain continues at
+the height following the last committed block (initial_height), with the current
+validator set, consensus parameters and app hash.
+
+A new chain ID is required, so that votes and transactions signed for the old
+chain cannot be replayed on the new one. The application state exported by the
+application can be included with --app-state.
+
+The node must be stopped before running this command.
+`,
+	Example: `
+	tendermint export-genesis --chain-id test-chain-2
+	tendermint export-genesis --chain-id test-chain-2 --app-state app_state.json --output genesis.json
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var appState json.RawMessage
+		if exportAppState != "" {
+			bz, err := os.ReadFile(exportAppState)
+			if err != nil {
+				return fmt.Errorf("failed to read app state: %w", err)
+			}
+			appState = bz
+		}
+
+		genDoc, err := ExportGenesis(config, exportChainID, appState)
+		if err != nil {
+			return fmt.Errorf("failed to export genesis: %w", err)
+		}
+
+		if exportOutput != "" {
+			return genDoc.SaveAs(exportOutput)
+		}
+		bz, err := tmjson.MarshalIndent(genDoc, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bz))
+		return nil
+	},
+}
+
+func init() {
+	ExportGenesisCmd.Flags().StringVar(&exportChainID, "chain-id", "", "chain ID of the new chain (required)")
+	ExportGenesisCmd.Flags().StringVar(&exportAppState, "app-state", "",
+		"path to a JSON file with the application state to include in the genesis")
+	ExportGenesisCmd.Flags().StringVar(&exportOutput, "output", "",
+		"file to write the genesis to (default: standard output)")
+	addSharedDBFlag(ExportGenesisCmd)
+}
+
+// ExportGenesis builds a genesis document for a new chain with the given
+// chain ID, which starts at the height following the last block committed
+// according to the state store.
+func ExportGenesis(config *cfg.Config, chainID string, appState json.RawMessage) (*types.GenesisDoc, error) {
+	if chainID == "" {
+		return nil, errors.New("a new chain ID must be provided")
+	}
+
+	blockStore, stateStore, err := loadStateAndBlockStore(config, dbProvider())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	return genesisFromState(state, chainID, appState)
+}
+
+func genesisFromState(state sm.State, chainID string, appState json.RawMessage) (*types.GenesisDoc, error) {
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+	if state.LastBlockHeight == 0 {
+		return nil, errors.New("no blocks have been committed yet")
+	}
+	if chainID == state.ChainID {
+		return nil, fmt.Errorf("new chain ID must differ from the current one (%s)", state.ChainID)
+	}
+
+	validators := make([]types.GenesisValidator, len(state.Validators.Validators))
+	for i, val := range state.Validators.Validators {
+		validators[i] = types.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower,
+		}
+	}
+
+	params := state.ConsensusParams
+	genDoc := &types.GenesisDoc{
+		GenesisTime:     tmtime.Now(),
+		ChainID:         chainID,
+		InitialHeight:   state.LastBlockHeight + 1,
+		ConsensusParams: &params,
+		Validators:      validators,
+		AppHash:         state.AppHash,
+		AppState:        appState,
+	}
+	// the new chain must not start before the last block of the old one
+	if !genDoc.GenesisTime.After(state.LastBlockTime) {
+		genDoc.GenesisTime = state.LastBlockTime.Add(time.Millisecond)
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return genDoc, nil
+}
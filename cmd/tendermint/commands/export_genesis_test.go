@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestGenesisFromState(t *testing.T) {
+	valSet, _ := types.RandValidatorSet(3, 10)
+	genVals := make([]types.GenesisValidator, len(valSet.Validators))
+	for i, val := range valSet.Validators {
+		genVals[i] = types.GenesisValidator{PubKey: val.PubKey, Power: val.VotingPower}
+	}
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:    "test-chain-1",
+		Validators: genVals,
+	})
+	require.NoError(t, err)
+
+	// nothing has been committed yet
+	_, err = genesisFromState(state, "test-chain-2", nil)
+	require.Error(t, err)
+
+	state.LastBlockHeight = 100
+	state.LastBlockTime = time.Now().Add(time.Hour)
+	state.AppHash = []byte("app_hash")
+
+	// the chain ID must change
+	_, err = genesisFromState(state, state.ChainID, nil)
+	require.Error(t, err)
+
+	appState := json.RawMessage(`{"key":"value"}`)
+	genDoc, err := genesisFromState(state, "test-chain-2", appState)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-chain-2", genDoc.ChainID)
+	assert.EqualValues(t, 101, genDoc.InitialHeight)
+	assert.True(t, genDoc.GenesisTime.After(state.LastBlockTime))
+	assert.EqualValues(t, state.AppHash, genDoc.AppHash)
+	assert.Equal(t, appState, genDoc.AppState)
+	assert.Equal(t, state.Validators.Hash(), genDoc.ValidatorHash())
+	assert.Equal(t, state.ConsensusParams, *genDoc.ConsensusParams)
+
+	// the exported genesis yields a state at the following height
+	newState, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	assert.EqualValues(t, 101, newState.InitialHeight)
+}
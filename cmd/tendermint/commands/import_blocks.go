@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cs "github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/libs/protoio"
+	"github.com/tendermint/tendermint/mempool/mock"
+	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ImportBlocksCmd executes and stores the blocks of a file written by
+// export-blocks.
+var ImportBlocksCmd = &cobra.Command{
+	Use:   "import-blocks [file]",
+	Short: "Import blocks exported by export-blocks",
+	Long: `
+import-blocks verifies the blocks of a file written by export-blocks against
+their commits, executes them against the application and stores them, as fast
+sync does with the blocks fetched from peers. The blocks up to the latest one
+of this node are skipped, and the following ones must follow it without gaps.
+
+The application configured by proxy_app must be running, unless it is a
+built-in one. Blocks are not indexed as they are imported; run reindex-event
+afterwards if the node indexes them.
+
+The node must be stopped before running this command.
+`,
+	Example: `
+	tendermint import-blocks blocks.bin
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return err
+		}
+
+		// the stores are opened as the node opens them, so that it reads the
+		// imported blocks
+		provider := dbProvider()
+		blockStore, stateDB, err := nm.InitDBs(config, provider, logger)
+		if blockStore != nil {
+			defer blockStore.Close()
+		}
+		if err != nil {
+			return err
+		}
+		stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+			DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		})
+		defer stateStore.Close()
+		evidenceDB, err := provider(&nm.DBContext{ID: "evidence", Config: config})
+		if err != nil {
+			return err
+		}
+		defer evidenceDB.Close()
+		// the evidence of the imported blocks is marked as committed, as in
+		// fast sync
+		evidencePool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+		if err != nil {
+			return err
+		}
+		evidencePool.SetLogger(logger.With("module", "evidence"))
+
+		state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+		if err != nil {
+			return err
+		}
+
+		proxyApp := proxy.NewAppConns(proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()))
+		proxyApp.SetLogger(logger.With("module", "proxy"))
+		if err := proxyApp.Start(); err != nil {
+			return fmt.Errorf("error starting proxy app connections: %w", err)
+		}
+		defer func() {
+			if err := proxyApp.Stop(); err != nil {
+				logger.Error("Error stopping proxy app connections", "err", err)
+			}
+		}()
+
+		// bring the application up to date with the state first
+		handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
+		handshaker.SetLogger(logger.With("module", "consensus"))
+		if err := handshaker.Handshake(proxyApp); err != nil {
+			return fmt.Errorf("error during handshake: %w", err)
+		}
+		state, err = stateStore.Load()
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		blockExec := sm.NewBlockExecutor(
+			stateStore,
+			logger.With("module", "state"),
+			proxyApp.Consensus(),
+			mock.Mempool{},
+			evidencePool,
+		)
+		state, imported, err := importBlocks(f, state, blockExec, blockStore)
+		if err != nil {
+			return fmt.Errorf("failed to import blocks: %w", err)
+		}
+
+		fmt.Printf("Imported %d blocks, up to height %d\n", imported, state.LastBlockHeight)
+		return nil
+	},
+}
+
+func init() {
+	addSharedDBFlag(ImportBlocksCmd)
+}
+
+// importBlocks reads the blocks written by exportBlocks, and verifies,
+// executes and stores those following the state. It returns the new state and
+// the number of imported blocks.
+func importBlocks(
+	r io.Reader,
+	state sm.State,
+	blockExec *sm.BlockExecutor,
+	blockStore *store.BlockStore,
+) (sm.State, int64, error) {
+	pr := protoio.NewDelimitedReader(bufio.NewReader(r), types.MaxBlockSizeBytes)
+
+	imported := int64(0)
+	for {
+		block, commit, err := readExportedBlock(pr)
+		if err == io.EOF {
+			return state, imported, nil
+		}
+		if err != nil {
+			return state, imported, err
+		}
+
+		next := state.LastBlockHeight + 1
+		if state.LastBlockHeight == 0 {
+			next = state.InitialHeight
+		}
+		if block.Height < next {
+			continue // already have it
+		}
+		if block.Height > next {
+			return state, imported, fmt.Errorf("expected block at height %d, got %d", next, block.Height)
+		}
+
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		if err := state.Validators.VerifyCommitLight(state.ChainID, blockID, block.Height, commit); err != nil {
+			return state, imported, fmt.Errorf("invalid commit for block %d: %w", block.Height, err)
+		}
+		if err := sm.ValidateBlockSize(state, parts); err != nil {
+			return state, imported, fmt.Errorf("invalid block %d: %w", block.Height, err)
+		}
+		if err := blockExec.ValidateBlock(state, block); err != nil {
+			return state, imported, fmt.Errorf("invalid block %d: %w", block.Height, err)
+		}
+
+		// as in fast sync, the block is saved before it is executed, so that
+		// the handshake completes the execution after a crash
+		blockStore.SaveBlock(block, parts, commit)
+		state, _, err = blockExec.ApplyBlock(state, blockID, block)
+		if err != nil {
+			return state, imported, fmt.Errorf("failed to execute block %d: %w", block.Height, err)
+		}
+		imported++
+	}
+}
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +16,8 @@ import (
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
+var initAppState string
+
 // InitFilesCmd initialises a fresh Tendermint Core instance.
 var InitFilesCmd = &cobra.Command{
 	Use:   "init",
@@ -21,11 +25,30 @@ var InitFilesCmd = &cobra.Command{
 	RunE:  initFiles,
 }
 
+func init() {
+	InitFilesCmd.Flags().StringVar(&initAppState, "app-state", "",
+		"path to a JSON file with the application state to include in the genesis")
+}
+
 func initFiles(cmd *cobra.Command, args []string) error {
-	return initFilesWithConfig(config)
+	var appState json.RawMessage
+	if initAppState != "" {
+		bz, err := os.ReadFile(initAppState)
+		if err != nil {
+			return fmt.Errorf("failed to read app state: %w", err)
+		}
+		appState = bz
+	}
+	return initFilesWithAppState(config, appState)
 }
 
 func initFilesWithConfig(config *cfg.Config) error {
+	return initFilesWithAppState(config, nil)
+}
+
+// initFilesWithAppState initializes the files of the node, with the given
+// app_state in the genesis if it's created.
+func initFilesWithAppState(config *cfg.Config, appState json.RawMessage) error {
 	// private validator
 	privValKeyFile := config.PrivValidatorKeyFile()
 	privValStateFile := config.PrivValidatorStateFile()
@@ -60,6 +83,7 @@ func initFilesWithConfig(config *cfg.Config) error {
 			ChainID:         fmt.Sprintf("test-chain-%v", tmrand.Str(6)),
 			GenesisTime:     tmtime.Now(),
 			ConsensusParams: types.DefaultConsensusParams(),
+			AppState:        appState,
 		}
 		pubKey, err := pv.GetPubKey()
 		if err != nil {
@@ -70,6 +94,9 @@ func initFilesWithConfig(config *cfg.Config) error {
 			PubKey:  pubKey,
 			Power:   10,
 		}}
+		if err := genDoc.ValidateAndComplete(); err != nil {
+			return fmt.Errorf("invalid genesis: %w", err)
+		}
 
 		if err := genDoc.SaveAs(genFile); err != nil {
 			return err
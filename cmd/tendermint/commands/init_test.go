@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestInitFilesWithAppState(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+
+	appState := json.RawMessage(`{"accounts":[{"name":"alice","balance":"10"}]}`)
+	require.NoError(t, initFilesWithAppState(config, appState))
+
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(appState), string(genDoc.AppState))
+	assert.Len(t, genDoc.Validators, 1)
+}
+
+func TestInitFilesWithInvalidAppState(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+
+	require.Error(t, initFilesWithAppState(config, json.RawMessage(`{"accounts":`)))
+	assert.NoFileExists(t, config.GenesisFile())
+}
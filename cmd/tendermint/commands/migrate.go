@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+)
+
+var migrateDryRun bool
+
+// MigrateCmd migrates the state and block store DBs to the schema versions of
+// this binary.
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "migrate the state and block store DBs to the schema versions of this binary",
+	Long: `
+Migrate the state and block store DBs to the schema versions of this binary,
+after an upgrade changing the encoding of the data stored in them. The node does
+the same when it starts; this command allows doing it ahead, and reporting what
+is pending. The node must be stopped.
+
+Migrations cannot be undone: older versions of Tendermint cannot read migrated
+DBs. Back up the data directory first if you may need to downgrade.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider := dbProvider()
+		if err := migrateStateDB(provider); err != nil {
+			return err
+		}
+		return migrateBlockStoreDB(provider)
+	},
+}
+
+func init() {
+	MigrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "only report the pending migrations")
+	addSharedDBFlag(MigrateCmd)
+}
+
+func migrateStateDB(provider nm.DBProvider) error {
+	if !dbExists(config, "state") {
+		return fmt.Errorf("no statestore found in %v", config.DBDir())
+	}
+	db, err := provider(&nm.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	version, err := state.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	pending, err := state.PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("State DB schema version: %d, latest: %d\n", version, state.LatestSchemaVersion())
+	for _, m := range pending {
+		fmt.Printf("Pending migration to version %d: %s\n", m.Version, m.Description)
+	}
+	if migrateDryRun {
+		return nil
+	}
+
+	if err := state.Migrate(db, logger); err != nil {
+		return err
+	}
+	fmt.Printf("Migrated state DB to schema version %d\n", state.LatestSchemaVersion())
+	return nil
+}
+
+func migrateBlockStoreDB(provider nm.DBProvider) error {
+	if !dbExists(config, "blockstore") {
+		return fmt.Errorf("no blockstore found in %v", config.DBDir())
+	}
+	db, err := provider(&nm.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	version, err := store.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	pending, err := store.PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Block store DB schema version: %d, latest: %d\n", version, store.LatestSchemaVersion())
+	for _, m := range pending {
+		fmt.Printf("Pending migration to version %d: %s\n", m.Version, m.Description)
+	}
+	if migrateDryRun {
+		return nil
+	}
+
+	if err := store.Migrate(db, logger); err != nil {
+		return err
+	}
+	fmt.Printf("Migrated block store DB to schema version %d\n", store.LatestSchemaVersion())
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	tmcfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/progressbar"
+	nm "github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
@@ -51,7 +52,10 @@ want to use this command.
 	tendermint reindex-event --start-height 2 --end-height 10
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
-		bs, ss, err := loadStateAndBlockStore(config)
+		// the indexer DB is opened with the same provider, since a shared DB
+		// can only be opened once
+		provider := dbProvider()
+		bs, ss, err := loadStateAndBlockStore(config, provider)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
 			return
@@ -62,7 +66,7 @@ want to use this command.
 			return
 		}
 
-		bi, ti, err := loadEventSinks(config)
+		bi, ti, err := loadEventSinks(config, provider)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
 			return
@@ -92,9 +96,10 @@ var (
 func init() {
 	ReIndexEventCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
 	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	addSharedDBFlag(ReIndexEventCmd)
 }
 
-func loadEventSinks(cfg *tmcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer, error) {
+func loadEventSinks(cfg *tmcfg.Config, provider nm.DBProvider) (indexer.BlockIndexer, txindex.TxIndexer, error) {
 	switch strings.ToLower(cfg.TxIndex.Indexer) {
 	case "null":
 		return nil, nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
@@ -109,13 +114,15 @@ func loadEventSinks(cfg *tmcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer,
 		}
 		return es.BlockIndexer(), es.TxIndexer(), nil
 	case "kv":
-		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+		store, err := provider(&nm.DBContext{ID: "tx_index", Config: cfg})
 		if err != nil {
 			return nil, nil, err
 		}
 
 		txIndexer := kv.NewTxIndex(store)
+		txIndexer.SetSyncWrites(cfg.TxIndex.SyncWrites)
 		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+		blockIndexer.SetSyncWrites(cfg.TxIndex.SyncWrites)
 		return blockIndexer, txIndexer, nil
 	default:
 		return nil, nil, fmt.Errorf("unsupported event sink type: %s", cfg.TxIndex.Indexer)
@@ -13,6 +13,7 @@ import (
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	tmcfg "github.com/tendermint/tendermint/config"
+	nm "github.com/tendermint/tendermint/node"
 	prototmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	blockmocks "github.com/tendermint/tendermint/state/indexer/mocks"
 	"github.com/tendermint/tendermint/state/mocks"
@@ -98,7 +99,7 @@ func TestLoadEventSink(t *testing.T) {
 		cfg := tmcfg.TestConfig()
 		cfg.TxIndex.Indexer = tc.sinks
 		cfg.TxIndex.PsqlConn = tc.connURL
-		_, _, err := loadEventSinks(cfg)
+		_, _, err := loadEventSinks(cfg, nm.DefaultDBProvider)
 		if tc.loadErr {
 			require.Error(t, err, idx)
 		} else {
@@ -110,7 +111,7 @@ func TestLoadEventSink(t *testing.T) {
 func TestLoadBlockStore(t *testing.T) {
 	cfg := tmcfg.TestConfig()
 	cfg.DBPath = t.TempDir()
-	_, _, err := loadStateAndBlockStore(cfg)
+	_, _, err := loadStateAndBlockStore(cfg, nm.DefaultDBProvider)
 	require.Error(t, err)
 
 	_, err = dbm.NewDB("blockstore", dbm.GoLevelDBBackend, cfg.DBDir())
@@ -120,7 +121,7 @@ func TestLoadBlockStore(t *testing.T) {
 	_, err = dbm.NewDB("state", dbm.GoLevelDBBackend, cfg.DBDir())
 	require.NoError(t, err)
 
-	bs, ss, err := loadStateAndBlockStore(cfg)
+	bs, ss, err := loadStateAndBlockStore(cfg, nm.DefaultDBProvider)
 	require.NoError(t, err)
 	require.NotNil(t, bs)
 	require.NotNil(t, ss)
@@ -15,6 +15,8 @@ var ReplayCmd = &cobra.Command{
 	},
 }
 
+var replayScript string
+
 // ReplayConsoleCmd allows replaying of messages from the WAL in a
 // console.
 var ReplayConsoleCmd = &cobra.Command{
@@ -22,7 +24,16 @@ var ReplayConsoleCmd = &cobra.Command{
 	Aliases: []string{"replay_console"},
 	Short:   "Replay messages from WAL in a console",
 	Run: func(cmd *cobra.Command, args []string) {
+		if replayScript != "" {
+			consensus.RunReplayScript(config.BaseConfig, config.Consensus, replayScript)
+			return
+		}
 		consensus.RunReplayFile(config.BaseConfig, config.Consensus, true)
 	},
 	PreRun: deprecateSnakeCase,
 }
+
+func init() {
+	ReplayConsoleCmd.Flags().StringVar(&replayScript, "script", "",
+		"file with the console commands to run, one per line, instead of reading them from stdin")
+}
@@ -2,14 +2,11 @@ package commands
 
 import (
 	"fmt"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 
-	dbm "github.com/tendermint/tm-db"
-
 	cfg "github.com/tendermint/tendermint/config"
-	"github.com/tendermint/tendermint/libs/os"
+	nm "github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/store"
 )
@@ -36,12 +33,16 @@ application.
 	},
 }
 
+func init() {
+	addSharedDBFlag(RollbackStateCmd)
+}
+
 // RollbackState takes the state at the current height n and overwrites it with the state
 // at height n - 1. Note state here refers to tendermint state not application state.
 // Returns the latest state height and app hash alongside an error if there was one.
 func RollbackState(config *cfg.Config) (int64, []byte, error) {
 	// use the parsed config to load the block and state store
-	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	blockStore, stateStore, err := loadStateAndBlockStore(config, dbProvider())
 	if err != nil {
 		return -1, nil, err
 	}
@@ -54,27 +55,27 @@ func RollbackState(config *cfg.Config) (int64, []byte, error) {
 	return state.Rollback(blockStore, stateStore)
 }
 
-func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
-	dbType := dbm.BackendType(config.DBBackend)
-
-	if !os.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
+// loadStateAndBlockStore opens the block and state stores of the node with
+// the given provider.
+func loadStateAndBlockStore(config *cfg.Config, provider nm.DBProvider) (*store.BlockStore, state.Store, error) {
+	if !dbExists(config, "blockstore") {
 		return nil, nil, fmt.Errorf("no blockstore found in %v", config.DBDir())
 	}
+	if !dbExists(config, "state") {
+		return nil, nil, fmt.Errorf("no statestore found in %v", config.DBDir())
+	}
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStoreDB, err := provider(&nm.DBContext{ID: "blockstore", Config: config})
 	if err != nil {
 		return nil, nil, err
 	}
 	blockStore := store.NewBlockStore(blockStoreDB)
 
-	if !os.FileExists(filepath.Join(config.DBDir(), "state.db")) {
-		return nil, nil, fmt.Errorf("no statestore found in %v", config.DBDir())
-	}
-
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	stateDB, err := provider(&nm.DBContext{ID: "state", Config: config})
 	if err != nil {
+		_ = blockStore.Close()
 		return nil, nil, err
 	}
 	stateStore := state.NewStore(stateDB, state.StoreOptions{
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/consensus"
+	auto "github.com/tendermint/tendermint/libs/autofile"
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+// WALToJSONCmd exports the consensus WAL to line-delimited JSON.
+var WALToJSONCmd = &cobra.Command{
+	Use:   "wal2json [wal-file]",
+	Short: "Export the consensus WAL to line-delimited JSON",
+	Long: `
+Export the consensus WAL to line-delimited JSON, one message per line, on the
+standard output. The WAL defaults to the one of the node, including its rotated
+files.
+
+The JSON can be inspected, edited and imported back with json2wal, possibly by
+another version of Tendermint.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		walFile := config.Consensus.WalFile()
+		if len(args) > 0 {
+			walFile = args[0]
+		}
+		if _, err := os.Stat(walFile); err != nil {
+			return fmt.Errorf("failed to open WAL: %w", err)
+		}
+
+		group, err := auto.OpenGroup(walFile)
+		if err != nil {
+			return fmt.Errorf("failed to open WAL: %w", err)
+		}
+		defer group.Close()
+		gr, err := group.NewReader(group.MinIndex())
+		if err != nil {
+			return fmt.Errorf("failed to open WAL: %w", err)
+		}
+		defer gr.Close()
+
+		return consensus.WALToJSON(gr, cmd.OutOrStdout())
+	},
+}
+
+// JSONToWALCmd imports line-delimited JSON to a consensus WAL.
+var JSONToWALCmd = &cobra.Command{
+	Use:   "json2wal <json-file> [wal-file]",
+	Short: "Import line-delimited JSON to a consensus WAL",
+	Long: `
+Import the line-delimited JSON written by wal2json to a consensus WAL. The WAL
+defaults to the one of the node, and must not exist yet.
+`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		walFile := config.Consensus.WalFile()
+		if len(args) > 1 {
+			walFile = args[1]
+		}
+
+		jsonFile, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open JSON: %w", err)
+		}
+		defer jsonFile.Close()
+
+		if err := tmos.EnsureDir(filepath.Dir(walFile), 0700); err != nil {
+			return fmt.Errorf("failed to create WAL directory: %w", err)
+		}
+		f, err := os.OpenFile(walFile, os.O_EXCL|os.O_WRONLY|os.O_CREATE, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to create WAL: %w", err)
+		}
+		if err := consensus.JSONToWAL(jsonFile, f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	},
+}
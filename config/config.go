@@ -4,9 +4,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -28,6 +30,12 @@ const (
 	// Default is v0.
 	MempoolV0 = "v0"
 	MempoolV1 = "v1"
+
+	// Node modes. Only validators load the private validator and sign.
+	// Default is validator.
+	ModeValidator = "validator"
+	ModeFull      = "full"
+	ModeSeed      = "seed"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -170,6 +178,13 @@ type BaseConfig struct { //nolint: maligned
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
+	// Mode of the node: validator | full | seed
+	// * validator: loads the private validator, and signs and proposes
+	//   blocks when in the validator set
+	// * full: follows the chain without loading the private validator
+	// * seed: like full, with the PEX reactor in seed mode (see p2p.seed_mode)
+	Mode string `mapstructure:"mode"`
+
 	// If this node is many blocks behind the tip of the chain, FastSync
 	// allows them to catchup quickly by downloading blocks in parallel
 	// and verifying their commits
@@ -224,6 +239,11 @@ type BaseConfig struct { //nolint: maligned
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// Maximum number of DeliverTx requests sent to the ABCI application
+	// without waiting for their responses, when executing a block.
+	// 0 means no limit.
+	MaxInFlightDeliverTxs int `mapstructure:"max_in_flight_deliver_txs"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
@@ -237,6 +257,7 @@ func DefaultBaseConfig() BaseConfig {
 		PrivValidatorState: defaultPrivValStatePath,
 		NodeKey:            defaultNodeKeyPath,
 		Moniker:            defaultMoniker,
+		Mode:               ModeValidator,
 		ProxyApp:           "tcp://127.0.0.1:26658",
 		ABCI:               "socket",
 		LogLevel:           DefaultLogLevel,
@@ -245,6 +266,8 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers:        false,
 		DBBackend:          "goleveldb",
 		DBPath:             "data",
+
+		MaxInFlightDeliverTxs: 256,
 	}
 }
 
@@ -295,9 +318,24 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	switch cfg.Mode {
+	case "", ModeValidator, ModeFull, ModeSeed:
+	default:
+		return fmt.Errorf("unknown mode %q (must be '%s', '%s' or '%s')", cfg.Mode, ModeValidator, ModeFull, ModeSeed)
+	}
+	if cfg.MaxInFlightDeliverTxs < 0 {
+		return errors.New("max_in_flight_deliver_txs can't be negative")
+	}
 	return nil
 }
 
+// IsValidatorMode returns true if the node runs as a validator, i.e. loads
+// the private validator and signs. An empty mode means validator, for
+// compatibility with configs created before the mode existed.
+func (cfg BaseConfig) IsValidatorMode() bool {
+	return cfg.Mode == ModeValidator || cfg.Mode == ""
+}
+
 //-----------------------------------------------------------------------------
 // RPCConfig
 
@@ -305,7 +343,8 @@ func (cfg BaseConfig) ValidateBasic() error {
 type RPCConfig struct {
 	RootDir string `mapstructure:"home"`
 
-	// TCP or UNIX socket address for the RPC server to listen on
+	// TCP or UNIX socket address for the RPC server to listen on, or a
+	// comma-separated list of them
 	ListenAddress string `mapstructure:"laddr"`
 
 	// A list of origins a cross-domain request can be executed from.
@@ -321,7 +360,7 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server only supports /broadcast_tx_commit and block streaming
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -334,6 +373,10 @@ type RPCConfig struct {
 	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool
 	Unsafe bool `mapstructure:"unsafe"`
 
+	// If set, unsafe RPC commands and debug dumps like /dump_consensus_state
+	// require the "Authorization: Bearer <token>" HTTP header with this token.
+	UnsafeAuthToken string `mapstructure:"unsafe_auth_token"`
+
 	// Maximum number of simultaneous connections (including WebSocket).
 	// Does not include gRPC connections. See grpc_max_open_connections
 	// If you want to accept a larger number than the default, make sure
@@ -382,6 +425,14 @@ type RPCConfig struct {
 	// See https://github.com/tendermint/tendermint/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout_broadcast_tx_commit"`
 
+	// A WebSocket connection is closed if nothing, not even a pong, is read
+	// from the client within this time. Pings are sent at 9/10 of it.
+	WebSocketReadTimeout time.Duration `mapstructure:"websocket_read_timeout"`
+
+	// A WebSocket connection is closed if a write to the client does not
+	// complete within this time.
+	WebSocketWriteTimeout time.Duration `mapstructure:"websocket_write_timeout"`
+
 	// Maximum size of request body, in bytes
 	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
 
@@ -406,6 +457,16 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls_key_file"`
 
+	// The path to a file containing the certificates of the authorities
+	// allowed to sign client certificates. If set, unsafe RPC commands and
+	// debug dumps require a client certificate signed by one of them.
+	// Requests without a client certificate are still served for all other
+	// endpoints.
+	// Might be either absolute path or path related to Tendermint's config directory.
+	//
+	// NOTE: requires tls_cert_file and tls_key_file.
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
 }
@@ -428,6 +489,8 @@ func DefaultRPCConfig() *RPCConfig {
 		SubscriptionBufferSize:    defaultSubscriptionBufferSize,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
+		WebSocketReadTimeout:      30 * time.Second,
+		WebSocketWriteTimeout:     10 * time.Second,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
@@ -449,6 +512,20 @@ func TestRPCConfig() *RPCConfig {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *RPCConfig) ValidateBasic() error {
+	// the RPC server listens on each of the comma separated addresses
+	for _, addr := range strings.Split(cfg.ListenAddress, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		if err := validateAddress(addr, true, "tcp", "unix"); err != nil {
+			return fmt.Errorf("invalid laddr: %w", err)
+		}
+	}
+	if cfg.GRPCListenAddress != "" {
+		if err := validateAddress(cfg.GRPCListenAddress, true, "tcp", "unix"); err != nil {
+			return fmt.Errorf("invalid grpc_laddr: %w", err)
+		}
+	}
 	if cfg.GRPCMaxOpenConnections < 0 {
 		return errors.New("grpc_max_open_connections can't be negative")
 	}
@@ -476,12 +553,21 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout_broadcast_tx_commit can't be negative")
 	}
+	if cfg.WebSocketReadTimeout <= 0 {
+		return errors.New("websocket_read_timeout must be positive")
+	}
+	if cfg.WebSocketWriteTimeout <= 0 {
+		return errors.New("websocket_write_timeout must be positive")
+	}
 	if cfg.MaxBodyBytes < 0 {
 		return errors.New("max_body_bytes can't be negative")
 	}
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.TLSClientCAFile != "" && !cfg.IsTLSEnabled() {
+		return errors.New("tls_client_ca_file requires tls_cert_file and tls_key_file")
+	}
 	return nil
 }
 
@@ -510,6 +596,20 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+func (cfg RPCConfig) ClientCAFile() string {
+	path := cfg.TLSClientCAFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
+// IsUnsafeAuthEnabled returns true if unsafe RPC commands require either a
+// token or a client certificate.
+func (cfg RPCConfig) IsUnsafeAuthEnabled() bool {
+	return cfg.UnsafeAuthToken != "" || cfg.TLSClientCAFile != ""
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -530,7 +630,9 @@ type P2PConfig struct { //nolint: maligned
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent_peers"`
 
-	// UPNP port forwarding
+	// UPNP port forwarding. On start, the p2p port is forwarded on the internet
+	// gateway and its external address is advertised to peers, unless
+	// ExternalAddress is set.
 	UPNP bool `mapstructure:"upnp"`
 
 	// Path to address book
@@ -564,6 +666,14 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Rate at which packets can be sent to all peers combined, in
+	// bytes/second. 0 means no limit besides the per-peer send_rate.
+	TotalSendRate int64 `mapstructure:"total_send_rate"`
+
+	// Rate at which packets can be received from all peers combined, in
+	// bytes/second. 0 means no limit besides the per-peer recv_rate.
+	TotalRecvRate int64 `mapstructure:"total_recv_rate"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -635,6 +745,14 @@ func (cfg *P2PConfig) AddrBookFile() string {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	if err := validateAddress(cfg.ListenAddress, false, "tcp"); err != nil {
+		return fmt.Errorf("invalid laddr: %w", err)
+	}
+	if cfg.ExternalAddress != "" {
+		if err := validateAddress(cfg.ExternalAddress, false, "tcp"); err != nil {
+			return fmt.Errorf("invalid external_address: %w", err)
+		}
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return errors.New("max_num_inbound_peers can't be negative")
 	}
@@ -656,6 +774,12 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.TotalSendRate < 0 {
+		return errors.New("total_send_rate can't be negative")
+	}
+	if cfg.TotalRecvRate < 0 {
+		return errors.New("total_recv_rate can't be negative")
+	}
 	return nil
 }
 
@@ -881,12 +1005,17 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// Maximum number of blocks requested or received, but not yet applied.
+	// Only used by v1.
+	MaxPendingRequests int `mapstructure:"max_pending_requests"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
 func DefaultFastSyncConfig() *FastSyncConfig {
 	return &FastSyncConfig{
-		Version: "v0",
+		Version:            "v0",
+		MaxPendingRequests: 64,
 	}
 }
 
@@ -897,6 +1026,9 @@ func TestFastSyncConfig() *FastSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *FastSyncConfig) ValidateBasic() error {
+	if cfg.MaxPendingRequests <= 0 {
+		return errors.New("max_pending_requests must be positive")
+	}
 	switch cfg.Version {
 	case "v0":
 		return nil
@@ -918,6 +1050,9 @@ type ConsensusConfig struct {
 	RootDir string `mapstructure:"home"`
 	WalPath string `mapstructure:"wal_file"`
 	walFile string // overrides WalPath if set
+	// Maximum total size of the WAL files in bytes. The oldest files are
+	// removed once it is exceeded. 0 means no limit.
+	WalMaxSize int64 `mapstructure:"wal_max_size"`
 
 	// How long we wait for a proposal block before prevoting nil
 	TimeoutPropose time.Duration `mapstructure:"timeout_propose"`
@@ -949,12 +1084,39 @@ type ConsensusConfig struct {
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Bounds of the cache of verified vote signatures, which spares verifying
+	// the precommits of the LastCommit of the next block again. Caching is
+	// disabled if either is 0.
+	VoteSignatureCacheSize  int `mapstructure:"vote_signature_cache_size"`
+	VoteSignatureCacheBytes int `mapstructure:"vote_signature_cache_bytes"`
+
+	// Raise a liveness alarm when no block has been committed for this many
+	// times the expected block interval. 0 disables the alarm.
+	MaxBlockIntervalFactor float64 `mapstructure:"max_block_interval_factor"`
+	// If set, a diagnostic dump is written to this directory each time the
+	// liveness alarm is raised.
+	MaxBlockIntervalDumpDir string `mapstructure:"max_block_interval_dump_dir"`
+
+	// Capacity of the queues of messages received from peers and of our own
+	// messages (proposals, block parts and votes). 0 means the default of 1000.
+	PeerMsgQueueSize     int `mapstructure:"peer_msg_queue_size"`
+	InternalMsgQueueSize int `mapstructure:"internal_msg_queue_size"`
+	// If true, messages from peers are dropped when the peer queue is full
+	// instead of blocking the receiving reactor.
+	DropPeerMsgsWhenFull bool `mapstructure:"drop_peer_msgs_when_full"`
+
+	// Bytes of the parts of a proposal block kept in memory while receiving
+	// it. The following parts are saved to the blockparts DB until the block
+	// is committed. 0 keeps all of them in memory.
+	BlockPartsMemoryBudget int64 `mapstructure:"block_parts_memory_budget"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(defaultDataDir, "cs.wal", "wal"),
+		WalMaxSize:                  1073741824, // 1GB
 		TimeoutPropose:              3000 * time.Millisecond,
 		TimeoutProposeDelta:         500 * time.Millisecond,
 		TimeoutPrevote:              1000 * time.Millisecond,
@@ -968,6 +1130,12 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		VoteSignatureCacheSize:      10000,
+		VoteSignatureCacheBytes:     4194304, // 4MB
+		PeerMsgQueueSize:            1000,
+		InternalMsgQueueSize:        1000,
+		DropPeerMsgsWhenFull:        false,
+		BlockPartsMemoryBudget:      0,
 	}
 }
 
@@ -1034,6 +1202,31 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
 }
 
+// ExpectedBlockInterval returns how long committing a block takes at most on
+// a live network where the first proposal of each height is accepted. It is 0
+// if empty blocks are never created, as there is no bound then.
+func (cfg *ConsensusConfig) ExpectedBlockInterval() time.Duration {
+	if !cfg.CreateEmptyBlocks && cfg.CreateEmptyBlocksInterval == 0 {
+		return 0
+	}
+	return cfg.TimeoutCommit + cfg.CreateEmptyBlocksInterval + cfg.TimeoutPropose
+}
+
+// MaxBlockInterval returns how long may pass without a block being committed
+// before the liveness alarm is raised, or 0 if the alarm is disabled.
+func (cfg *ConsensusConfig) MaxBlockInterval() time.Duration {
+	return time.Duration(cfg.MaxBlockIntervalFactor * float64(cfg.ExpectedBlockInterval()))
+}
+
+// MaxBlockIntervalDumpPath returns the full path to the directory diagnostic
+// dumps are written to when the liveness alarm is raised, or "" if none are.
+func (cfg *ConsensusConfig) MaxBlockIntervalDumpPath() string {
+	if cfg.MaxBlockIntervalDumpDir == "" {
+		return ""
+	}
+	return rootify(cfg.MaxBlockIntervalDumpDir, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
@@ -1070,6 +1263,27 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	if cfg.VoteSignatureCacheSize < 0 {
+		return errors.New("vote_signature_cache_size can't be negative")
+	}
+	if cfg.VoteSignatureCacheBytes < 0 {
+		return errors.New("vote_signature_cache_bytes can't be negative")
+	}
+	if cfg.MaxBlockIntervalFactor < 0 {
+		return errors.New("max_block_interval_factor can't be negative")
+	}
+	if cfg.WalMaxSize < 0 {
+		return errors.New("wal_max_size can't be negative")
+	}
+	if cfg.PeerMsgQueueSize < 0 {
+		return errors.New("peer_msg_queue_size can't be negative")
+	}
+	if cfg.InternalMsgQueueSize < 0 {
+		return errors.New("internal_msg_queue_size can't be negative")
+	}
+	if cfg.BlockPartsMemoryBudget < 0 {
+		return errors.New("block_parts_memory_budget can't be negative")
+	}
 	return nil
 }
 
@@ -1127,12 +1341,19 @@ type TxIndexConfig struct {
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// If true (default), the "kv" indexer flushes each of its writes to disk
+	// before moving on. The index can be rebuilt with reindex-event, so
+	// operators who favor throughput may disable it, at the risk of losing the
+	// latest entries on a crash.
+	SyncWrites bool `mapstructure:"sync_writes"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: "kv",
+		Indexer:    "kv",
+		SyncWrites: true,
 	}
 }
 
@@ -1201,6 +1422,37 @@ func rootify(path, root string) string {
 	return filepath.Join(root, path)
 }
 
+// validateAddress checks that addr is a protocol://address address with one
+// of the given protocols, where address is host:port for tcp and a path for
+// unix. If the protocol isn't required, it defaults to the first one.
+func validateAddress(addr string, requireProtocol bool, protocols ...string) error {
+	protocol, address := protocols[0], addr
+	if parts := strings.SplitN(addr, "://", 2); len(parts) == 2 {
+		protocol, address = parts[0], parts[1]
+	} else if requireProtocol {
+		return fmt.Errorf("%q must be of the form protocol://address", addr)
+	}
+
+	supported := false
+	for _, p := range protocols {
+		supported = supported || p == protocol
+	}
+	if !supported {
+		return fmt.Errorf("unsupported protocol %q in %q (must be one of %v)", protocol, addr, protocols)
+	}
+
+	if protocol == "unix" {
+		if address == "" {
+			return fmt.Errorf("missing path in %q", addr)
+		}
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("%q: %w", addr, err)
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // Moniker
 
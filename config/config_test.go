@@ -61,6 +61,20 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	for _, mode := range []string{ModeValidator, ModeFull, ModeSeed} {
+		cfg.Mode = mode
+		assert.NoError(t, cfg.ValidateBasic())
+	}
+	cfg.Mode = "observer"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.MaxInFlightDeliverTxs = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.MaxInFlightDeliverTxs = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -82,6 +96,40 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg = TestRPCConfig()
+	cfg.WebSocketReadTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.WebSocketWriteTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	testCases := []struct {
+		laddr     string
+		grpcLaddr string
+		expectErr bool
+	}{
+		{"", "", false},
+		{"tcp://127.0.0.1:26657", "tcp://127.0.0.1:26658", false},
+		{"tcp://127.0.0.1:26657, unix:///tmp/rpc.sock", "unix:///tmp/grpc.sock", false},
+		{"tcp://[::1]:26657", "", false},
+		{"127.0.0.1:26657", "", true},
+		{"udp://127.0.0.1:26657", "", true},
+		{"tcp://127.0.0.1", "", true},
+		{"unix://", "", true},
+		{"tcp://127.0.0.1:26657", "127.0.0.1:26658", true},
+	}
+	for _, tc := range testCases {
+		cfg = TestRPCConfig()
+		cfg.ListenAddress = tc.laddr
+		cfg.GRPCListenAddress = tc.grpcLaddr
+		if tc.expectErr {
+			assert.Error(t, cfg.ValidateBasic(), tc.laddr+" "+tc.grpcLaddr)
+		} else {
+			assert.NoError(t, cfg.ValidateBasic(), tc.laddr+" "+tc.grpcLaddr)
+		}
+	}
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -95,6 +143,8 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"TotalSendRate",
+		"TotalRecvRate",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -102,6 +152,30 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	testCases := []struct {
+		laddr           string
+		externalAddress string
+		expectErr       bool
+	}{
+		{"tcp://0.0.0.0:26656", "", false},
+		{"0.0.0.0:26656", "1.2.3.4:26656", false},
+		{"tcp://0.0.0.0:26656", "tcp://1.2.3.4:26656", false},
+		{"", "", true},
+		{"0.0.0.0", "", true},
+		{"unix:///tmp/p2p.sock", "", true},
+		{"tcp://0.0.0.0:26656", "1.2.3.4", true},
+	}
+	for _, tc := range testCases {
+		cfg = TestP2PConfig()
+		cfg.ListenAddress = tc.laddr
+		cfg.ExternalAddress = tc.externalAddress
+		if tc.expectErr {
+			assert.Error(t, cfg.ValidateBasic(), tc.laddr+" "+tc.externalAddress)
+		} else {
+			assert.NoError(t, cfg.ValidateBasic(), tc.laddr+" "+tc.externalAddress)
+		}
+	}
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -137,6 +211,10 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestFastSyncConfig()
+	cfg.MaxPendingRequests = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 //nolint:lll
@@ -164,6 +242,14 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"VoteSignatureCacheSize negative":      {func(c *ConsensusConfig) { c.VoteSignatureCacheSize = -1 }, true},
+		"VoteSignatureCacheBytes negative":     {func(c *ConsensusConfig) { c.VoteSignatureCacheBytes = -1 }, true},
+		"MaxBlockIntervalFactor negative":      {func(c *ConsensusConfig) { c.MaxBlockIntervalFactor = -1 }, true},
+		"WalMaxSize negative":                  {func(c *ConsensusConfig) { c.WalMaxSize = -1 }, true},
+		"PeerMsgQueueSize zero":                {func(c *ConsensusConfig) { c.PeerMsgQueueSize = 0 }, false},
+		"PeerMsgQueueSize negative":            {func(c *ConsensusConfig) { c.PeerMsgQueueSize = -1 }, true},
+		"InternalMsgQueueSize negative":        {func(c *ConsensusConfig) { c.InternalMsgQueueSize = -1 }, true},
+		"BlockPartsMemoryBudget negative":      {func(c *ConsensusConfig) { c.BlockPartsMemoryBudget = -1 }, true},
 	}
 
 	for desc, tc := range testcases {
@@ -182,6 +268,22 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestConsensusConfigMaxBlockInterval(t *testing.T) {
+	cfg := DefaultConsensusConfig()
+	assert.EqualValues(t, 0, cfg.MaxBlockInterval(), "disabled by default")
+
+	cfg.MaxBlockIntervalFactor = 2.5
+	assert.Equal(t, 4*time.Second, cfg.ExpectedBlockInterval())
+	assert.Equal(t, 10*time.Second, cfg.MaxBlockInterval())
+
+	cfg.CreateEmptyBlocks = false
+	cfg.CreateEmptyBlocksInterval = 6 * time.Second
+	assert.Equal(t, 25*time.Second, cfg.MaxBlockInterval())
+
+	cfg.CreateEmptyBlocksInterval = 0
+	assert.EqualValues(t, 0, cfg.MaxBlockInterval(), "no bound without empty blocks")
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/spf13/viper"
+
 	tmos "github.com/tendermint/tendermint/libs/os"
 )
 
@@ -66,6 +68,34 @@ func WriteConfigFile(configFilePath string, config *Config) {
 	tmos.MustWriteFile(configFilePath, buffer.Bytes(), 0o644)
 }
 
+// LoadConfigFile reads the config file at configFilePath, written by
+// WriteConfigFile or by hand, and validates it. Settings missing from the file
+// take their default value. The root dir is the parent of the directory of the
+// config file, as laid out by EnsureRoot.
+func LoadConfigFile(configFilePath string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(configFilePath)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configFilePath, err)
+	}
+
+	config := DefaultConfig()
+	if err := v.Unmarshal(config); err != nil {
+		return nil, fmt.Errorf("failed to decode config file %s: %w", configFilePath, err)
+	}
+
+	absPath, err := filepath.Abs(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+	config.SetRoot(filepath.Dir(filepath.Dir(absPath)))
+
+	if err := config.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in config file %s: %w", configFilePath, err)
+	}
+	return config, nil
+}
+
 // Note: any changes to the comments/variables/mapstructure
 // must be reflected in the appropriate struct in config/config.go
 const defaultConfigTemplate = `# This is a TOML config file.
@@ -87,6 +117,13 @@ proxy_app = "{{ .BaseConfig.ProxyApp }}"
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
+# Mode of the node: validator | full | seed
+# * validator: loads the private validator, and signs and proposes blocks
+#   when in the validator set
+# * full: follows the chain without loading the private validator
+# * seed: like full, with the PEX reactor in seed mode (see p2p.seed_mode)
+mode = "{{ .BaseConfig.Mode }}"
+
 # If this node is many blocks behind the tip of the chain, FastSync
 # allows them to catchup quickly by downloading blocks in parallel
 # and verifying their commits
@@ -143,6 +180,10 @@ node_key_file = "{{ js .BaseConfig.NodeKey }}"
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# Maximum number of DeliverTx requests sent to the ABCI application without
+# waiting for their responses, when executing a block. 0 means no limit.
+max_in_flight_deliver_txs = {{ .BaseConfig.MaxInFlightDeliverTxs }}
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
@@ -157,7 +198,9 @@ filter_peers = {{ .BaseConfig.FilterPeers }}
 #######################################################
 [rpc]
 
-# TCP or UNIX socket address for the RPC server to listen on
+# TCP or UNIX socket address for the RPC server to listen on. Several
+# addresses can be given as a comma-separated list, e.g.
+# "tcp://0.0.0.0:26657,unix:///var/run/tendermint.sock".
 laddr = "{{ .RPC.ListenAddress }}"
 
 # A list of origins a cross-domain request can be executed from
@@ -172,7 +215,7 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# NOTE: This server only supports /broadcast_tx_commit and block streaming
 grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -187,6 +230,11 @@ grpc_max_open_connections = {{ .RPC.GRPCMaxOpenConnections }}
 # Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool
 unsafe = {{ .RPC.Unsafe }}
 
+# If set, unsafe RPC commands and debug dumps like /dump_consensus_state
+# require the "Authorization: Bearer <token>" HTTP header with this token.
+# All other endpoints remain public.
+unsafe_auth_token = "{{ .RPC.UnsafeAuthToken }}"
+
 # Maximum number of simultaneous connections (including WebSocket).
 # Does not include gRPC connections. See grpc_max_open_connections
 # If you want to accept a larger number than the default, make sure
@@ -239,6 +287,14 @@ experimental_close_on_slow_client = {{ .RPC.CloseOnSlowClient }}
 # See https://github.com/tendermint/tendermint/issues/3435
 timeout_broadcast_tx_commit = "{{ .RPC.TimeoutBroadcastTxCommit }}"
 
+# A WebSocket connection is closed if nothing, not even a pong, is read from
+# the client within this time. Pings are sent at 9/10 of it.
+websocket_read_timeout = "{{ .RPC.WebSocketReadTimeout }}"
+
+# A WebSocket connection is closed if a write to the client does not complete
+# within this time.
+websocket_write_timeout = "{{ .RPC.WebSocketWriteTimeout }}"
+
 # Maximum size of request body, in bytes
 max_body_bytes = {{ .RPC.MaxBodyBytes }}
 
@@ -260,6 +316,13 @@ tls_cert_file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls_key_file = "{{ .RPC.TLSKeyFile }}"
 
+# The path to a file containing the certificates of the authorities allowed to
+# sign client certificates. If set, unsafe RPC commands and debug dumps require
+# a client certificate signed by one of them. All other endpoints remain public.
+# Might be either absolute path or path related to Tendermint's config directory.
+# NOTE: requires tls_cert_file and tls_key_file.
+tls_client_ca_file = "{{ .RPC.TLSClientCAFile }}"
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
@@ -285,6 +348,9 @@ seeds = "{{ .P2P.Seeds }}"
 persistent_peers = "{{ .P2P.PersistentPeers }}"
 
 # UPNP port forwarding
+# If true, the p2p port is forwarded on the internet gateway on start and the
+# gateway's external address is advertised to peers, unless external_address
+# is set.
 upnp = {{ .P2P.UPNP }}
 
 # Path to address book
@@ -318,6 +384,14 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Rate at which packets can be sent to all peers combined, in bytes/second.
+# 0 means no limit besides the per-peer send_rate.
+total_send_rate = {{ .P2P.TotalSendRate }}
+
+# Rate at which packets can be received from all peers combined, in bytes/second.
+# 0 means no limit besides the per-peer recv_rate.
+total_recv_rate = {{ .P2P.TotalRecvRate }}
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -347,6 +421,9 @@ dial_timeout = "{{ .P2P.DialTimeout }}"
 #   2) "v1" - prioritized mempool.
 version = "{{ .Mempool.Version }}"
 
+# Whether to check the transactions left in the mempool again after each block,
+# with CheckTx requests of type Recheck. Applications which don't need rechecks
+# can have them disabled.
 recheck = {{ .Mempool.Recheck }}
 broadcast = {{ .Mempool.Broadcast }}
 wal_dir = "{{ js .Mempool.WalPath }}"
@@ -439,6 +516,10 @@ chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 #   2) "v2" - complete redesign of v0, optimized for testability & readability
 version = "{{ .FastSync.Version }}"
 
+# Maximum number of blocks requested from peers or received, but not yet
+# applied. Blocks are requested from all peers in parallel. Only used by v1.
+max_pending_requests = {{ .FastSync.MaxPendingRequests }}
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -446,6 +527,10 @@ version = "{{ .FastSync.Version }}"
 
 wal_file = "{{ js .Consensus.WalPath }}"
 
+# Maximum total size of the WAL files in bytes. Once it is exceeded, the
+# oldest files are removed. 0 means no limit.
+wal_max_size = {{ .Consensus.WalMaxSize }}
+
 # How long we wait for a proposal block before prevoting nil
 timeout_propose = "{{ .Consensus.TimeoutPropose }}"
 # How much timeout_propose increases with each round
@@ -480,6 +565,41 @@ create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Verified vote signatures are cached, so that the precommits included in the
+# LastCommit of the next block are not verified again when the block is
+# validated and executed. The cache is bounded by the number of entries and by
+# their total size in bytes. Set either to 0 to disable the cache.
+vote_signature_cache_size = {{ .Consensus.VoteSignatureCacheSize }}
+vote_signature_cache_bytes = {{ .Consensus.VoteSignatureCacheBytes }}
+
+# Raise a liveness alarm (an error log, the LivenessAlarm event and the
+# consensus_liveness_alarms metric) when no block has been committed for this
+# many times the expected block interval, i.e. timeout_propose +
+# timeout_commit + create_empty_blocks_interval. 0 disables the alarm, as does
+# create_empty_blocks = false without an interval.
+max_block_interval_factor = {{ .Consensus.MaxBlockIntervalFactor }}
+
+# If set, each time the liveness alarm is raised the consensus state and the
+# stacks of all goroutines are written to a new subdirectory of this
+# directory.
+max_block_interval_dump_dir = "{{ js .Consensus.MaxBlockIntervalDumpDir }}"
+
+# Capacity of the queues of consensus messages received from peers and of our
+# own messages (proposals, block parts and votes). 0 means the default of 1000.
+peer_msg_queue_size = {{ .Consensus.PeerMsgQueueSize }}
+internal_msg_queue_size = {{ .Consensus.InternalMsgQueueSize }}
+
+# If true, consensus messages received from peers are dropped (and counted by
+# the consensus_dropped_peer_messages metric) when the peer queue is full.
+# Otherwise the receiving reactor blocks until there is room in the queue.
+drop_peer_msgs_when_full = {{ .Consensus.DropPeerMsgsWhenFull }}
+
+# Bytes of the parts of a proposal block kept in memory while receiving it,
+# e.g. 67108864 (64MB). The following parts are saved to the blockparts DB in
+# the data directory until the block is committed, which bounds the memory
+# used by big blocks. 0 keeps all of them in memory.
+block_parts_memory_budget = {{ .Consensus.BlockPartsMemoryBudget }}
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
@@ -513,6 +633,13 @@ indexer = "{{ .TxIndex.Indexer }}"
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# If true, the "kv" indexer flushes each of its writes to disk (fsync) before
+# moving on. Unlike the state, the block store, the WAL and the private
+# validator state, which are always flushed, the index can be rebuilt with the
+# reindex-event command, so it may be disabled to trade the latest entries on a
+# crash for throughput.
+sync_writes = {{ .TxIndex.SyncWrites }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -40,6 +40,36 @@ func TestEnsureRoot(t *testing.T) {
 	ensureFiles(t, tmpDir, "data")
 }
 
+func TestLoadConfigFile(t *testing.T) {
+	rootDir := t.TempDir()
+	configFilePath := filepath.Join(rootDir, defaultConfigFilePath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(configFilePath), DefaultDirPerm))
+
+	config := DefaultConfig()
+	config.Moniker = "loaded"
+	config.P2P.ListenAddress = "tcp://0.0.0.0:36656"
+	config.Mempool.Size = 1234
+	WriteConfigFile(configFilePath, config)
+
+	loaded, err := LoadConfigFile(configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, rootDir, loaded.RootDir)
+	assert.Equal(t, "loaded", loaded.Moniker)
+	assert.Equal(t, "tcp://0.0.0.0:36656", loaded.P2P.ListenAddress)
+	assert.Equal(t, 1234, loaded.Mempool.Size)
+	assert.Equal(t, config.Consensus.TimeoutCommit, loaded.Consensus.TimeoutCommit)
+	assert.Equal(t, rootDir, loaded.Mempool.RootDir)
+
+	// invalid settings are rejected
+	config.RPC.ListenAddress = "127.0.0.1:26657"
+	WriteConfigFile(configFilePath, config)
+	_, err = LoadConfigFile(configFilePath)
+	assert.Error(t, err)
+
+	_, err = LoadConfigFile(filepath.Join(rootDir, "missing.toml"))
+	assert.Error(t, err)
+}
+
 func TestEnsureTestRoot(t *testing.T) {
 	require := require.New(t)
 
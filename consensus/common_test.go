@@ -205,8 +205,17 @@ func decideProposal(
 	height int64,
 	round int32,
 ) (proposal *types.Proposal, block *types.Block) {
+	pubKey, err := vs.GetPubKey()
+	if err != nil {
+		panic(err)
+	}
+
+	// The block must name vs as its proposer.
 	cs1.mtx.Lock()
+	ownPubKey := cs1.privValidatorPubKey
+	cs1.privValidatorPubKey = pubKey
 	block, blockParts := cs1.createProposalBlock()
+	cs1.privValidatorPubKey = ownPubKey
 	validRound := cs1.ValidRound
 	chainID := cs1.state.ChainID
 	cs1.mtx.Unlock()
@@ -385,6 +394,7 @@ func newStateWithConfigAndBlockStore(
 	pv types.PrivValidator,
 	app abci.Application,
 	blockDB dbm.DB,
+	options ...StateOption,
 ) *State {
 	// Get BlockStore
 	blockStore := store.NewBlockStore(blockDB)
@@ -435,7 +445,7 @@ func newStateWithConfigAndBlockStore(
 	}
 
 	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyAppConnCon, mempool, evpool)
-	cs := NewState(thisConfig.Consensus, state, blockExec, blockStore, mempool, evpool)
+	cs := NewState(thisConfig.Consensus, state, blockExec, blockStore, mempool, evpool, options...)
 	cs.SetLogger(log.TestingLogger().With("module", "consensus"))
 	cs.SetPrivValidator(pv)
 
@@ -477,6 +487,21 @@ func randState(nValidators int) (*State, []*validatorStub) {
 
 //-------------------------------------------------------------------------------
 
+// testDeadline returns a channel which is closed shortly before the test
+// binary times out, so a test waiting for the nodes to make progress can log
+// their state before it is killed. The wait is not bounded by a fixed
+// duration, which a loaded machine could exceed; without -timeout the channel
+// is never closed.
+func testDeadline(t *testing.T) <-chan time.Time {
+	deadline, ok := t.Deadline()
+	if !ok {
+		return nil
+	}
+	timer := time.NewTimer(time.Until(deadline) * 9 / 10)
+	t.Cleanup(func() { timer.Stop() })
+	return timer.C
+}
+
 func ensureNoNewEvent(ch <-chan tmpubsub.Message, timeout time.Duration,
 	errorMessage string,
 ) {
@@ -0,0 +1,118 @@
+package consensus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// livenessMonitor keeps track of when the last block was committed, so an
+// alarm can be raised when no block gets committed for too long. It has its
+// own lock, so that it keeps working when the consensus state is stuck.
+type livenessMonitor struct {
+	mtx tmsync.Mutex
+
+	height     int64     // height waiting to be committed
+	lastCommit time.Time // local time the previous height was committed
+	alarmed    bool      // whether the alarm was raised for height
+}
+
+// blockCommitted records that the block at height was committed at the given
+// time.
+func (lm *livenessMonitor) blockCommitted(height int64, now time.Time) {
+	lm.mtx.Lock()
+	defer lm.mtx.Unlock()
+	lm.height = height + 1
+	lm.lastCommit = now
+	lm.alarmed = false
+}
+
+// check returns the alarm to raise if no block has been committed for longer
+// than maxInterval. The alarm is raised once per height.
+func (lm *livenessMonitor) check(now time.Time, maxInterval time.Duration) (types.EventDataLivenessAlarm, bool) {
+	lm.mtx.Lock()
+	defer lm.mtx.Unlock()
+	elapsed := now.Sub(lm.lastCommit)
+	if lm.alarmed || elapsed <= maxInterval {
+		return types.EventDataLivenessAlarm{}, false
+	}
+	lm.alarmed = true
+	return types.EventDataLivenessAlarm{
+		Height:         lm.height,
+		LastCommitTime: lm.lastCommit,
+		Elapsed:        elapsed,
+	}, true
+}
+
+// livenessRoutine raises the liveness alarm when no block has been committed
+// for longer than maxInterval.
+func (cs *State) livenessRoutine(maxInterval time.Duration) {
+	ticker := cs.clock.NewTicker(maxInterval / 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			if alarm, ok := cs.liveness.check(cs.now(), maxInterval); ok {
+				cs.raiseLivenessAlarm(alarm, maxInterval)
+			}
+		case <-cs.Quit():
+			return
+		}
+	}
+}
+
+func (cs *State) raiseLivenessAlarm(alarm types.EventDataLivenessAlarm, maxInterval time.Duration) {
+	cs.Logger.Error("no block committed for longer than the max block interval",
+		"height", alarm.Height, "elapsed", alarm.Elapsed, "max_block_interval", maxInterval)
+	cs.metrics.LivenessAlarms.Add(1)
+	if err := cs.eventBus.PublishEventLivenessAlarm(alarm); err != nil {
+		cs.Logger.Error("failed publishing liveness alarm", "err", err)
+	}
+
+	if dir := cs.config.MaxBlockIntervalDumpPath(); dir != "" {
+		// the consensus state may be locked by whatever stalls it, so don't
+		// block the routine on writing it
+		go func() {
+			path, err := cs.dumpDiagnostics(dir, alarm.Height)
+			if err != nil {
+				cs.Logger.Error("failed writing liveness diagnostics", "err", err)
+				return
+			}
+			cs.Logger.Info("wrote liveness diagnostics", "dir", path)
+		}()
+	}
+}
+
+// dumpDiagnostics writes the stacks of all goroutines and the consensus state
+// to a new subdirectory of dir, and returns the path of the subdirectory.
+func (cs *State) dumpDiagnostics(dir string, height int64) (string, error) {
+	path := filepath.Join(dir, fmt.Sprintf("%d-%s", height, cs.now().Format("20060102T150405")))
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return "", err
+	}
+
+	// goroutines first, since getting the consensus state may block
+	f, err := os.Create(filepath.Join(path, "goroutine.out"))
+	if err != nil {
+		return "", err
+	}
+	err = pprof.Lookup("goroutine").WriteTo(f, 2)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	roundState, err := cs.GetRoundStateJSON()
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(filepath.Join(path, "consensus_state.json"), roundState, 0o600)
+}
@@ -0,0 +1,80 @@
+package consensus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestLivenessMonitor(t *testing.T) {
+	var lm livenessMonitor
+	start := time.Now()
+	lm.blockCommitted(4, start)
+
+	_, ok := lm.check(start.Add(time.Second), time.Second)
+	assert.False(t, ok)
+
+	alarm, ok := lm.check(start.Add(2*time.Second), time.Second)
+	require.True(t, ok)
+	assert.EqualValues(t, 5, alarm.Height)
+	assert.Equal(t, start, alarm.LastCommitTime)
+	assert.Equal(t, 2*time.Second, alarm.Elapsed)
+
+	// raised once per height
+	_, ok = lm.check(start.Add(3*time.Second), time.Second)
+	assert.False(t, ok)
+
+	lm.blockCommitted(5, start.Add(3*time.Second))
+	_, ok = lm.check(start.Add(4*time.Second), time.Second)
+	assert.False(t, ok)
+	alarm, ok = lm.check(start.Add(5*time.Second), time.Second)
+	require.True(t, ok)
+	assert.EqualValues(t, 6, alarm.Height)
+}
+
+func TestStateLivenessAlarm(t *testing.T) {
+	// a single one of two validators can't commit blocks
+	cs1, _ := randState(2)
+	consensusConfig := *cs1.config
+	consensusConfig.MaxBlockIntervalFactor = 2
+	consensusConfig.MaxBlockIntervalDumpDir = t.TempDir()
+	cs1.config = &consensusConfig
+	require.Greater(t, consensusConfig.MaxBlockInterval(), time.Duration(0))
+
+	alarmCh := subscribe(cs1.eventBus, types.EventQueryLivenessAlarm)
+
+	require.NoError(t, cs1.Start())
+	t.Cleanup(func() {
+		if err := cs1.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	select {
+	case msg := <-alarmCh:
+		alarm := msg.Data().(types.EventDataLivenessAlarm)
+		assert.EqualValues(t, 1, alarm.Height)
+		assert.Greater(t, alarm.Elapsed, consensusConfig.MaxBlockInterval())
+	case <-time.After(10 * consensusConfig.MaxBlockInterval()):
+		t.Fatal("expected a liveness alarm")
+	}
+
+	// the diagnostic dump is written in the background
+	require.Eventually(t, func() bool {
+		dumps, err := filepath.Glob(filepath.Join(consensusConfig.MaxBlockIntervalDumpDir, "1-*", "*"))
+		require.NoError(t, err)
+		return len(dumps) == 2
+	}, time.Second, 10*time.Millisecond)
+	dumps, err := filepath.Glob(filepath.Join(consensusConfig.MaxBlockIntervalDumpDir, "1-*", "consensus_state.json"))
+	require.NoError(t, err)
+	require.Len(t, dumps, 1)
+	roundState, err := os.ReadFile(dumps[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(roundState), `"height":"1"`)
+}
@@ -76,6 +76,20 @@ type Metrics struct {
 	// timestamp and the timestamp of the latest prevote in a round where 100%
 	// of the voting power on the network issued prevotes.
 	FullPrevoteMessageDelay metrics.Gauge
+
+	// Number of vote signatures found in the signature cache.
+	VoteSignatureCacheHits metrics.Counter
+	// Number of vote signatures not found in the signature cache.
+	VoteSignatureCacheMisses metrics.Counter
+
+	// Number of times no block was committed for longer than the max block
+	// interval.
+	LivenessAlarms metrics.Counter
+
+	// Number of messages waiting in the peer and internal message queues.
+	MessageQueueSize metrics.Gauge
+	// Number of peer messages dropped because the peer queue was full.
+	DroppedPeerMessages metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -216,6 +230,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help: "Difference in seconds between the proposal timestamp and the timestamp " +
 				"of the latest prevote that achieved 100% of the voting power in the prevote step.",
 		}, labels).With(labelsAndValues...),
+		VoteSignatureCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "vote_signature_cache_hits",
+			Help:      "Number of vote signatures found in the signature cache.",
+		}, labels).With(labelsAndValues...),
+		VoteSignatureCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "vote_signature_cache_misses",
+			Help:      "Number of vote signatures not found in the signature cache.",
+		}, labels).With(labelsAndValues...),
+		LivenessAlarms: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "liveness_alarms",
+			Help:      "Number of times no block was committed for longer than the max block interval.",
+		}, labels).With(labelsAndValues...),
+		MessageQueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "message_queue_size",
+			Help:      "Number of messages waiting in the peer and internal message queues.",
+		}, append(labels, "queue")).With(labelsAndValues...),
+		DroppedPeerMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_peer_messages",
+			Help:      "Number of peer messages dropped because the peer queue was full.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -248,5 +292,10 @@ func NopMetrics() *Metrics {
 		BlockParts:                discard.NewCounter(),
 		QuorumPrevoteMessageDelay: discard.NewGauge(),
 		FullPrevoteMessageDelay:   discard.NewGauge(),
+		VoteSignatureCacheHits:    discard.NewCounter(),
+		VoteSignatureCacheMisses:  discard.NewCounter(),
+		LivenessAlarms:            discard.NewCounter(),
+		MessageQueueSize:          discard.NewGauge(),
+		DroppedPeerMessages:       discard.NewCounter(),
 	}
 }
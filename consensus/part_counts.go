@@ -0,0 +1,112 @@
+package consensus
+
+import (
+	"github.com/tendermint/tendermint/libs/bits"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// blockPartCounts counts, for each block our peers are collecting, how many of
+// them have each of its parts. The peer states keep it up to date as they
+// learn which parts their peer has, so that the rarest parts can be picked
+// without going through the part bit arrays of every peer.
+//
+// A nil *blockPartCounts counts nothing.
+type blockPartCounts struct {
+	mtx    tmsync.Mutex
+	blocks map[string]*partCounts // by part set header hash
+}
+
+type partCounts struct {
+	peers  int   // number of peers collecting the block
+	counts []int // number of them having each part
+}
+
+func newBlockPartCounts() *blockPartCounts {
+	return &blockPartCounts{blocks: make(map[string]*partCounts)}
+}
+
+// add records that a peer collects the block with the given header and has
+// the given parts of it.
+func (c *blockPartCounts) add(header types.PartSetHeader, parts *bits.BitArray) {
+	if c == nil || parts == nil || header.Total > types.MaxBlockPartsCount {
+		return
+	}
+	parts = parts.Copy()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := string(header.Hash)
+	pc, ok := c.blocks[key]
+	if !ok {
+		pc = &partCounts{counts: make([]int, header.Total)}
+		c.blocks[key] = pc
+	}
+	pc.peers++
+	for i := range pc.counts {
+		if parts.GetIndex(i) {
+			pc.counts[i]++
+		}
+	}
+}
+
+// remove undoes add, once the peer no longer collects the block or has other
+// parts of it.
+func (c *blockPartCounts) remove(header types.PartSetHeader, parts *bits.BitArray) {
+	if c == nil || parts == nil || header.Total > types.MaxBlockPartsCount {
+		return
+	}
+	parts = parts.Copy()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := string(header.Hash)
+	pc, ok := c.blocks[key]
+	if !ok {
+		return
+	}
+	pc.peers--
+	if pc.peers <= 0 {
+		delete(c.blocks, key)
+		return
+	}
+	for i := range pc.counts {
+		if parts.GetIndex(i) && pc.counts[i] > 0 {
+			pc.counts[i]--
+		}
+	}
+}
+
+// inc records that a peer collecting the block with the given header got one
+// more part of it.
+func (c *blockPartCounts) inc(header types.PartSetHeader, index int) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if pc, ok := c.blocks[string(header.Hash)]; ok && index >= 0 && index < len(pc.counts) {
+		pc.counts[index]++
+	}
+}
+
+// get returns how many peers have each part of the block with the given
+// header, or nil if no peer collects it.
+func (c *blockPartCounts) get(header types.PartSetHeader) []int {
+	if c == nil {
+		return nil
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	pc, ok := c.blocks[string(header.Hash)]
+	if !ok {
+		return nil
+	}
+	counts := make([]int, len(pc.counts))
+	copy(counts, pc.counts)
+	return counts
+}
@@ -0,0 +1,95 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	dbm "github.com/tendermint/tm-db"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// partSpill is the types.PartSpill of the proposal block parts of a height,
+// see ConsensusConfig.BlockPartsMemoryBudget. The parts are saved in a DB
+// under keys prefixed by the height, so that those of past heights can be
+// pruned by prunePartSpill.
+type partSpill struct {
+	db     dbm.DB
+	height int64
+}
+
+var _ types.PartSpill = (*partSpill)(nil)
+
+func (s *partSpill) SavePart(header types.PartSetHeader, part *types.Part) error {
+	pbp, err := part.ToProto()
+	if err != nil {
+		return err
+	}
+	bz, err := proto.Marshal(pbp)
+	if err != nil {
+		return err
+	}
+	return s.db.Set(partSpillKey(s.height, header, part.Index), bz)
+}
+
+func (s *partSpill) LoadPart(header types.PartSetHeader, index uint32) (*types.Part, error) {
+	bz, err := s.db.Get(partSpillKey(s.height, header, index))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, errors.New("part not found")
+	}
+	var pbp tmproto.Part
+	if err := proto.Unmarshal(bz, &pbp); err != nil {
+		return nil, fmt.Errorf("invalid part: %w", err)
+	}
+	return types.PartFromProto(&pbp)
+}
+
+// partSpillKey is the height (big endian, so that the keys are ordered by
+// height), followed by the part set header and the index of the part.
+func partSpillKey(height int64, header types.PartSetHeader, index uint32) []byte {
+	key := make([]byte, 12, 12+len(header.Hash)+4)
+	binary.BigEndian.PutUint64(key, uint64(height))
+	binary.BigEndian.PutUint32(key[8:], header.Total)
+	key = append(key, header.Hash...)
+	key = append(key, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(key[len(key)-4:], index)
+	return key
+}
+
+// prunePartSpill deletes the parts spilled at the heights below height.
+func prunePartSpill(db dbm.DB, height int64) error {
+	end := make([]byte, 8)
+	binary.BigEndian.PutUint64(end, uint64(height))
+	it, err := db.Iterator(nil, end)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	// the iterator must be closed before writing to the DB
+	it.Close()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
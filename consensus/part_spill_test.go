@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPartSpill(t *testing.T) {
+	db := dbm.NewMemDB()
+	parts := types.NewPartSetFromData(tmrand.Bytes(3*int(types.BlockPartSizeBytes)), types.BlockPartSizeBytes)
+
+	// the parts of a few heights are spilled
+	for height := int64(1); height <= 3; height++ {
+		spill := &partSpill{db: db, height: height}
+		for i := 0; i < int(parts.Total()); i++ {
+			require.NoError(t, spill.SavePart(parts.Header(), parts.GetPart(i)))
+		}
+	}
+	spill := &partSpill{db: db, height: 2}
+	part, err := spill.LoadPart(parts.Header(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, parts.GetPart(1), part)
+	_, err = spill.LoadPart(parts.Header(), parts.Total())
+	assert.Error(t, err)
+
+	// pruning removes those of the heights below
+	require.NoError(t, prunePartSpill(db, 3))
+	for height := int64(1); height <= 3; height++ {
+		spill := &partSpill{db: db, height: height}
+		for i := 0; i < int(parts.Total()); i++ {
+			_, err := spill.LoadPart(parts.Header(), uint32(i))
+			if height < 3 {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestStateSpillsProposalBlockParts(t *testing.T) {
+	cs1, _ := randState(1)
+	cs1.config.BlockPartsMemoryBudget = int64(types.BlockPartSizeBytes)
+	db := dbm.NewMemDB()
+	StateBlockPartSpill(db)(cs1)
+
+	parts := types.NewPartSetFromData(tmrand.Bytes(3*int(types.BlockPartSizeBytes)), types.BlockPartSizeBytes)
+	cs1.mtx.Lock()
+	proposalParts := cs1.newProposalBlockParts(parts.Header())
+	cs1.mtx.Unlock()
+	for i := 0; i < int(parts.Total()); i++ {
+		_, err := proposalParts.AddPart(parts.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	// all but the first part are in the DB
+	spill := &partSpill{db: db, height: cs1.Height}
+	_, err := spill.LoadPart(parts.Header(), 0)
+	assert.Error(t, err)
+	for i := 1; i < int(parts.Total()); i++ {
+		_, err := spill.LoadPart(parts.Header(), uint32(i))
+		assert.NoError(t, err)
+	}
+	for i := 0; i < int(parts.Total()); i++ {
+		assert.Equal(t, parts.GetPart(i), proposalParts.GetPart(i))
+	}
+}
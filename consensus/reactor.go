@@ -13,6 +13,7 @@ import (
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
@@ -48,6 +49,13 @@ type Reactor struct {
 	rs       *cstypes.RoundState
 
 	Metrics *Metrics
+
+	// how many peers have each part of the blocks they are collecting, kept
+	// up to date by the peer states
+	partCounts *blockPartCounts
+
+	// called once the consensus state machine has started
+	onSwitchToConsensus func(height int64)
 }
 
 type ReactorOption func(*Reactor)
@@ -56,10 +64,11 @@ type ReactorOption func(*Reactor)
 // consensusState.
 func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption) *Reactor {
 	conR := &Reactor{
-		conS:     consensusState,
-		waitSync: waitSync,
-		rs:       consensusState.GetRoundState(),
-		Metrics:  NopMetrics(),
+		conS:       consensusState,
+		waitSync:   waitSync,
+		rs:         consensusState.GetRoundState(),
+		Metrics:    NopMetrics(),
+		partCounts: newBlockPartCounts(),
 	}
 	conR.BaseReactor = *p2p.NewBaseReactor("Consensus", conR)
 
@@ -86,11 +95,28 @@ func (conR *Reactor) OnStart() error {
 		if err != nil {
 			return err
 		}
+		conR.notifySwitchToConsensus(conR.conS.GetState().LastBlockHeight)
 	}
 
 	return nil
 }
 
+// SetSwitchToConsensusCallback sets a function to call once the consensus
+// state machine has started, either when the reactor starts or, if it waits
+// for fast sync or state sync, when SwitchToConsensus is called. The function
+// receives the height of the last block applied before consensus took over.
+// It is called synchronously, so it should not block. It must be set before
+// the reactor is started.
+func (conR *Reactor) SetSwitchToConsensusCallback(fn func(height int64)) {
+	conR.onSwitchToConsensus = fn
+}
+
+func (conR *Reactor) notifySwitchToConsensus(height int64) {
+	if conR.onSwitchToConsensus != nil {
+		conR.onSwitchToConsensus(height)
+	}
+}
+
 // OnStop implements BaseService by unsubscribing from events and stopping
 // state.
 func (conR *Reactor) OnStop() {
@@ -136,6 +162,7 @@ conS:
 conR:
 %+v`, err, conR.conS, conR))
 	}
+	conR.notifySwitchToConsensus(state.LastBlockHeight)
 }
 
 // GetChannels implements Reactor
@@ -180,6 +207,7 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 // InitPeer implements Reactor by creating a state for the peer.
 func (conR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
 	peerState := NewPeerState(peer).SetLogger(conR.Logger)
+	peerState.partCounts = conR.partCounts
 	peer.Set(types.PeerStateKey, peerState)
 	return peer
 }
@@ -207,8 +235,12 @@ func (conR *Reactor) AddPeer(peer p2p.Peer) {
 	}
 }
 
-// RemovePeer is a noop.
+// RemovePeer implements Reactor by no longer counting the block parts of the
+// peer.
 func (conR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+		ps.untrackBlockParts()
+	}
 	if !conR.IsRunning() {
 		return
 	}
@@ -238,13 +270,13 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 	msg, err := MsgFromProto(m.(*tmcons.Message))
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", e.Src, "chId", e.ChannelID, "err", err)
-		conR.Switch.StopPeerForError(e.Src, err)
+		conR.Switch.StopPeerForMisbehavior(e.Src, err)
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		conR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", e.Message, "err", err)
-		conR.Switch.StopPeerForError(e.Src, err)
+		conR.Switch.StopPeerForMisbehavior(e.Src, err)
 		return
 	}
 
@@ -265,7 +297,7 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			conR.conS.mtx.Unlock()
 			if err = msg.ValidateHeight(initialHeight); err != nil {
 				conR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", msg, "err", err)
-				conR.Switch.StopPeerForError(e.Src, err)
+				conR.Switch.StopPeerForMisbehavior(e.Src, err)
 				return
 			}
 			ps.ApplyNewRoundStepMessage(msg)
@@ -284,7 +316,7 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			// Peer claims to have a maj23 for some BlockID at H,R,S,
 			err := votes.SetPeerMaj23(msg.Round, msg.Type, ps.peer.ID(), msg.BlockID)
 			if err != nil {
-				conR.Switch.StopPeerForError(e.Src, err)
+				conR.Switch.StopPeerForMisbehavior(e.Src, err)
 				return
 			}
 			// Respond with a VoteSetBitsMessage showing which votes we have.
@@ -323,13 +355,13 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		switch msg := msg.(type) {
 		case *ProposalMessage:
 			ps.SetHasProposal(msg.Proposal)
-			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			conR.conS.queuePeerMsg(msgInfo{msg, e.Src.ID()})
 		case *ProposalPOLMessage:
 			ps.ApplyProposalPOLMessage(msg)
 		case *BlockPartMessage:
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
-			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			conR.conS.queuePeerMsg(msgInfo{msg, e.Src.ID()})
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -349,7 +381,7 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
 
-			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			cs.queuePeerMsg(msgInfo{msg, e.Src.ID()})
 
 		default:
 			// don't punish (leave room for soft upgrades)
@@ -569,26 +601,24 @@ OUTER_LOOP:
 		prs := ps.GetRoundState()
 
 		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
-				part := rs.ProposalBlockParts.GetPart(index)
-				parts, err := part.ToProto()
-				if err != nil {
-					panic(err)
-				}
-				logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
-				if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
-					ChannelID: DataChannel,
-					Message: &tmcons.BlockPart{
-						Height: rs.Height, // This tells peer that this part applies to us.
-						Round:  rs.Round,  // This tells peer that this part applies to us.
-						Part:   *parts,
-					},
-				}, logger) {
-					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
-				}
-				continue OUTER_LOOP
+		if part, ok := pickBlockPartToSend(rs, prs, conR.partCounts.get); ok {
+			index := int(part.Index)
+			parts, err := part.ToProto()
+			if err != nil {
+				panic(err)
+			}
+			logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
+			if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+				ChannelID: DataChannel,
+				Message: &tmcons.BlockPart{
+					Height: rs.Height, // This tells peer that this part applies to us.
+					Round:  rs.Round,  // This tells peer that this part applies to us.
+					Part:   *parts,
+				},
+			}, logger) {
+				ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 			}
+			continue OUTER_LOOP
 		}
 
 		// If the peer is on a previous height that we have, help catch up.
@@ -663,6 +693,80 @@ OUTER_LOOP:
 	}
 }
 
+// pickBlockPartToSend picks a part of the block the peer is collecting parts
+// for, which we have and the peer does not. Besides our current proposal, a
+// peer at an earlier round of our height may be collecting a block which got
+// a POL in its round, and which we keep as our valid or locked block even
+// after moving on to later rounds (where the proposal may be missing or
+// different). Without those, such a peer could only catch up once the block
+// was committed.
+//
+// Of those parts, it picks one of the rarest among the peers collecting the
+// same block, as counted by partCounts (which may be nil), so that each part
+// spreads through the network as soon as possible, rather than all of the
+// peers waiting for the same last parts.
+func pickBlockPartToSend(
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	partCounts func(types.PartSetHeader) []int,
+) (*types.Part, bool) {
+	partSets := []*types.PartSet{rs.ProposalBlockParts}
+	if rs.Height == prs.Height {
+		partSets = append(partSets, rs.ValidBlockParts, rs.LockedBlockParts)
+	}
+	for _, partSet := range partSets {
+		if !partSet.HasHeader(prs.ProposalBlockPartSetHeader) {
+			continue
+		}
+		missing := partSet.BitArray().Sub(prs.ProposalBlockParts.Copy())
+		if missing.IsEmpty() {
+			continue
+		}
+		var counts []int
+		if partCounts != nil {
+			counts = partCounts(prs.ProposalBlockPartSetHeader)
+		}
+		if index, ok := pickRarestPart(missing, counts); ok {
+			// a spilled part may fail to load
+			if part := partSet.GetPart(index); part != nil {
+				return part, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// pickRarestPart picks, at random, one of the parts of candidates with the
+// lowest count, counts giving how many peers have each part.
+func pickRarestPart(candidates *bits.BitArray, counts []int) (int, bool) {
+	if len(counts) == 0 {
+		return candidates.PickRandom()
+	}
+
+	var rarest []int
+	minCount := -1
+	for i := 0; i < candidates.Size(); i++ {
+		if !candidates.GetIndex(i) {
+			continue
+		}
+		count := 0
+		if i < len(counts) {
+			count = counts[i]
+		}
+		switch {
+		case minCount == -1 || count < minCount:
+			minCount = count
+			rarest = append(rarest[:0], i)
+		case count == minCount:
+			rarest = append(rarest, i)
+		}
+	}
+	if len(rarest) == 0 {
+		return 0, false
+	}
+	return rarest[tmrand.Intn(len(rarest))], true
+}
+
 func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundState,
 	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) {
 
@@ -1032,6 +1136,10 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// the reactor's counts of the block parts peers have, updated with the
+	// parts of this peer; may be nil
+	partCounts *blockPartCounts
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1113,8 +1221,8 @@ func (ps *PeerState) SetHasProposal(proposal *types.Proposal) {
 		return
 	}
 
-	ps.PRS.ProposalBlockPartSetHeader = proposal.BlockID.PartSetHeader
-	ps.PRS.ProposalBlockParts = bits.NewBitArray(int(proposal.BlockID.PartSetHeader.Total))
+	ps.setProposalBlockParts(proposal.BlockID.PartSetHeader,
+		bits.NewBitArray(int(proposal.BlockID.PartSetHeader.Total)))
 	ps.PRS.ProposalPOLRound = proposal.POLRound
 	ps.PRS.ProposalPOL = nil // Nil until ProposalPOLMessage received.
 }
@@ -1128,8 +1236,26 @@ func (ps *PeerState) InitProposalBlockParts(partSetHeader types.PartSetHeader) {
 		return
 	}
 
+	ps.setProposalBlockParts(partSetHeader, bits.NewBitArray(int(partSetHeader.Total)))
+}
+
+// setProposalBlockParts sets the peer's proposal block parts header and bit
+// array, updating the part counts. ps.mtx must be held.
+func (ps *PeerState) setProposalBlockParts(partSetHeader types.PartSetHeader, parts *bits.BitArray) {
+	ps.partCounts.remove(ps.PRS.ProposalBlockPartSetHeader, ps.PRS.ProposalBlockParts)
 	ps.PRS.ProposalBlockPartSetHeader = partSetHeader
-	ps.PRS.ProposalBlockParts = bits.NewBitArray(int(partSetHeader.Total))
+	ps.PRS.ProposalBlockParts = parts
+	ps.partCounts.add(partSetHeader, parts)
+}
+
+// untrackBlockParts removes the parts of the peer from the part counts, and
+// stops updating them, once the peer is gone.
+func (ps *PeerState) untrackBlockParts() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.partCounts.remove(ps.PRS.ProposalBlockPartSetHeader, ps.PRS.ProposalBlockParts)
+	ps.partCounts = nil
 }
 
 // SetHasProposalBlockPart sets the given block part index as known for the peer.
@@ -1141,7 +1267,9 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 		return
 	}
 
-	ps.PRS.ProposalBlockParts.SetIndex(index, true)
+	if !ps.PRS.ProposalBlockParts.GetIndex(index) && ps.PRS.ProposalBlockParts.SetIndex(index, true) {
+		ps.partCounts.inc(ps.PRS.ProposalBlockPartSetHeader, index)
+	}
 }
 
 // PickSendVote picks a vote and sends it to the peer.
@@ -1383,8 +1511,7 @@ func (ps *PeerState) ApplyNewRoundStepMessage(msg *NewRoundStepMessage) {
 	ps.PRS.StartTime = startTime
 	if psHeight != msg.Height || psRound != msg.Round {
 		ps.PRS.Proposal = false
-		ps.PRS.ProposalBlockPartSetHeader = types.PartSetHeader{}
-		ps.PRS.ProposalBlockParts = nil
+		ps.setProposalBlockParts(types.PartSetHeader{}, nil)
 		ps.PRS.ProposalPOLRound = -1
 		ps.PRS.ProposalPOL = nil
 		// We'll update the BitArray capacity later.
@@ -1426,8 +1553,7 @@ func (ps *PeerState) ApplyNewValidBlockMessage(msg *NewValidBlockMessage) {
 		return
 	}
 
-	ps.PRS.ProposalBlockPartSetHeader = msg.BlockPartSetHeader
-	ps.PRS.ProposalBlockParts = msg.BlockParts
+	ps.setProposalBlockParts(msg.BlockPartSetHeader, msg.BlockParts)
 }
 
 // ApplyProposalPOLMessage updates the peer state for the new proposal POL.
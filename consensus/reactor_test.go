@@ -28,6 +28,7 @@ import (
 	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
@@ -336,6 +337,31 @@ func TestReactorReceivePanicsIfInitPeerHasntBeenCalledYet(t *testing.T) {
 	})
 }
 
+func TestReactorSwitchToConsensusCallback(t *testing.T) {
+	cs, _ := randState(1)
+	reactor := NewReactor(cs, true)
+	reactor.SetLogger(log.TestingLogger())
+
+	switched := make(chan int64, 1)
+	reactor.SetSwitchToConsensusCallback(func(height int64) { switched <- height })
+
+	state := cs.GetState()
+	reactor.SwitchToConsensus(state, false)
+	t.Cleanup(func() {
+		if err := cs.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	require.True(t, cs.IsRunning())
+	select {
+	case height := <-switched:
+		assert.Equal(t, state.LastBlockHeight, height)
+	default:
+		t.Fatal("expected the callback to be called")
+	}
+}
+
 // Test we record stats about votes and block parts from other peers.
 func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	N := 4
@@ -358,6 +384,218 @@ func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	assert.Equal(t, true, ps.BlockPartsSent() > 0, "number of votes sent should have increased")
 }
 
+// A peer stuck at an earlier round of our height collecting the block which
+// got a POL there must get its parts even though we moved on to a round with
+// no (or a different) proposal.
+func TestReactorGossipsValidBlockToLaggingPeer(t *testing.T) {
+	txs := make([]types.Tx, 3)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(int(types.BlockPartSizeBytes))
+	}
+	block := types.MakeBlock(1, txs, nil, nil)
+	blockParts := block.MakePartSet(types.BlockPartSizeBytes)
+	require.Greater(t, blockParts.Total(), uint32(1))
+
+	otherBlock := types.MakeBlock(1, []types.Tx{types.Tx("other")}, nil, nil)
+	otherBlockParts := otherBlock.MakePartSet(types.BlockPartSizeBytes)
+
+	// we are at round 2 with a different proposal; the block got a POL at round 0
+	rs := &cstypes.RoundState{
+		Height:             1,
+		Round:              2,
+		ProposalBlockParts: otherBlockParts,
+		ValidRound:         0,
+		ValidBlockParts:    blockParts,
+	}
+
+	// the peer is still at round 0
+	ps := NewPeerState(p2pmock.NewPeer(nil))
+	ps.PRS.Height = 1
+	ps.PRS.Round = 0
+	ps.InitProposalBlockParts(blockParts.Header())
+
+	peerParts := types.NewPartSetFromHeader(blockParts.Header())
+	for {
+		part, ok := pickBlockPartToSend(rs, ps.GetRoundState(), nil)
+		if !ok {
+			break
+		}
+		added, err := peerParts.AddPart(part)
+		require.NoError(t, err)
+		require.True(t, added, "part %d sent twice", part.Index)
+		ps.SetHasProposalBlockPart(1, 0, int(part.Index))
+	}
+	require.True(t, peerParts.IsComplete())
+	assert.Equal(t, blockParts.Hash(), peerParts.Hash())
+
+	// a peer at another height does not get parts of the valid block
+	ps = NewPeerState(p2pmock.NewPeer(nil))
+	ps.PRS.Height = 2
+	ps.InitProposalBlockParts(blockParts.Header())
+	_, ok := pickBlockPartToSend(rs, ps.GetRoundState(), nil)
+	assert.False(t, ok)
+}
+
+// blockPartPeer is a peer recording the block parts sent to it.
+type blockPartPeer struct {
+	*p2pmock.Peer
+	parts chan *types.Part
+}
+
+func (p *blockPartPeer) SendEnvelope(e p2p.Envelope) bool {
+	if msg, ok := e.Message.(*tmcons.BlockPart); ok {
+		part, err := types.PartFromProto(&msg.Part)
+		if err != nil {
+			panic(err)
+		}
+		p.parts <- part
+	}
+	return true
+}
+
+// A lagging peer which received part of the block which got a POL in its
+// round catches up through the gossip of the reactor, after we moved on to a
+// round with a different proposal.
+func TestReactorCatchesUpLaggingPeerWithPartialBlock(t *testing.T) {
+	cs, _ := randState(1)
+
+	txs := make([]types.Tx, 3)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(int(types.BlockPartSizeBytes))
+	}
+	block := types.MakeBlock(1, txs, nil, nil)
+	blockParts := block.MakePartSet(types.BlockPartSizeBytes)
+	require.Greater(t, blockParts.Total(), uint32(2))
+	otherBlock := types.MakeBlock(1, []types.Tx{types.Tx("other")}, nil, nil)
+
+	// we are at round 2 with a different proposal; the block got a POL at round 0
+	cs.mtx.Lock()
+	cs.Round = 2
+	cs.ProposalBlockParts = otherBlock.MakePartSet(types.BlockPartSizeBytes)
+	cs.ValidRound = 0
+	cs.ValidBlock = block
+	cs.ValidBlockParts = blockParts
+	cs.mtx.Unlock()
+
+	conR := NewReactor(cs, true) // the state machine is not started
+	conR.SetLogger(log.TestingLogger())
+	require.NoError(t, conR.Start())
+	t.Cleanup(func() {
+		if err := conR.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	peer := &blockPartPeer{Peer: p2pmock.NewPeer(nil), parts: make(chan *types.Part, blockParts.Total())}
+	t.Cleanup(func() {
+		if err := peer.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	conR.InitPeer(peer)
+
+	// the peer is still at round 0, and has the first part of the block
+	ps := peer.Get(types.PeerStateKey).(*PeerState)
+	ps.PRS.Height = 1
+	ps.PRS.Round = 0
+	ps.InitProposalBlockParts(blockParts.Header())
+	ps.SetHasProposalBlockPart(1, 0, 0)
+	peerParts := types.NewPartSetFromHeader(blockParts.Header())
+	_, err := peerParts.AddPart(blockParts.GetPart(0))
+	require.NoError(t, err)
+
+	conR.AddPeer(peer)
+
+	timeout := testDeadline(t)
+	for !peerParts.IsComplete() {
+		select {
+		case part := <-peer.parts:
+			added, err := peerParts.AddPart(part)
+			require.NoError(t, err)
+			require.True(t, added, "part %d sent again", part.Index)
+		case <-timeout:
+			t.Fatalf("the peer got %v of the parts", peerParts.BitArray())
+		}
+	}
+	assert.Equal(t, blockParts.Hash(), peerParts.Hash())
+}
+
+func TestPickRarestPart(t *testing.T) {
+	newBitArray := func(indices ...int) *bits.BitArray {
+		bA := bits.NewBitArray(4)
+		for _, i := range indices {
+			bA.SetIndex(i, true)
+		}
+		return bA
+	}
+
+	// without counts, any candidate
+	index, ok := pickRarestPart(newBitArray(1, 2), nil)
+	require.True(t, ok)
+	assert.Contains(t, []int{1, 2}, index)
+
+	_, ok = pickRarestPart(newBitArray(), []int{1, 0, 0, 0})
+	assert.False(t, ok)
+
+	// part 3 is the only one no peer has
+	counts := []int{2, 2, 3, 0}
+	for i := 0; i < 10; i++ {
+		index, ok = pickRarestPart(newBitArray(0, 1, 2, 3), counts)
+		require.True(t, ok)
+		assert.Equal(t, 3, index)
+	}
+
+	// parts 0 and 1 are both had by two peers, part 2 by three
+	for i := 0; i < 10; i++ {
+		index, ok = pickRarestPart(newBitArray(0, 1, 2), counts)
+		require.True(t, ok)
+		assert.Contains(t, []int{0, 1}, index)
+	}
+}
+
+func TestPeerStateBlockPartCounts(t *testing.T) {
+	header := types.PartSetHeader{Total: 3, Hash: tmrand.Bytes(tmhash.Size)}
+	otherHeader := types.PartSetHeader{Total: 2, Hash: tmrand.Bytes(tmhash.Size)}
+	partCounts := newBlockPartCounts()
+	newPeerState := func() *PeerState {
+		ps := NewPeerState(p2pmock.NewPeer(nil))
+		ps.partCounts = partCounts
+		ps.PRS.Height = 1
+		ps.PRS.Round = 0
+		return ps
+	}
+
+	ps1, ps2 := newPeerState(), newPeerState()
+	ps1.InitProposalBlockParts(header)
+	ps2.InitProposalBlockParts(header)
+	assert.Equal(t, []int{0, 0, 0}, partCounts.get(header))
+
+	ps1.SetHasProposalBlockPart(1, 0, 0)
+	ps1.SetHasProposalBlockPart(1, 0, 0) // counted once
+	ps2.SetHasProposalBlockPart(1, 0, 0)
+	ps2.SetHasProposalBlockPart(1, 0, 2)
+	assert.Equal(t, []int{2, 0, 1}, partCounts.get(header))
+
+	// a peer learning about a new valid block collects that one instead
+	blockParts := bits.NewBitArray(2)
+	blockParts.SetIndex(1, true)
+	ps2.ApplyNewValidBlockMessage(&NewValidBlockMessage{
+		Height: 1, Round: 0, BlockPartSetHeader: otherHeader, BlockParts: blockParts,
+	})
+	assert.Equal(t, []int{1, 0, 0}, partCounts.get(header))
+	assert.Equal(t, []int{0, 1}, partCounts.get(otherHeader))
+
+	// a peer moving to the next round no longer collects the block
+	ps1.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: 1, Round: 1, Step: cstypes.RoundStepPropose})
+	assert.Nil(t, partCounts.get(header))
+
+	// nor does a peer which is gone
+	ps2.untrackBlockParts()
+	assert.Nil(t, partCounts.get(otherHeader))
+	ps2.SetHasProposalBlockPart(1, 0, 0)
+	assert.Nil(t, partCounts.get(otherHeader))
+}
+
 //-------------------------------------------------------------
 // ensure we can make blocks despite cycling a validator set
 
@@ -698,12 +936,11 @@ func timeoutWaitGroup(t *testing.T, n int, f func(int), css []*State) {
 	}()
 
 	// we're running many nodes in-process, possibly in in a virtual machine,
-	// and spewing debug messages - making a block could take a while,
-	timeout := time.Second * 120
-
+	// and spewing debug messages - making a block could take a while, so wait
+	// for as long as the test may run
 	select {
 	case <-done:
-	case <-time.After(timeout):
+	case <-testDeadline(t):
 		for i, cs := range css {
 			t.Log("#################")
 			t.Log("Validator", i)
@@ -264,6 +264,18 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		h.initialState.Version.Consensus.App = res.AppVersion
 	}
 
+	// An app which is not behind runs the protocol version of the state, as
+	// the app set it, unless it was swapped for one which does not. This is
+	// only reported: the blocks we make carry the version of the state, and
+	// apps upgraded ahead of the height switching protocols report the new
+	// version early.
+	if blockHeight > 0 && blockHeight == h.initialState.LastBlockHeight &&
+		res.AppVersion != h.initialState.Version.Consensus.App {
+		h.logger.Error("App protocol version differs from the one of the state, the app may not be compatible",
+			"app", res.AppVersion,
+			"state", h.initialState.Version.Consensus.App)
+	}
+
 	// Replay blocks up to the latest in the blockstore.
 	_, err = h.ReplayBlocks(h.initialState, appHash, blockHeight, proxyApp)
 	if err != nil {
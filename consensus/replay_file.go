@@ -13,6 +13,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/proxy"
@@ -38,13 +39,34 @@ func RunReplayFile(config cfg.BaseConfig, csConfig *cfg.ConsensusConfig, console
 	}
 }
 
+// RunReplayScript replays the wal file in the console, reading the commands
+// from the script file instead of stdin. The replay ends with the script.
+func RunReplayScript(config cfg.BaseConfig, csConfig *cfg.ConsensusConfig, script string) {
+	consensusState := newConsensusStateForReplay(config, csConfig)
+
+	fp, err := os.Open(script)
+	if err != nil {
+		tmos.Exit(err.Error())
+	}
+	defer fp.Close()
+
+	if err := consensusState.replayFile(csConfig.WalFile(), true, fp, os.Stdout, true); err != nil {
+		tmos.Exit(fmt.Sprintf("Error during consensus replay: %v", err))
+	}
+}
+
 // Replay msgs in file or start the console
 func (cs *State) ReplayFile(file string, console bool) error {
+	return cs.replayFile(file, console, os.Stdin, os.Stdout, false)
+}
 
+// replayFile replays msgs in file. In console mode, the commands are read
+// from in and echoed to out if echo is set.
+func (cs *State) replayFile(file string, console bool, in io.Reader, out io.Writer, echo bool) error {
 	if cs.IsRunning() {
 		return errors.New("cs is already running, cannot replay")
 	}
-	if cs.wal != nil {
+	if _, ok := cs.wal.(nilWAL); !ok {
 		return errors.New("cs wal is open, cannot replay")
 	}
 
@@ -53,7 +75,7 @@ func (cs *State) ReplayFile(file string, console bool) error {
 	// ensure all new step events are regenerated as expected
 
 	ctx := context.Background()
-	newStepSub, err := cs.eventBus.Subscribe(ctx, subscriber, types.EventQueryNewRoundStep)
+	newStepSub, err := cs.eventBus.Subscribe(ctx, subscriber, types.EventQueryNewRoundStep, replayStepCapacity)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe %s to %v", subscriber, types.EventQueryNewRoundStep)
 	}
@@ -70,13 +92,19 @@ func (cs *State) ReplayFile(file string, console bool) error {
 	}
 
 	pb := newPlayback(file, fp, cs, cs.state.Copy())
+	pb.in, pb.out, pb.echo = bufio.NewReader(in), out, echo
 	defer pb.fp.Close()
 
-	var nextN int // apply N msgs in a row
+	var nextN int // apply N msgs in a row, or all msgs until the breakpoint if negative
 	var msg *TimedWALMessage
 	for {
 		if nextN == 0 && console {
-			nextN = pb.replayConsoleLoop()
+			nextN, err = pb.replayConsoleLoop()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
 		}
 
 		msg, err = pb.dec.Decode()
@@ -86,17 +114,26 @@ func (cs *State) ReplayFile(file string, console bool) error {
 			return err
 		}
 
-		if err := pb.cs.readReplayMessage(msg, newStepSub); err != nil {
+		if err := pb.replayMessage(msg, newStepSub); err != nil {
 			return err
 		}
+		pb.count++
 
-		if nextN > 0 {
+		if pb.breakpointHit() {
+			rs := pb.cs.RoundState
+			fmt.Fprintf(pb.out, "Break at %s: %v/%v/%v (%d)\n", pb.breakAt, rs.Height, rs.Round, rs.Step, pb.count)
+			pb.breakAt = ""
+			nextN = 0
+		} else if nextN > 0 {
 			nextN--
 		}
-		pb.count++
 	}
 }
 
+// replayStepCapacity is the capacity of the subscription to the new step
+// events of a replay. A single message can cause several steps.
+const replayStepCapacity = 100
+
 //------------------------------------------------
 // playback manager
 
@@ -110,6 +147,14 @@ type playback struct {
 	// replays can be reset to beginning
 	fileName     string   // so we can close/reopen the file
 	genesisState sm.State // so the replay session knows where to restart from
+
+	// console
+	in   *bufio.Reader
+	out  io.Writer
+	echo bool // print the commands read, when they don't come from a terminal
+
+	diff    []roundStateChange // changes of the RoundState by the last msg
+	breakAt string             // replay until this breakpoint
 }
 
 func newPlayback(fileName string, fp *os.File, cs *State, genState sm.State) *playback {
@@ -119,7 +164,33 @@ func newPlayback(fileName string, fp *os.File, cs *State, genState sm.State) *pl
 		fileName:     fileName,
 		genesisState: genState,
 		dec:          NewWALDecoder(fp),
+		in:           bufio.NewReader(os.Stdin),
+		out:          os.Stdout,
+	}
+}
+
+// replayMessage replays msg, recording the changes to the RoundState.
+func (pb *playback) replayMessage(msg *TimedWALMessage, newStepSub types.Subscription) error {
+	before := roundStateFields(&pb.cs.RoundState)
+	if err := pb.cs.readReplayMessage(msg, newStepSub); err != nil {
+		return err
 	}
+	pb.diff = diffRoundStateFields(before, roundStateFields(&pb.cs.RoundState))
+	return nil
+}
+
+// breakpointHit tells whether the last msg hit the breakpoint.
+func (pb *playback) breakpointHit() bool {
+	bp, ok := replayBreakpoints[pb.breakAt]
+	if !ok {
+		return false
+	}
+	for _, c := range pb.diff {
+		if c.name == bp.field && bp.hit(c.to) {
+			return true
+		}
+	}
+	return false
 }
 
 // go back count steps by resetting the state and running (pb.count - count) steps
@@ -144,7 +215,7 @@ func (pb *playback) replayReset(count int, newStepSub types.Subscription) error
 	pb.fp = fp
 	pb.dec = NewWALDecoder(fp)
 	count = pb.count - count
-	fmt.Printf("Reseting from %d to %d\n", pb.count, count)
+	fmt.Fprintf(pb.out, "Reseting from %d to %d\n", pb.count, count)
 	pb.count = 0
 	pb.cs = newCS
 	var msg *TimedWALMessage
@@ -155,7 +226,7 @@ func (pb *playback) replayReset(count int, newStepSub types.Subscription) error
 		} else if err != nil {
 			return err
 		}
-		if err := pb.cs.readReplayMessage(msg, newStepSub); err != nil {
+		if err := pb.replayMessage(msg, newStepSub); err != nil {
 			return err
 		}
 		pb.count++
@@ -164,34 +235,39 @@ func (pb *playback) replayReset(count int, newStepSub types.Subscription) error
 }
 
 func (cs *State) startForReplay() {
-	cs.Logger.Error("Replay commands are disabled until someone updates them and writes tests")
-	/* TODO:!
-	// since we replay tocks we just ignore ticks
-		go func() {
-			for {
-				select {
-				case <-cs.tickChan:
-				case <-cs.Quit:
-					return
-				}
-			}
-		}()*/
+	// the timeouts which fired are replayed from the WAL, so the ones
+	// scheduled during the replay are ignored
+	cs.SetTimeoutTicker(replayTicker{})
 }
 
-// console function for parsing input and running commands
-func (pb *playback) replayConsoleLoop() int {
+// replayTicker is a TimeoutTicker which never fires.
+type replayTicker struct{}
+
+func (replayTicker) Start() error                   { return nil }
+func (replayTicker) Stop() error                    { return nil }
+func (replayTicker) Chan() <-chan timeoutInfo       { return nil }
+func (replayTicker) ScheduleTimeout(ti timeoutInfo) {}
+func (replayTicker) SetLogger(log.Logger)           {}
+
+// console function for parsing input and running commands. It returns the
+// number of msgs to replay, or -1 to replay until the breakpoint.
+func (pb *playback) replayConsoleLoop() (int, error) {
 	for {
-		fmt.Printf("> ")
-		bufReader := bufio.NewReader(os.Stdin)
-		line, more, err := bufReader.ReadLine()
-		if more {
-			tmos.Exit("input is too long")
-		} else if err != nil {
-			tmos.Exit(err.Error())
+		fmt.Fprintf(pb.out, "> ")
+		line, err := pb.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				fmt.Fprintln(pb.out)
+			}
+			return 0, err
+		}
+		line = strings.TrimSpace(line)
+		if pb.echo {
+			fmt.Fprintln(pb.out, line)
 		}
 
-		tokens := strings.Split(string(line), " ")
-		if len(tokens) == 0 {
+		tokens := strings.Fields(line)
+		if len(tokens) == 0 || strings.HasPrefix(tokens[0], "#") {
 			continue
 		}
 
@@ -201,13 +277,13 @@ func (pb *playback) replayConsoleLoop() int {
 			// "next N" -> replay next N messages
 
 			if len(tokens) == 1 {
-				return 0
+				return 0, nil
 			}
 			i, err := strconv.Atoi(tokens[1])
 			if err != nil {
-				fmt.Println("next takes an integer argument")
+				fmt.Fprintln(pb.out, "next takes an integer argument")
 			} else {
-				return i
+				return i, nil
 			}
 
 		case "back":
@@ -220,7 +296,7 @@ func (pb *playback) replayConsoleLoop() int {
 			ctx := context.Background()
 			// ensure all new step events are regenerated as expected
 
-			newStepSub, err := pb.cs.eventBus.Subscribe(ctx, subscriber, types.EventQueryNewRoundStep)
+			newStepSub, err := pb.cs.eventBus.Subscribe(ctx, subscriber, types.EventQueryNewRoundStep, replayStepCapacity)
 			if err != nil {
 				tmos.Exit(fmt.Sprintf("failed to subscribe %s to %v", subscriber, types.EventQueryNewRoundStep))
 			}
@@ -237,14 +313,39 @@ func (pb *playback) replayConsoleLoop() int {
 			} else {
 				i, err := strconv.Atoi(tokens[1])
 				if err != nil {
-					fmt.Println("back takes an integer argument")
+					fmt.Fprintln(pb.out, "back takes an integer argument")
 				} else if i > pb.count {
-					fmt.Printf("argument to back must not be larger than the current count (%d)\n", pb.count)
+					fmt.Fprintf(pb.out, "argument to back must not be larger than the current count (%d)\n", pb.count)
 				} else if err := pb.replayReset(i, newStepSub); err != nil {
 					pb.cs.Logger.Error("Replay reset error", "err", err)
 				}
 			}
 
+		case "break":
+			// "break <event>" -> replay until the next polka, lock or commit
+
+			if len(tokens) == 1 {
+				fmt.Fprintln(pb.out, "break takes an event: polka, lock or commit")
+				continue
+			}
+			event := strings.ToLower(tokens[1])
+			if _, ok := replayBreakpoints[event]; !ok {
+				fmt.Fprintln(pb.out, "Unknown event", tokens[1])
+				continue
+			}
+			pb.breakAt = event
+			return -1, nil
+
+		case "diff":
+			// "diff" -> print the round state fields changed by the last message
+
+			if len(pb.diff) == 0 {
+				fmt.Fprintln(pb.out, "no changes")
+			}
+			for _, c := range pb.diff {
+				fmt.Fprintf(pb.out, "%s: %s -> %s\n", c.name, c.from, c.to)
+			}
+
 		case "rs":
 			// "rs" -> print entire round state
 			// "rs short" -> print height/round/step
@@ -252,32 +353,102 @@ func (pb *playback) replayConsoleLoop() int {
 
 			rs := pb.cs.RoundState
 			if len(tokens) == 1 {
-				fmt.Println(rs)
+				fmt.Fprintln(pb.out, rs)
 			} else {
 				switch tokens[1] {
 				case "short":
-					fmt.Printf("%v/%v/%v\n", rs.Height, rs.Round, rs.Step)
+					fmt.Fprintf(pb.out, "%v/%v/%v\n", rs.Height, rs.Round, rs.Step)
 				case "validators":
-					fmt.Println(rs.Validators)
+					fmt.Fprintln(pb.out, rs.Validators)
 				case "proposal":
-					fmt.Println(rs.Proposal)
+					fmt.Fprintln(pb.out, rs.Proposal)
 				case "proposal_block":
-					fmt.Printf("%v %v\n", rs.ProposalBlockParts.StringShort(), rs.ProposalBlock.StringShort())
+					fmt.Fprintf(pb.out, "%v %v\n", rs.ProposalBlockParts.StringShort(), rs.ProposalBlock.StringShort())
 				case "locked_round":
-					fmt.Println(rs.LockedRound)
+					fmt.Fprintln(pb.out, rs.LockedRound)
 				case "locked_block":
-					fmt.Printf("%v %v\n", rs.LockedBlockParts.StringShort(), rs.LockedBlock.StringShort())
+					fmt.Fprintf(pb.out, "%v %v\n", rs.LockedBlockParts.StringShort(), rs.LockedBlock.StringShort())
 				case "votes":
-					fmt.Println(rs.Votes.StringIndented("  "))
+					fmt.Fprintln(pb.out, rs.Votes.StringIndented("  "))
 
 				default:
-					fmt.Println("Unknown option", tokens[1])
+					fmt.Fprintln(pb.out, "Unknown option", tokens[1])
 				}
 			}
 		case "n":
-			fmt.Println(pb.count)
+			fmt.Fprintln(pb.out, pb.count)
+		}
+	}
+}
+
+// replayBreakpoints are the events the console can break at, by the field of
+// the RoundState they change and the test of its new value.
+var replayBreakpoints = map[string]struct {
+	field string
+	hit   func(value string) bool
+}{
+	"polka":  {"polka", func(v string) bool { return v != "" }},
+	"lock":   {"locked_round", func(v string) bool { return v != "-1" }},
+	"commit": {"height", func(string) bool { return true }},
+}
+
+// roundStateField is a field of the RoundState, as shown by the "diff"
+// command.
+type roundStateField struct {
+	name  string
+	value string
+}
+
+// roundStateChange is a change of a field of the RoundState.
+type roundStateChange struct {
+	name     string
+	from, to string
+}
+
+// roundStateFields returns the fields of rs compared by the "diff" command.
+// Blocks are shown by their hash, and votes by the bit arrays of the current
+// round.
+func roundStateFields(rs *cstypes.RoundState) []roundStateField {
+	var proposal, polka string
+	if rs.Proposal != nil {
+		proposal = rs.Proposal.String()
+	}
+	prevotes, precommits := rs.Votes.Prevotes(rs.Round), rs.Votes.Precommits(rs.Round)
+	if blockID, ok := prevotes.TwoThirdsMajority(); ok {
+		polka = "nil"
+		if !blockID.IsZero() {
+			polka = blockID.String()
+		}
+	}
+	return []roundStateField{
+		{"height", fmt.Sprint(rs.Height)},
+		{"round", fmt.Sprint(rs.Round)},
+		{"step", rs.Step.String()},
+		{"start_time", rs.StartTime.String()},
+		{"commit_time", rs.CommitTime.String()},
+		{"proposal", proposal},
+		{"proposal_block", fmt.Sprintf("%X", rs.ProposalBlock.Hash())},
+		{"locked_round", fmt.Sprint(rs.LockedRound)},
+		{"locked_block", fmt.Sprintf("%X", rs.LockedBlock.Hash())},
+		{"valid_round", fmt.Sprint(rs.ValidRound)},
+		{"valid_block", fmt.Sprintf("%X", rs.ValidBlock.Hash())},
+		{"prevotes", prevotes.BitArray().String()},
+		{"precommits", precommits.BitArray().String()},
+		{"polka", polka},
+		{"commit_round", fmt.Sprint(rs.CommitRound)},
+		{"last_commit", rs.LastCommit.BitArray().String()},
+		{"triggered_timeout_precommit", fmt.Sprint(rs.TriggeredTimeoutPrecommit)},
+	}
+}
+
+func diffRoundStateFields(before, after []roundStateField) []roundStateChange {
+	var changes []roundStateChange
+	for i, f := range after {
+		if before[i].value != f.value {
+			changes = append(changes, roundStateChange{name: f.name, from: before[i].value, to: f.value})
 		}
 	}
+	return changes
 }
 
 //--------------------------------------------------------------------------------
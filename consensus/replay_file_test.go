@@ -0,0 +1,110 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// newStateForReplayTest returns a State replaying the WAL generated by
+// WALGenerateNBlocks, which uses the same genesis and validator.
+func newStateForReplayTest(t *testing.T) *State {
+	config := cfg.ResetTestRoot(t.Name())
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	state.Version.Consensus.App = kvstore.ProtocolVersion
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+	require.NoError(t, stateStore.Save(state))
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	app := kvstore.NewPersistentKVStoreApplication(filepath.Join(config.DBDir(), "replay"))
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { require.NoError(t, proxyApp.Stop()) })
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { require.NoError(t, eventBus.Stop()) })
+
+	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mempool, evpool)
+	cs := NewState(config.Consensus, state, blockExec, blockStore, mempool, evpool)
+	cs.SetLogger(log.TestingLogger())
+	cs.SetEventBus(eventBus)
+	return cs
+}
+
+func writeWALWithNBlocks(t *testing.T, numBlocks int) string {
+	walFile := filepath.Join(t.TempDir(), "wal")
+	fp, err := os.Create(walFile)
+	require.NoError(t, err)
+	defer fp.Close()
+
+	wr := bufio.NewWriter(fp)
+	require.NoError(t, WALGenerateNBlocks(t, wr, numBlocks))
+	require.NoError(t, wr.Flush())
+	return walFile
+}
+
+func TestReplayFile(t *testing.T) {
+	walFile := writeWALWithNBlocks(t, 3)
+
+	cs := newStateForReplayTest(t)
+	require.NoError(t, cs.ReplayFile(walFile, false))
+	assert.EqualValues(t, 3, cs.blockStore.Height())
+}
+
+func TestReplayConsoleScript(t *testing.T) {
+	walFile := writeWALWithNBlocks(t, 3)
+
+	script := strings.Join([]string{
+		"# stop at the first polka",
+		"break polka",
+		"diff",
+		"break lock",
+		"rs locked_round",
+		"break commit",
+		"rs short",
+		"break foo",
+		"next 2",
+		"n",
+	}, "\n")
+	var out bytes.Buffer
+	cs := newStateForReplayTest(t)
+	require.NoError(t, cs.replayFile(walFile, true, strings.NewReader(script), &out, true))
+
+	output := out.String()
+	assert.Contains(t, output, "> # stop at the first polka\n")
+	assert.Contains(t, output, "Break at polka: 1/0/RoundStepPrecommit")
+	assert.Contains(t, output, "\nlocked_round: -1 -> 0\n")
+	assert.Contains(t, output, "\npolka:  -> ")
+	// the first lock came with the polka
+	assert.Contains(t, output, "Break at lock: 2/0/RoundStepPrecommit")
+	assert.Contains(t, output, "> rs locked_round\n0\n")
+	assert.Contains(t, output, "Break at commit: 3/0/")
+	assert.Contains(t, output, "> rs short\n3/0/")
+	assert.Contains(t, output, "Unknown event foo")
+
+	// the replay ends with the script
+	assert.EqualValues(t, 2, cs.blockStore.Height())
+}
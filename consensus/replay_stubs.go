@@ -27,8 +27,9 @@ func (txmp emptyMempool) RemoveTxByKey(txKey types.TxKey) error {
 	return nil
 }
 
-func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
-func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs     { return types.Txs{} }
+func (emptyMempool) ReapMaxTxs(n int) types.Txs                  { return types.Txs{} }
+func (emptyMempool) ListTxs(_, _ int, _ mempl.TxOrder) types.Txs { return types.Txs{} }
 func (emptyMempool) Update(
 	_ int64,
 	_ types.Txs,
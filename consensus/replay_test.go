@@ -99,7 +99,7 @@ func startNewStateAndWaitForBlock(t *testing.T, consensusReplayConfig *cfg.Confi
 	case <-newBlockSub.Out():
 	case <-newBlockSub.Cancelled():
 		t.Fatal("newBlockSub was cancelled")
-	case <-time.After(120 * time.Second):
+	case <-testDeadline(t):
 		t.Fatal("Timed out waiting for new block (see trace above)")
 	}
 }
@@ -155,6 +155,7 @@ func crashWALandCheckLiveness(t *testing.T, consensusReplayConfig *cfg.Config,
 	walPanicked := make(chan error)
 	crashingWal := &crashingWAL{panicCh: walPanicked, heightToStop: heightToStop}
 
+	deadline := testDeadline(t)
 	i := 1
 LOOP:
 	for {
@@ -217,8 +218,8 @@ LOOP:
 			if _, ok := err.(ReachedHeightToStopError); ok {
 				break LOOP
 			}
-		case <-time.After(10 * time.Second):
-			t.Fatal("WAL did not panic for 10 seconds (check the log)")
+		case <-deadline:
+			t.Fatal("WAL did not panic (check the log)")
 		}
 	}
 }
@@ -962,6 +963,56 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 	}
 }
 
+func TestHandshakeReportsAppVersionMismatch(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	defer os.RemoveAll(config.RootDir)
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	const appVersion = 0x1
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, appVersion)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	state.LastValidators = state.Validators.Copy()
+	store.chain = makeBlocks(3, &state, privVal)
+
+	testCases := []struct {
+		name       string
+		appVersion uint64
+		reported   bool
+	}{
+		{"same version", appVersion, false},
+		{"other version", appVersion + 1, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// the app is in sync with the state, but may run another protocol
+			app := &versionApp{height: state.LastBlockHeight, appHash: state.AppHash, appVersion: tc.appVersion}
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			var buf bytes.Buffer
+			h := NewHandshaker(stateStore, state, store, genDoc)
+			h.SetLogger(log.NewTMLogger(log.NewSyncWriter(&buf)))
+			// the mismatch doesn't fail the handshake
+			require.NoError(t, h.Handshake(proxyApp))
+			if tc.reported {
+				assert.Contains(t, buf.String(), "App protocol version differs from the one of the state")
+			} else {
+				assert.NotContains(t, buf.String(), "App protocol version differs")
+			}
+		})
+	}
+}
+
 func makeBlocks(n int, state *sm.State, privVal types.PrivValidator) []*types.Block {
 	blocks := make([]*types.Block, 0)
 
@@ -1008,6 +1059,22 @@ func makeBlock(state sm.State, lastBlock *types.Block, lastBlockMeta *types.Bloc
 	return state.MakeBlock(height, []types.Tx{}, lastCommit, nil, state.Validators.GetProposer().Address)
 }
 
+// versionApp reports the given last block and protocol version.
+type versionApp struct {
+	abci.BaseApplication
+	height     int64
+	appHash    []byte
+	appVersion uint64
+}
+
+func (app *versionApp) Info(abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{
+		LastBlockHeight:  app.height,
+		LastBlockAppHash: app.appHash,
+		AppVersion:       app.appVersion,
+	}
+}
+
 type badApp struct {
 	abci.BaseApplication
 	numBlocks           byte
@@ -0,0 +1,400 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/clock"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// simulation runs a network of States in the test goroutine. Messages are
+// exchanged through an in-memory queue and timeouts fire on a virtual clock,
+// which only moves when no message is in flight. Given the same genesis and
+// keys, a simulation therefore always takes the same steps, which makes it
+// possible to set up round-skipping, locking and unlocking scenarios
+// precisely, without real timers.
+type simulation struct {
+	t     *testing.T
+	clock *clock.Mock
+	nodes []*simNode
+
+	queue    []simMsg // in flight, delivered in order
+	deferred []simMsg // proposals for a round the receiver hasn't reached
+
+	// filter decides whether a message is delivered. Messages it rejects are
+	// dropped. A nil filter delivers everything.
+	filter func(m simMsg) bool
+
+	steps int
+}
+
+type simNode struct {
+	cs      *State
+	ticker  *simTicker
+	address types.Address
+}
+
+// simMsg is a message sent from one node to another.
+type simMsg struct {
+	from, to int
+	msg      Message
+}
+
+func newSimulation(t *testing.T, genDoc *types.GenesisDoc, privVals []types.PrivValidator) *simulation {
+	s := &simulation{
+		t:     t,
+		clock: clock.NewMock(genDoc.GenesisTime),
+		nodes: make([]*simNode, len(privVals)),
+	}
+	logger := consensusLogger()
+	for i, pv := range privVals {
+		stateDB := dbm.NewMemDB()
+		stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+		state, err := stateStore.LoadFromDBOrGenesisDoc(genDoc)
+		require.NoError(t, err)
+
+		thisConfig := ResetConfig(fmt.Sprintf("%s_%d", t.Name(), i))
+		t.Cleanup(func() { os.RemoveAll(thisConfig.RootDir) })
+		ensureDir(filepath.Dir(thisConfig.Consensus.WalFile()), 0o700)
+
+		app := kvstore.NewApplication()
+		app.InitChain(abci.RequestInitChain{Validators: types.TM2PB.ValidatorUpdates(state.Validators)})
+
+		cs := newStateWithConfigAndBlockStore(thisConfig, state, pv, app, stateDB, StateClock(s.clock))
+		ticker := &simTicker{clock: s.clock}
+		cs.SetTimeoutTicker(ticker)
+		cs.SetLogger(logger.With("validator", i, "module", "consensus"))
+
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		s.nodes[i] = &simNode{cs: cs, ticker: ticker, address: pubKey.Address()}
+	}
+	for _, n := range s.nodes {
+		n.cs.scheduleRound0(n.cs.GetRoundState())
+	}
+	return s
+}
+
+func simPeerID(i int) p2p.ID {
+	return p2p.ID(fmt.Sprintf("node%d", i))
+}
+
+// step performs the next action of the simulation: handling a message of a
+// node to itself, delivering a message in flight, or, if there is none,
+// advancing the clock to the earliest scheduled timeout and firing it. It
+// returns false if there was nothing to do.
+func (s *simulation) step() bool {
+	for i, n := range s.nodes {
+		select {
+		case mi := <-n.cs.internalMsgQueue:
+			s.handle(i, mi)
+			for j := range s.nodes {
+				if j != i {
+					s.send(simMsg{from: i, to: j, msg: mi.Msg})
+				}
+			}
+			return true
+		default:
+		}
+	}
+
+	// like the reactor, only send proposals to nodes in their round
+	for k, m := range s.deferred {
+		if !s.ahead(m) {
+			s.deferred = append(s.deferred[:k], s.deferred[k+1:]...)
+			s.handle(m.to, msgInfo{m.msg, simPeerID(m.from)})
+			return true
+		}
+	}
+
+	for len(s.queue) > 0 {
+		m := s.queue[0]
+		s.queue = s.queue[1:]
+		if isProposal(m.msg) && s.ahead(m) {
+			s.deferred = append(s.deferred, m)
+			continue
+		}
+		s.handle(m.to, msgInfo{m.msg, simPeerID(m.from)})
+		return true
+	}
+
+	var next *simNode
+	for _, n := range s.nodes {
+		if n.ticker.pending && (next == nil || n.ticker.deadline.Before(next.ticker.deadline)) {
+			next = n
+		}
+	}
+	if next == nil {
+		return false
+	}
+	if d := next.ticker.deadline.Sub(s.clock.Now()); d > 0 {
+		s.clock.Advance(d)
+	}
+	next.ticker.pending = false
+	s.steps++
+	next.cs.handleTimeout(next.ticker.ti, next.cs.RoundState)
+	return true
+}
+
+func (s *simulation) handle(i int, mi msgInfo) {
+	cs := s.nodes[i].cs
+	s.steps++
+	cs.handleMsg(mi)
+
+	// nobody reads the stats of peer messages here
+	for {
+		select {
+		case <-cs.statsMsgQueue:
+		default:
+			return
+		}
+	}
+}
+
+// ahead tells whether a message is for a later height or round than the one
+// its receiver is in.
+func (s *simulation) ahead(m simMsg) bool {
+	cs := s.nodes[m.to].cs
+	height, round := msgRound(m.msg)
+	return height > cs.Height || (height == cs.Height && round > cs.Round)
+}
+
+func (s *simulation) send(m simMsg) {
+	if s.filter == nil || s.filter(m) {
+		s.queue = append(s.queue, m)
+	}
+}
+
+// run steps the simulation until cond holds. It fails the test if that takes
+// more than maxSteps steps or if the simulation stalls.
+func (s *simulation) run(cond func() bool, maxSteps int) {
+	s.t.Helper()
+	for start := s.steps; !cond(); {
+		if s.steps-start >= maxSteps {
+			s.t.Fatalf("condition not reached after %d steps", maxSteps)
+		}
+		if !s.step() {
+			s.t.Fatal("simulation stalled")
+		}
+	}
+}
+
+// allAtHeight returns a condition which holds once every node has reached
+// height.
+func (s *simulation) allAtHeight(height int64) func() bool {
+	return func() bool {
+		for _, n := range s.nodes {
+			if n.cs.Height < height {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// proposer returns the index of the proposer of round at the current height
+// of the first node.
+func (s *simulation) proposer(round int32) int {
+	cs := s.nodes[0].cs
+	vals := cs.Validators.Copy()
+	if round > cs.Round {
+		vals.IncrementProposerPriority(round - cs.Round)
+	}
+	address := vals.GetProposer().Address
+	for i, n := range s.nodes {
+		if bytes.Equal(n.address, address) {
+			return i
+		}
+	}
+	s.t.Fatalf("no node with the address %v", address)
+	return -1
+}
+
+// msgRound returns the height and round a message belongs to.
+func msgRound(msg Message) (int64, int32) {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return msg.Proposal.Height, msg.Proposal.Round
+	case *BlockPartMessage:
+		return msg.Height, msg.Round
+	case *VoteMessage:
+		return msg.Vote.Height, msg.Vote.Round
+	}
+	return 0, 0
+}
+
+// isProposal tells whether a message is a proposal or one of its block parts.
+func isProposal(msg Message) bool {
+	switch msg.(type) {
+	case *ProposalMessage, *BlockPartMessage:
+		return true
+	}
+	return false
+}
+
+// isVote tells whether a message is a vote of the type.
+func isVote(msg Message, voteType tmproto.SignedMsgType) bool {
+	vote, ok := msg.(*VoteMessage)
+	return ok && vote.Vote.Type == voteType
+}
+
+// simTicker is a TimeoutTicker for simulations. It keeps the scheduled
+// timeout, replacing it by later ones like timeoutTicker does, and leaves it
+// to the simulation to fire it.
+type simTicker struct {
+	clock    *clock.Mock
+	ti       timeoutInfo
+	deadline time.Time
+	pending  bool
+}
+
+var _ TimeoutTicker = (*simTicker)(nil)
+
+func (t *simTicker) Start() error             { return nil }
+func (t *simTicker) Stop() error              { return nil }
+func (t *simTicker) Chan() <-chan timeoutInfo { return nil }
+func (t *simTicker) SetLogger(log.Logger)     {}
+
+func (t *simTicker) ScheduleTimeout(ti timeoutInfo) {
+	// ignore timeouts for old height/round/step
+	if ti.Height < t.ti.Height {
+		return
+	} else if ti.Height == t.ti.Height {
+		if ti.Round < t.ti.Round {
+			return
+		} else if ti.Round == t.ti.Round && t.ti.Step > 0 && ti.Step <= t.ti.Step {
+			return
+		}
+	}
+	t.ti = ti
+	t.deadline = t.clock.Now().Add(ti.Duration)
+	t.pending = true
+}
+
+//-------------------------------------------------------------------------------
+
+const simMaxSteps = 10000
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	genDoc, privVals := randGenesisDoc(4, false, 10)
+
+	blocks := func() [][]byte {
+		s := newSimulation(t, genDoc, privVals)
+		s.run(s.allAtHeight(4), simMaxSteps)
+
+		var hashes [][]byte
+		for height := int64(1); height < 4; height++ {
+			for _, n := range s.nodes {
+				require.Equal(t, int32(0), n.cs.blockStore.LoadSeenCommit(height).Round)
+			}
+			hashes = append(hashes, s.nodes[0].cs.blockStore.LoadBlockMeta(height).BlockID.Hash)
+		}
+		return hashes
+	}
+
+	assert.Equal(t, blocks(), blocks())
+}
+
+func TestSimulationRoundSkip(t *testing.T) {
+	genDoc, privVals := randGenesisDoc(4, false, 10)
+	s := newSimulation(t, genDoc, privVals)
+
+	// nobody gets the first proposal, and the lagging node gets nothing from
+	// round 0, so that it never sees +2/3 of its votes
+	lagging := 0
+	for lagging == s.proposer(0) || lagging == s.proposer(1) {
+		lagging++
+	}
+	s.filter = func(m simMsg) bool {
+		height, round := msgRound(m.msg)
+		if height != 1 || round != 0 {
+			return true
+		}
+		return !isProposal(m.msg) && m.to != lagging
+	}
+
+	// the lagging node skips to round 1 once it sees +2/3 prevotes of round 1
+	s.run(s.allAtHeight(2), simMaxSteps)
+	for _, n := range s.nodes {
+		assert.Equal(t, int32(1), n.cs.blockStore.LoadSeenCommit(1).Round)
+	}
+}
+
+// lockInRound0 makes the lock node, and only it, lock on the proposal of
+// round 0 at height 1: nilVoter doesn't get the proposal and prevotes nil,
+// and only lock sees the three prevotes for the proposal. It runs the
+// simulation until lock is locked and returns the hash of the locked block.
+func lockInRound0(s *simulation, lock, nilVoter int) []byte {
+	s.filter = func(m simMsg) bool {
+		height, round := msgRound(m.msg)
+		if height != 1 || round != 0 {
+			return true
+		}
+		if isProposal(m.msg) {
+			return m.to != nilVoter
+		}
+		return m.from != lock || !isVote(m.msg, tmproto.PrevoteType)
+	}
+
+	cs := s.nodes[lock].cs
+	s.run(func() bool { return cs.LockedRound == 0 }, simMaxSteps)
+	return cs.LockedBlock.Hash()
+}
+
+func TestSimulationLocking(t *testing.T) {
+	genDoc, privVals := randGenesisDoc(4, false, 10)
+	s := newSimulation(t, genDoc, privVals)
+
+	// the proposer of round 1 locks in round 0
+	lock, nilVoter := s.proposer(1), 0
+	for nilVoter == lock || nilVoter == s.proposer(0) {
+		nilVoter++
+	}
+	locked := lockInRound0(s, lock, nilVoter)
+
+	// so it proposes the locked block again, and it is committed
+	s.run(s.allAtHeight(2), simMaxSteps)
+	for _, n := range s.nodes {
+		assert.Equal(t, int32(1), n.cs.blockStore.LoadSeenCommit(1).Round)
+		assert.EqualValues(t, locked, n.cs.blockStore.LoadBlockMeta(1).BlockID.Hash)
+	}
+}
+
+func TestSimulationUnlockOnPOL(t *testing.T) {
+	genDoc, privVals := randGenesisDoc(4, false, 10)
+	s := newSimulation(t, genDoc, privVals)
+
+	// the two nodes which don't propose in rounds 0 and 1
+	var others []int
+	for i := range s.nodes {
+		if i != s.proposer(0) && i != s.proposer(1) {
+			others = append(others, i)
+		}
+	}
+	require.Len(t, others, 2)
+	lock := others[0]
+	locked := lockInRound0(s, lock, others[1])
+
+	// in round 1, lock prevotes its locked block, but the others prevote the
+	// new proposal, and the polka for it unlocks lock
+	s.run(s.allAtHeight(2), simMaxSteps)
+	for _, n := range s.nodes {
+		assert.Equal(t, int32(1), n.cs.blockStore.LoadSeenCommit(1).Round)
+		assert.NotEqualValues(t, locked, n.cs.blockStore.LoadBlockMeta(1).BlockID.Hash)
+	}
+}
@@ -11,10 +11,13 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	dbm "github.com/tendermint/tm-db"
 
 	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto"
+	auto "github.com/tendermint/tendermint/libs/autofile"
+	"github.com/tendermint/tendermint/libs/clock"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/libs/fail"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -40,8 +43,17 @@ var (
 	errPubKeyIsNotSet = errors.New("pubkey is not set. Look for \"Can't get private validator pubkey\" errors")
 )
 
+// msgQueueSize is the size of the message queues when the config sets them to
+// 0.
 var msgQueueSize = 1000
 
+func queueSize(size int) int {
+	if size == 0 {
+		return msgQueueSize
+	}
+	return size
+}
+
 // msgs from the reactor which may update the state
 type msgInfo struct {
 	Msg    Message `json:"msg"`
@@ -103,12 +115,20 @@ type State struct {
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
 
+	// copy of state.Validators, guarded by its own mutex so that IsValidator
+	// doesn't wait for cs.mtx
+	validatorsMtx tmsync.RWMutex
+	validators    *types.ValidatorSet
+
 	// state changes may be triggered by: msgs from peers,
 	// msgs from ourself, or by timeouts
 	peerMsgQueue     chan msgInfo
 	internalMsgQueue chan msgInfo
 	timeoutTicker    TimeoutTicker
 
+	// source of the current time and of timers, replaceable in tests
+	clock clock.Clock
+
 	// information about about added votes and block parts are written on this channel
 	// so statistics can be computed by reactor
 	statsMsgQueue chan msgInfo
@@ -140,6 +160,21 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// verified vote signatures, shared across heights and with blockExec,
+	// which finds the precommits in the LastCommit of the next block there
+	// (nil if disabled)
+	sigCache *types.SignatureCache
+
+	// proposal block parts whose header is invalid, which we stop collecting
+	rejectedBlockParts *types.PartSet
+
+	// where proposal block parts spill beyond config.BlockPartsMemoryBudget
+	// (nil if disabled)
+	partSpillDB dbm.DB
+
+	// when the last block was committed, for the liveness alarm
+	liveness livenessMonitor
 }
 
 // StateOption sets an optional parameter on the State.
@@ -160,9 +195,10 @@ func NewState(
 		blockExec:        blockExec,
 		blockStore:       blockStore,
 		txNotifier:       txNotifier,
-		peerMsgQueue:     make(chan msgInfo, msgQueueSize),
-		internalMsgQueue: make(chan msgInfo, msgQueueSize),
+		peerMsgQueue:     make(chan msgInfo, queueSize(config.PeerMsgQueueSize)),
+		internalMsgQueue: make(chan msgInfo, queueSize(config.InternalMsgQueueSize)),
 		timeoutTicker:    NewTimeoutTicker(),
+		clock:            clock.System,
 		statsMsgQueue:    make(chan msgInfo, msgQueueSize),
 		done:             make(chan struct{}),
 		doWALCatchup:     true,
@@ -170,13 +206,26 @@ func NewState(
 		evpool:           evpool,
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		sigCache:         blockExec.SignatureCache(),
 	}
+	cs.sigCache.SetObserver(func(hit bool) {
+		if hit {
+			cs.metrics.VoteSignatureCacheHits.Add(1)
+		} else {
+			cs.metrics.VoteSignatureCacheMisses.Add(1)
+		}
+	})
 
 	// set function defaults (may be overwritten before calling Start)
 	cs.decideProposal = cs.defaultDecideProposal
 	cs.doPrevote = cs.defaultDoPrevote
 	cs.setProposal = cs.defaultSetProposal
 
+	// apply options before updateToState, which reads the clock
+	for _, option := range options {
+		option(cs)
+	}
+
 	// We have no votes, so reconstruct LastCommit from SeenCommit.
 	if state.LastBlockHeight > 0 {
 		cs.reconstructLastCommit(state)
@@ -187,9 +236,6 @@ func NewState(
 	// NOTE: we do not call scheduleRound0 yet, we do that upon Start()
 
 	cs.BaseService = *service.NewBaseService(nil, "State", cs)
-	for _, option := range options {
-		option(cs)
-	}
 
 	return cs
 }
@@ -211,6 +257,26 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateBlockPartSpill sets the DB the proposal block parts received beyond
+// config.BlockPartsMemoryBudget are saved to.
+func StateBlockPartSpill(db dbm.DB) StateOption {
+	return func(cs *State) { cs.partSpillDB = db }
+}
+
+// StateClock sets the clock used for timeouts, timestamps and start times,
+// so tests can control the passage of time.
+func StateClock(c clock.Clock) StateOption {
+	return func(cs *State) {
+		cs.clock = c
+		cs.timeoutTicker = newTimeoutTicker(c)
+	}
+}
+
+// now returns the current time of the clock in canonical form.
+func (cs *State) now() time.Time {
+	return tmtime.Canonical(cs.clock.Now())
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -261,6 +327,15 @@ func (cs *State) GetValidators() (int64, []*types.Validator) {
 	return cs.state.LastBlockHeight, cs.state.Validators.Copy().Validators
 }
 
+// IsValidator returns true if the given address is in the current validator
+// set. Unlike GetValidators, it doesn't take the consensus mutex, so it is
+// cheap enough to be called for every peer.
+func (cs *State) IsValidator(address crypto.Address) bool {
+	cs.validatorsMtx.RLock()
+	defer cs.validatorsMtx.RUnlock()
+	return cs.validators != nil && cs.validators.HasAddress(address)
+}
+
 // SetPrivValidator sets the private validator account for signing votes. It
 // immediately requests pubkey and caches it.
 func (cs *State) SetPrivValidator(priv types.PrivValidator) {
@@ -378,6 +453,11 @@ func (cs *State) OnStart() error {
 	// now start the receiveRoutine
 	go cs.receiveRoutine(0)
 
+	if maxInterval := cs.config.MaxBlockInterval(); maxInterval > 0 {
+		cs.liveness.blockCommitted(cs.Height-1, cs.now())
+		go cs.livenessRoutine(maxInterval)
+	}
+
 	// schedule the first round!
 	// use GetRoundState so we don't race the receiveRoutine for access
 	cs.scheduleRound0(cs.GetRoundState())
@@ -431,13 +511,14 @@ func (cs *State) Wait() {
 // OpenWAL opens a file to log all consensus messages and timeouts for
 // deterministic accountability.
 func (cs *State) OpenWAL(walFile string) (WAL, error) {
-	wal, err := NewWAL(walFile)
+	wal, err := NewWAL(walFile, auto.GroupTotalSizeLimit(cs.config.WalMaxSize))
 	if err != nil {
 		cs.Logger.Error("failed to open WAL", "file", walFile, "err", err)
 		return nil, err
 	}
 
 	wal.SetLogger(cs.Logger.With("wal", walFile))
+	wal.SetClock(cs.clock)
 
 	if err := wal.Start(); err != nil {
 		cs.Logger.Error("failed to start WAL", "err", err)
@@ -451,7 +532,8 @@ func (cs *State) OpenWAL(walFile string) (WAL, error) {
 // Public interface for passing messages into the consensus state, possibly causing a state transition.
 // If peerID == "", the msg is considered internal.
 // Messages are added to the appropriate queue (peer or internal).
-// If the queue is full, the function may block.
+// If the queue is full, the function may block, unless the config asks for
+// peer messages to be dropped instead.
 // TODO: should these return anything or let callers just use events?
 
 // AddVote inputs a vote.
@@ -459,7 +541,7 @@ func (cs *State) AddVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 	if peerID == "" {
 		cs.internalMsgQueue <- msgInfo{&VoteMessage{vote}, ""}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}
+		cs.queuePeerMsg(msgInfo{&VoteMessage{vote}, peerID})
 	}
 
 	// TODO: wait for event?!
@@ -471,7 +553,7 @@ func (cs *State) SetProposal(proposal *types.Proposal, peerID p2p.ID) error {
 	if peerID == "" {
 		cs.internalMsgQueue <- msgInfo{&ProposalMessage{proposal}, ""}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&ProposalMessage{proposal}, peerID}
+		cs.queuePeerMsg(msgInfo{&ProposalMessage{proposal}, peerID})
 	}
 
 	// TODO: wait for event?!
@@ -483,7 +565,7 @@ func (cs *State) AddProposalBlockPart(height int64, round int32, part *types.Par
 	if peerID == "" {
 		cs.internalMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, ""}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, peerID}
+		cs.queuePeerMsg(msgInfo{&BlockPartMessage{height, round, part}, peerID})
 	}
 
 	// TODO: wait for event?!
@@ -526,8 +608,8 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
-	// cs.Logger.Info("scheduleRound0", "now", tmtime.Now(), "startTime", cs.StartTime)
-	sleepDuration := rs.StartTime.Sub(tmtime.Now())
+	// cs.Logger.Info("scheduleRound0", "now", cs.now(), "startTime", cs.StartTime)
+	sleepDuration := rs.StartTime.Sub(cs.now())
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
@@ -536,6 +618,22 @@ func (cs *State) scheduleTimeout(duration time.Duration, height int64, round int
 	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
 }
 
+// queuePeerMsg sends a msg received from a peer into the receiveRoutine. If
+// the peer queue is full, it blocks, or drops the msg if the config says so.
+func (cs *State) queuePeerMsg(mi msgInfo) {
+	if !cs.config.DropPeerMsgsWhenFull {
+		cs.peerMsgQueue <- mi
+		return
+	}
+
+	select {
+	case cs.peerMsgQueue <- mi:
+	default:
+		cs.metrics.DroppedPeerMessages.Add(1)
+		cs.Logger.Debug("peer msg queue is full; dropping msg", "peer", mi.PeerID, "msg", mi.Msg)
+	}
+}
+
 // send a msg into the receiveRoutine regarding our own proposal, block part, or vote
 func (cs *State) sendInternalMessage(mi msgInfo) {
 	select {
@@ -652,11 +750,18 @@ func (cs *State) updateToState(state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(tmtime.Now())
+		cs.StartTime = cs.config.Commit(cs.now())
 	} else {
 		cs.StartTime = cs.config.Commit(cs.CommitTime)
 	}
 
+	if cs.partSpillDB != nil {
+		// the parts of the previous height may still be read by the reactor
+		if err := prunePartSpill(cs.partSpillDB, height-1); err != nil {
+			cs.Logger.Error("failed to prune spilled block parts", "err", err)
+		}
+	}
+
 	cs.Validators = validators
 	cs.Proposal = nil
 	cs.ProposalBlock = nil
@@ -668,12 +773,19 @@ func (cs *State) updateToState(state sm.State) {
 	cs.ValidBlock = nil
 	cs.ValidBlockParts = nil
 	cs.Votes = cstypes.NewHeightVoteSet(state.ChainID, height, validators)
+	cs.Votes.SetSignatureCache(cs.sigCache)
 	cs.CommitRound = -1
 	cs.LastValidators = state.LastValidators
 	cs.TriggeredTimeoutPrecommit = false
 
 	cs.state = state
 
+	// a copy, which is read without cs.mtx
+	vals := validators.Copy()
+	cs.validatorsMtx.Lock()
+	cs.validators = vals
+	cs.validatorsMtx.Unlock()
+
 	// Finally, broadcast RoundState
 	cs.newStep()
 }
@@ -746,6 +858,9 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		rs := cs.RoundState
 		var mi msgInfo
 
+		cs.metrics.MessageQueueSize.With("queue", "peer").Set(float64(len(cs.peerMsgQueue)))
+		cs.metrics.MessageQueueSize.With("queue", "internal").Set(float64(len(cs.internalMsgQueue)))
+
 		select {
 		case <-cs.txNotifier.TxsAvailable():
 			cs.handleTxsAvailable()
@@ -953,7 +1068,7 @@ func (cs *State) handleTxsAvailable() {
 		}
 
 		// +1ms to ensure RoundStepNewRound timeout always happens after RoundStepNewHeight
-		timeoutCommit := cs.StartTime.Sub(tmtime.Now()) + 1*time.Millisecond
+		timeoutCommit := cs.StartTime.Sub(cs.now()) + 1*time.Millisecond
 		cs.scheduleTimeout(timeoutCommit, cs.Height, 0, cstypes.RoundStepNewRound)
 
 	case cstypes.RoundStepNewRound: // after timeoutCommit
@@ -984,7 +1099,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 		return
 	}
 
-	if now := tmtime.Now(); cs.StartTime.After(now) {
+	if now := cs.now(); cs.StartTime.After(now) {
 		logger.Debug("need to set a buffer and log message here for sanity", "start_time", cs.StartTime, "now", now)
 	}
 
@@ -1121,6 +1236,28 @@ func (cs *State) isProposer(address []byte) bool {
 	return bytes.Equal(cs.Validators.GetProposer().Address, address)
 }
 
+// isExpectedProposer returns true if address is the proposer of the round the
+// proposed block was created in. A block re-proposed with a POL may have been
+// created in any round up to the POL round, otherwise it was created in the
+// proposal round.
+func (cs *State) isExpectedProposer(address []byte, proposal *types.Proposal) bool {
+	first, last := proposal.Round, proposal.Round
+	if proposal.POLRound >= 0 {
+		first, last = 0, proposal.POLRound
+	}
+
+	vals := cs.state.Validators.Copy()
+	for r := int32(0); r <= last; r++ {
+		if r > 0 {
+			vals.IncrementProposerPriority(1)
+		}
+		if r >= first && bytes.Equal(vals.GetProposer().Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
 func (cs *State) defaultDecideProposal(height int64, round int32) {
 	var block *types.Block
 	var blockParts *types.PartSet
@@ -1275,6 +1412,14 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// The block must name the validator that proposed it.
+	if cs.Proposal != nil && !cs.isExpectedProposer(cs.ProposalBlock.ProposerAddress, cs.Proposal) {
+		logger.Error("prevote step: ProposalBlock has unexpected proposer",
+			"proposer", cs.ProposalBlock.ProposerAddress)
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1430,7 +1575,7 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 	if !cs.ProposalBlockParts.HasHeader(blockID.PartSetHeader) {
 		cs.ProposalBlock = nil
-		cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+		cs.ProposalBlockParts = cs.newProposalBlockParts(blockID.PartSetHeader)
 	}
 
 	if err := cs.eventBus.PublishEventUnlock(cs.RoundStateEvent()); err != nil {
@@ -1491,7 +1636,7 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 		// keep cs.Round the same, commitRound points to the right Precommits set.
 		cs.updateRoundStep(cs.Round, cstypes.RoundStepCommit)
 		cs.CommitRound = commitRound
-		cs.CommitTime = tmtime.Now()
+		cs.CommitTime = cs.now()
 		cs.newStep()
 
 		// Maybe finalize immediately.
@@ -1524,7 +1669,7 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 			// We're getting the wrong block.
 			// Set up ProposalBlockParts and keep waiting.
 			cs.ProposalBlock = nil
-			cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+			cs.ProposalBlockParts = cs.newProposalBlockParts(blockID.PartSetHeader)
 
 			if err := cs.eventBus.PublishEventValidBlock(cs.RoundStateEvent()); err != nil {
 				logger.Error("failed publishing valid block", "err", err)
@@ -1679,6 +1824,8 @@ func (cs *State) finalizeCommit(height int64) {
 	// must be called before we update state
 	cs.recordMetrics(height, block)
 
+	cs.liveness.blockCommitted(height, cs.now())
+
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
 
@@ -1837,7 +1984,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
 	if cs.ProposalBlockParts == nil {
-		cs.ProposalBlockParts = types.NewPartSetFromHeader(proposal.BlockID.PartSetHeader)
+		cs.ProposalBlockParts = cs.newProposalBlockParts(proposal.BlockID.PartSetHeader)
 	}
 
 	cs.Logger.Info("received proposal", "proposal", proposal)
@@ -1857,7 +2004,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 	}
 
 	// We're not expecting a block part.
-	if cs.ProposalBlockParts == nil {
+	if cs.ProposalBlockParts == nil || cs.ProposalBlockParts == cs.rejectedBlockParts {
 		// NOTE: this can happen when we've gone to a higher round and
 		// then receive parts from the previous round - not necessarily a bad peer.
 		cs.Logger.Debug(
@@ -1879,6 +2026,14 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 			cs.ProposalBlockParts.ByteSize(), cs.state.ConsensusParams.Block.MaxBytes,
 		)
 	}
+	// The header is in the first part: check it before the rest of the block
+	// arrives, and stop collecting the parts of a block we would reject anyway.
+	if added && part.Index == 0 && !cs.ProposalBlockParts.IsComplete() {
+		if err := cs.checkProposalBlockHeader(); err != nil {
+			cs.rejectedBlockParts = cs.ProposalBlockParts
+			return added, fmt.Errorf("rejecting proposal block parts: %w", err)
+		}
+	}
 	if added && cs.ProposalBlockParts.IsComplete() {
 		bz, err := io.ReadAll(cs.ProposalBlockParts.GetReader())
 		if err != nil {
@@ -1908,6 +2063,43 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 	return added, nil
 }
 
+// checkProposalBlockHeader checks the header of the proposal block, decoded
+// from the parts received so far, if they include it.
+func (cs *State) checkProposalBlockHeader() error {
+	r, ok := cs.ProposalBlockParts.GetPrefixReader()
+	if !ok {
+		return nil
+	}
+	header, ok, err := types.HeaderFromBlockPrefix(r)
+	if err != nil || !ok {
+		return err
+	}
+
+	if header.ChainID != cs.state.ChainID {
+		return fmt.Errorf("wrong chain ID %q, expected %q", header.ChainID, cs.state.ChainID)
+	}
+	if header.Height != cs.Height {
+		return fmt.Errorf("wrong height %d, expected %d", header.Height, cs.Height)
+	}
+	// a block of an earlier round may be proposed again, so its proposer is
+	// not necessarily the one of this round
+	if !cs.Validators.HasAddress(header.ProposerAddress) {
+		return fmt.Errorf("proposer %X is not a validator", header.ProposerAddress)
+	}
+	return nil
+}
+
+// newProposalBlockParts returns an empty PartSet for the parts of a proposal
+// block of the current height, which spills the parts beyond the memory budget
+// if configured.
+func (cs *State) newProposalBlockParts(header types.PartSetHeader) *types.PartSet {
+	parts := types.NewPartSetFromHeader(header)
+	if cs.partSpillDB != nil && cs.config.BlockPartsMemoryBudget > 0 {
+		parts.SetSpill(&partSpill{db: cs.partSpillDB, height: cs.Height}, cs.config.BlockPartsMemoryBudget)
+	}
+	return parts
+}
+
 func (cs *State) handleCompleteProposal(blockHeight int64) {
 	// Update Valid* if we can.
 	prevotes := cs.Votes.Prevotes(cs.Round)
@@ -1969,10 +2161,15 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 
 			// report conflicting votes to the evidence pool
 			cs.evpool.ReportConflictingVotes(voteErr.VoteA, voteErr.VoteB)
-			cs.Logger.Debug(
+			// the peers only relayed the votes, which is not misbehaviour, but
+			// knowing them helps to trace where the votes came from
+			peerA, _ := cs.Votes.VotePeer(voteErr.VoteA)
+			cs.Logger.Info(
 				"found and sent conflicting votes to the evidence pool",
 				"vote_a", voteErr.VoteA,
+				"peer_a", peerA,
 				"vote_b", voteErr.VoteB,
+				"peer_b", peerID,
 			)
 
 			return added, err
@@ -2100,7 +2297,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 				}
 
 				if !cs.ProposalBlockParts.HasHeader(blockID.PartSetHeader) {
-					cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+					cs.ProposalBlockParts = cs.newProposalBlockParts(blockID.PartSetHeader)
 				}
 
 				cs.evsw.FireEvent(types.EventValidBlock, &cs.RoundState)
@@ -2204,7 +2401,7 @@ func (cs *State) signVote(
 }
 
 func (cs *State) voteTime() time.Time {
-	now := tmtime.Now()
+	now := cs.now()
 	minVoteTime := now
 	// TODO: We should remove next line in case we don't vote for v in case cs.ProposalBlock == nil,
 	// even if cs.LockedBlock != nil. See https://github.com/tendermint/tendermint/tree/v0.34.x/spec/.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -11,14 +12,20 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/counter"
+	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/p2p"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 /*
@@ -135,6 +142,8 @@ func TestStateProposerSelection2(t *testing.T) {
 func TestStateEnterProposeNoPrivValidator(t *testing.T) {
 	cs, _ := randState(1)
 	cs.SetPrivValidator(nil)
+	clk := clock.NewMock(tmtime.Now())
+	StateClock(clk)(cs)
 	height, round := cs.Height, cs.Round
 
 	// Listen for propose timeout event
@@ -142,7 +151,13 @@ func TestStateEnterProposeNoPrivValidator(t *testing.T) {
 
 	startTestRound(cs, height, round)
 
+	// wait for the propose timeout to be scheduled
+	require.Eventually(t, func() bool { return clk.NumTimers() == 1 }, time.Second, time.Millisecond)
+	clk.Advance(cs.config.TimeoutPropose - time.Nanosecond)
+	ensureNoNewTimeout(timeoutCh, cs.config.TimeoutPropose.Nanoseconds())
+
 	// if we're not a validator, EnterPropose should timeout
+	clk.Advance(time.Nanosecond)
 	ensureNewTimeout(timeoutCh, height, round, cs.config.TimeoutPropose.Nanoseconds())
 
 	if cs.GetRoundState().Proposal != nil {
@@ -239,6 +254,44 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+func TestStateWrongProposerAddress(t *testing.T) {
+	cs1, vss := randState(2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	// the block names cs1 as its proposer
+	propBlock, propBlockParts := cs1.createProposalBlock()
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	if err := vs2.SignProposal(config.ChainID(), p); err != nil {
+		t.Fatal("failed to sign proposal", err)
+	}
+	proposal.Signature = p.Signature
+
+	if err := cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"); err != nil {
+		t.Fatal(err)
+	}
+
+	// start the machine
+	startTestRound(cs1, height, round)
+
+	// wait for proposal
+	ensureProposal(proposalCh, height, round, blockID)
+
+	// the block is otherwise valid, but we prevote nil
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	cs1, vss := randState(2)
 	cs1.state.ConsensusParams.Block.MaxBytes = 2000
@@ -297,6 +350,53 @@ func TestStateOversizedBlock(t *testing.T) {
 	signAddVotes(cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+// The parts of a proposal block with an invalid header are rejected as soon
+// as the header is received, rather than once the block is complete.
+func TestStateRejectsBlockPartsWithInvalidHeader(t *testing.T) {
+	cs1, _ := randState(1)
+	cs1.mtx.Lock()
+	defer cs1.mtx.Unlock()
+
+	testCases := []struct {
+		name          string
+		malleateBlock func(block *types.Block)
+		expectErr     bool
+	}{
+		{"valid", func(block *types.Block) {}, false},
+		{"wrong chain ID", func(block *types.Block) { block.ChainID = "not-the-real-one" }, true},
+		{"wrong height", func(block *types.Block) { block.Height++ }, true},
+		{"unknown proposer", func(block *types.Block) { block.ProposerAddress = tmrand.Bytes(crypto.AddressSize) }, true},
+	}
+	for _, tc := range testCases {
+		block, _ := cs1.createProposalBlock()
+		block.Data.Txs = []types.Tx{tmrand.Bytes(2000)}
+		block.Header.DataHash = block.Data.Hash()
+		tc.malleateBlock(block)
+		parts := block.MakePartSet(512)
+		require.Greater(t, parts.Total(), uint32(2))
+		cs1.ProposalBlockParts = types.NewPartSetFromHeader(parts.Header())
+
+		msg := &BlockPartMessage{Height: cs1.Height, Round: cs1.Round, Part: parts.GetPart(0)}
+		added, err := cs1.addProposalBlockPart(msg, "peer")
+		assert.True(t, added, tc.name)
+		if !tc.expectErr {
+			require.NoError(t, err, tc.name)
+			msg.Part = parts.GetPart(1)
+			added, err = cs1.addProposalBlockPart(msg, "peer")
+			assert.True(t, added, tc.name)
+			assert.NoError(t, err, tc.name)
+			continue
+		}
+		assert.Error(t, err, tc.name)
+
+		// the other parts are not collected
+		msg.Part = parts.GetPart(1)
+		added, err = cs1.addProposalBlockPart(msg, "peer")
+		assert.False(t, added, tc.name)
+		assert.NoError(t, err, tc.name)
+	}
+}
+
 //----------------------------------------------------------------------------------------------------
 // FullRoundSuite
 
@@ -1877,6 +1977,52 @@ func TestStateOutputVoteStats(t *testing.T) {
 
 }
 
+func TestStatePeerMsgQueueOverflow(t *testing.T) {
+	config := cfg.ResetTestRoot("consensus_state_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.PeerMsgQueueSize = 1
+	config.Consensus.DropPeerMsgsWhenFull = true
+
+	state, privVals := randGenesisState(2, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], counter.NewApplication(true))
+	require.Equal(t, 1, cap(cs.peerMsgQueue))
+	vs := newValidatorStub(privVals[1], 1)
+
+	vote := signVote(vs, tmproto.PrevoteType, nil, types.PartSetHeader{})
+	_, err := cs.AddVote(vote, "peer1")
+	require.NoError(t, err)
+
+	// the queue is full and nothing reads from it, so the vote is dropped
+	// instead of blocking
+	done := make(chan struct{})
+	go func() {
+		_, _ = cs.AddVote(vote, "peer2")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("AddVote blocked on a full peer msg queue")
+	}
+
+	mi := <-cs.peerMsgQueue
+	assert.Equal(t, p2p.ID("peer1"), mi.PeerID)
+	assert.Len(t, cs.peerMsgQueue, 0)
+}
+
+func TestStateDefaultMsgQueueSize(t *testing.T) {
+	config := cfg.ResetTestRoot("consensus_state_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.PeerMsgQueueSize = 0
+	config.Consensus.InternalMsgQueueSize = 0
+	require.NoError(t, config.Consensus.ValidateBasic())
+
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], counter.NewApplication(true))
+	assert.Equal(t, msgQueueSize, cap(cs.peerMsgQueue))
+	assert.Equal(t, msgQueueSize, cap(cs.internalMsgQueue))
+}
+
 func TestSignSameVoteTwice(t *testing.T) {
 	_, vss := randState(2)
 
@@ -1897,6 +2043,17 @@ func TestSignSameVoteTwice(t *testing.T) {
 	require.Equal(t, vote, vote2)
 }
 
+func TestStateIsValidator(t *testing.T) {
+	cs, vss := randState(4)
+
+	for _, vs := range vss {
+		pubKey, err := vs.GetPubKey()
+		require.NoError(t, err)
+		assert.True(t, cs.IsValidator(pubKey.Address()))
+	}
+	assert.False(t, cs.IsValidator(ed25519.GenPrivKey().PubKey().Address()))
+}
+
 // subscribe subscribes test client to the given query and returns a channel with cap = 1.
 func subscribe(eventBus *types.EventBus, q tmpubsub.Query) <-chan tmpubsub.Message {
 	sub, err := eventBus.Subscribe(context.Background(), testSubscriber, q)
@@ -0,0 +1,257 @@
+// Package testing provides utilities to test networks of Tendermint nodes
+// against byzantine behavior.
+//
+// A Reactor wraps the consensus reactor of a validator and makes it misbehave
+// towards its peers, as configured by Faults. It is added to the switch in
+// place of the consensus reactor:
+//
+//	sw.AddReactor("CONSENSUS", testing.NewReactor(conR, testing.Faults{
+//		PrivKey:    privKey,
+//		ChainID:    chainID,
+//		Equivocate: true,
+//	}))
+package testing
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Faults configures the byzantine behavior of a Reactor. The faults about
+// votes only apply to the votes of the validator itself, not to the votes of
+// others it gossips.
+type Faults struct {
+	// Key of the validator, which identifies its votes and signs the
+	// conflicting ones.
+	PrivKey crypto.PrivKey
+	// Chain the conflicting votes are signed for.
+	ChainID string
+
+	// Each prevote and precommit is followed by a conflicting one: for nil if
+	// the vote is for a block, for a random block otherwise.
+	Equivocate bool
+	// Votes are not sent.
+	WithholdVotes bool
+	// Votes are sent after this delay.
+	VoteDelay time.Duration
+	// Block parts are sent with their bytes altered, so that they don't match
+	// their proof.
+	CorruptBlockParts bool
+}
+
+// Reactor is a p2p.Reactor which applies Faults to the consensus messages the
+// wrapped reactor sends. It does so by handing the wrapped reactor, and the
+// reactors added to the switch after it, peers which alter the messages sent
+// to them.
+type Reactor struct {
+	p2p.Reactor
+
+	faults  Faults
+	address types.Address
+	logger  log.Logger
+
+	mtx   tmsync.Mutex
+	peers map[p2p.ID]*peer
+}
+
+var _ p2p.EnvelopeReceiver = (*Reactor)(nil)
+
+// NewReactor returns a Reactor wrapping r.
+func NewReactor(r p2p.Reactor, faults Faults) *Reactor {
+	br := &Reactor{
+		Reactor: r,
+		faults:  faults,
+		logger:  log.NewNopLogger(),
+		peers:   make(map[p2p.ID]*peer),
+	}
+	if faults.PrivKey != nil {
+		br.address = faults.PrivKey.PubKey().Address()
+	}
+	return br
+}
+
+// SetLogger implements service.Service.
+func (r *Reactor) SetLogger(l log.Logger) {
+	r.logger = l
+	r.Reactor.SetLogger(l)
+}
+
+// InitPeer implements p2p.Reactor. The wrapped reactor is handed a peer
+// applying the faults, so that the messages it sends through the peer states
+// it keeps go through the faults too.
+func (r *Reactor) InitPeer(p p2p.Peer) p2p.Peer {
+	fp := &peer{Peer: p, reactor: r}
+	r.mtx.Lock()
+	r.peers[p.ID()] = fp
+	r.mtx.Unlock()
+	return r.Reactor.InitPeer(fp)
+}
+
+// RemovePeer implements p2p.Reactor.
+func (r *Reactor) RemovePeer(p p2p.Peer, reason interface{}) {
+	r.mtx.Lock()
+	delete(r.peers, p.ID())
+	r.mtx.Unlock()
+	r.Reactor.RemovePeer(p, reason)
+}
+
+// ReceiveEnvelope implements p2p.EnvelopeReceiver. Replies of the wrapped
+// reactor to the message go through the faults too.
+func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
+	e.Src = r.peer(e.Src)
+	if er, ok := r.Reactor.(p2p.EnvelopeReceiver); ok {
+		er.ReceiveEnvelope(e)
+		return
+	}
+
+	msg := e.Message
+	if w, ok := msg.(p2p.Wrapper); ok {
+		msg = w.Wrap()
+	}
+	msgBytes, err := proto.Marshal(msg)
+	if err != nil {
+		r.logger.Error("marshaling message to receive", "err", err)
+		return
+	}
+	r.Reactor.Receive(e.ChannelID, e.Src, msgBytes) //nolint: staticcheck
+}
+
+// Receive implements p2p.Reactor.
+func (r *Reactor) Receive(chID byte, p p2p.Peer, msgBytes []byte) {
+	r.Reactor.Receive(chID, r.peer(p), msgBytes) //nolint: staticcheck
+}
+
+// peer returns the peer applying the faults for p.
+func (r *Reactor) peer(p p2p.Peer) p2p.Peer {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if fp, ok := r.peers[p.ID()]; ok {
+		return fp
+	}
+	return p
+}
+
+// send sends e to p, applying the faults.
+func (r *Reactor) send(p p2p.Peer, e p2p.Envelope, try bool) bool {
+	send := func(e p2p.Envelope) bool {
+		if try {
+			return p2p.TrySendEnvelopeShim(p, e, r.logger) //nolint: staticcheck
+		}
+		return p2p.SendEnvelopeShim(p, e, r.logger) //nolint: staticcheck
+	}
+
+	switch msg := e.Message.(type) {
+	case *tmcons.Vote:
+		if msg.Vote == nil || r.address == nil || !bytes.Equal(msg.Vote.ValidatorAddress, r.address) {
+			break
+		}
+		if r.faults.WithholdVotes {
+			r.logger.Debug("withholding vote", "peer", p.ID(), "vote", msg.Vote)
+			return true
+		}
+
+		envs := []p2p.Envelope{e}
+		if r.faults.Equivocate {
+			vote, err := r.conflictingVote(msg.Vote)
+			if err != nil {
+				r.logger.Error("failed to sign conflicting vote", "err", err)
+			} else {
+				envs = append(envs, p2p.Envelope{ChannelID: e.ChannelID, Message: &tmcons.Vote{Vote: vote}})
+			}
+		}
+		if r.faults.VoteDelay > 0 {
+			time.AfterFunc(r.faults.VoteDelay, func() {
+				for _, e := range envs {
+					send(e)
+				}
+			})
+			return true
+		}
+		for _, e := range envs {
+			if !send(e) {
+				return false
+			}
+		}
+		return true
+
+	case *tmcons.BlockPart:
+		if !r.faults.CorruptBlockParts {
+			break
+		}
+		part := msg.Part
+		part.Bytes = append([]byte(nil), part.Bytes...)
+		if len(part.Bytes) > 0 {
+			part.Bytes[0] ^= 0xff
+		}
+		e.Message = &tmcons.BlockPart{Height: msg.Height, Round: msg.Round, Part: part}
+	}
+
+	return send(e)
+}
+
+// conflictingVote returns a signed vote of the same height, round and type
+// as vote, but for a different block.
+func (r *Reactor) conflictingVote(vote *tmproto.Vote) (*tmproto.Vote, error) {
+	conflicting := *vote
+	if len(vote.BlockID.Hash) == 0 {
+		conflicting.BlockID = tmproto.BlockID{
+			Hash:          tmrand.Bytes(tmhash.Size),
+			PartSetHeader: tmproto.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+		}
+	} else {
+		conflicting.BlockID = tmproto.BlockID{}
+	}
+
+	sig, err := r.faults.PrivKey.Sign(types.VoteSignBytes(r.faults.ChainID, &conflicting))
+	if err != nil {
+		return nil, err
+	}
+	conflicting.Signature = sig
+	return &conflicting, nil
+}
+
+// peer is a p2p.Peer applying the faults of its reactor to the consensus
+// messages sent to it.
+type peer struct {
+	p2p.Peer
+	reactor *Reactor
+}
+
+var _ p2p.EnvelopeSender = (*peer)(nil)
+
+// SendEnvelope implements p2p.EnvelopeSender.
+func (p *peer) SendEnvelope(e p2p.Envelope) bool {
+	if !isConsensusChannel(e.ChannelID) {
+		return p2p.SendEnvelopeShim(p.Peer, e, p.reactor.logger) //nolint: staticcheck
+	}
+	return p.reactor.send(p.Peer, e, false)
+}
+
+// TrySendEnvelope implements p2p.EnvelopeSender.
+func (p *peer) TrySendEnvelope(e p2p.Envelope) bool {
+	if !isConsensusChannel(e.ChannelID) {
+		return p2p.TrySendEnvelopeShim(p.Peer, e, p.reactor.logger) //nolint: staticcheck
+	}
+	return p.reactor.send(p.Peer, e, true)
+}
+
+func isConsensusChannel(chID byte) bool {
+	switch chID {
+	case consensus.StateChannel, consensus.DataChannel, consensus.VoteChannel, consensus.VoteSetBitsChannel:
+		return true
+	}
+	return false
+}
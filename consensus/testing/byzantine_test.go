@@ -0,0 +1,270 @@
+package testing_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/consensus"
+	constesting "github.com/tendermint/tendermint/consensus/testing"
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+const (
+	chainID = "byzantine_test"
+	// validator 0 is byzantine, the others are honest
+	numValidators = 4
+	// height the honest validators must reach
+	targetHeight = 3
+)
+
+// evidencePool records the conflicting votes reported by the consensus state.
+type evidencePool struct {
+	sm.EmptyEvidencePool
+
+	mtx         tmsync.Mutex
+	conflicting []*types.Vote
+}
+
+func (evpool *evidencePool) ReportConflictingVotes(voteA, voteB *types.Vote) {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	evpool.conflicting = append(evpool.conflicting, voteA, voteB)
+}
+
+func (evpool *evidencePool) conflictingVotes() []*types.Vote {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	return append([]*types.Vote(nil), evpool.conflicting...)
+}
+
+// receivedVote is a vote added to the consensus state of a validator.
+type receivedVote struct {
+	vote *types.Vote
+	at   time.Time
+}
+
+type testNode struct {
+	address    types.Address
+	blockStore *store.BlockStore
+	evpool     *evidencePool
+
+	mtx   tmsync.Mutex
+	votes []receivedVote
+}
+
+func (n *testNode) receivedVotes() []receivedVote {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return append([]receivedVote(nil), n.votes...)
+}
+
+// votesFrom returns the votes of the validator with the given address added
+// to the consensus state of the node.
+func (n *testNode) votesFrom(address types.Address) []receivedVote {
+	var votes []receivedVote
+	for _, rv := range n.receivedVotes() {
+		if bytes.Equal(rv.vote.ValidatorAddress, address) {
+			votes = append(votes, rv)
+		}
+	}
+	return votes
+}
+
+// startNetwork starts a network of validators connected to each other, with
+// validator 0 applying the faults, and waits until the honest validators
+// reach targetHeight. The honest validators have a voting power of 10 each,
+// so that they can commit blocks without validator 0 unless its power is
+// greater than 15.
+func startNetwork(t *testing.T, faults constesting.Faults, power int64) []*testNode {
+	t.Helper()
+
+	pvs := make([]types.MockPV, numValidators)
+	genVals := make([]types.GenesisValidator, numValidators)
+	for i := range pvs {
+		pvs[i] = types.NewMockPV()
+		pubKey, err := pvs[i].GetPubKey()
+		require.NoError(t, err)
+		genVals[i] = types.GenesisValidator{PubKey: pubKey, Power: 10}
+	}
+	genVals[0].Power = power
+	genDoc := &types.GenesisDoc{
+		ChainID:     chainID,
+		GenesisTime: tmtime.Now(),
+		Validators:  genVals,
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	faults.PrivKey = pvs[0].PrivKey
+	faults.ChainID = chainID
+
+	nodes := make([]*testNode, numValidators)
+	reactors := make([]p2p.Reactor, numValidators)
+	for i := range nodes {
+		config := cfg.ResetTestRoot(fmt.Sprintf("byzantine_test_%d", i))
+		t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+		logger := log.TestingLogger().With("validator", i)
+
+		state, err := sm.MakeGenesisState(genDoc)
+		require.NoError(t, err)
+		stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+		require.NoError(t, stateStore.Save(state))
+		blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+		proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+		require.NoError(t, proxyApp.Start())
+		t.Cleanup(func() {
+			if err := proxyApp.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		mempool := mempoolv0.NewCListMempool(config.Mempool, proxyApp.Mempool(), state.LastBlockHeight)
+		evpool := &evidencePool{}
+		blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(), mempool, evpool)
+
+		eventBus := types.NewEventBus()
+		eventBus.SetLogger(logger)
+		require.NoError(t, eventBus.Start())
+		t.Cleanup(func() {
+			if err := eventBus.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		cs := consensus.NewState(config.Consensus, state, blockExec, blockStore, mempool, evpool)
+		cs.SetLogger(logger)
+		cs.SetPrivValidator(pvs[i])
+		cs.SetEventBus(eventBus)
+		conR := consensus.NewReactor(cs, false)
+		conR.SetEventBus(eventBus)
+		conR.SetLogger(logger)
+		reactors[i] = conR
+		if i == 0 {
+			br := constesting.NewReactor(conR, faults)
+			br.SetLogger(logger)
+			reactors[i] = br
+		}
+
+		n := &testNode{address: pvs[i].PrivKey.PubKey().Address(), blockStore: blockStore, evpool: evpool}
+		nodes[i] = n
+		sub, err := eventBus.Subscribe(context.Background(), "byzantine_test", types.EventQueryVote, 1000)
+		require.NoError(t, err)
+		go func() {
+			for {
+				select {
+				case msg := <-sub.Out():
+					n.mtx.Lock()
+					n.votes = append(n.votes, receivedVote{msg.Data().(types.EventDataVote).Vote, time.Now()})
+					n.mtx.Unlock()
+				case <-sub.Cancelled():
+					return
+				}
+			}
+		}()
+	}
+
+	p2pConfig := cfg.TestP2PConfig()
+	switches := p2p.MakeConnectedSwitches(p2pConfig, numValidators, func(i int, sw *p2p.Switch) *p2p.Switch {
+		sw.SetLogger(log.TestingLogger().With("validator", i))
+		sw.AddReactor("CONSENSUS", reactors[i])
+		return sw
+	}, p2p.Connect2Switches)
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	require.Eventually(t, func() bool {
+		for _, n := range nodes[1:] {
+			if n.blockStore.Height() < targetHeight {
+				return false
+			}
+		}
+		return true
+	}, 30*time.Second, 10*time.Millisecond, "honest validators did not reach height %d", targetHeight)
+	return nodes
+}
+
+func TestReactorWithholdVotes(t *testing.T) {
+	nodes := startNetwork(t, constesting.Faults{WithholdVotes: true}, 10)
+
+	for _, n := range nodes[1:] {
+		// the votes of the byzantine validator never reach the others...
+		assert.Empty(t, n.votesFrom(nodes[0].address))
+		// but the votes of honest validators it gossips do
+		for _, other := range nodes[1:] {
+			assert.NotEmpty(t, n.votesFrom(other.address))
+		}
+	}
+}
+
+func TestReactorEquivocate(t *testing.T) {
+	nodes := startNetwork(t, constesting.Faults{Equivocate: true}, 10)
+
+	for _, n := range nodes[1:] {
+		assert.NotEmpty(t, n.votesFrom(nodes[0].address))
+		// the conflicting votes reach the honest validators, which report them
+		require.Eventually(t, func() bool {
+			for _, vote := range n.evpool.conflictingVotes() {
+				if bytes.Equal(vote.ValidatorAddress, nodes[0].address) {
+					return true
+				}
+			}
+			return false
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestReactorVoteDelay(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	// the votes of the byzantine validator are needed to commit blocks, so
+	// they must have been received
+	nodes := startNetwork(t, constesting.Faults{VoteDelay: delay}, 20)
+
+	// when the byzantine validator added its own votes, i.e. signed them
+	signed := make(map[string]time.Time)
+	for _, rv := range nodes[0].votesFrom(nodes[0].address) {
+		signed[string(rv.vote.Signature)] = rv.at
+	}
+	for _, n := range nodes[1:] {
+		votes := n.votesFrom(nodes[0].address)
+		require.NotEmpty(t, votes)
+		for _, rv := range votes {
+			at, ok := signed[string(rv.vote.Signature)]
+			require.True(t, ok)
+			assert.GreaterOrEqual(t, rv.at.Sub(at), delay)
+		}
+	}
+}
+
+func TestReactorCorruptBlockParts(t *testing.T) {
+	nodes := startNetwork(t, constesting.Faults{CorruptBlockParts: true}, 10)
+
+	// the byzantine validator still votes, but its proposals never complete
+	for _, n := range nodes[1:] {
+		assert.NotEmpty(t, n.votesFrom(nodes[0].address))
+		for h := int64(1); h <= n.blockStore.Height(); h++ {
+			assert.NotEqual(t, nodes[0].address, n.blockStore.LoadBlockMeta(h).Header.ProposerAddress,
+				"block %d proposed by the byzantine validator was committed", h)
+		}
+	}
+}
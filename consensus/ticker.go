@@ -1,8 +1,7 @@
 package consensus
 
 import (
-	"time"
-
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -23,7 +22,7 @@ type TimeoutTicker interface {
 	SetLogger(log.Logger)
 }
 
-// timeoutTicker wraps time.Timer,
+// timeoutTicker wraps a clock.Timer,
 // scheduling timeouts only for greater height/round/step
 // than what it's already seen.
 // Timeouts are scheduled along the tickChan,
@@ -31,15 +30,19 @@ type TimeoutTicker interface {
 type timeoutTicker struct {
 	service.BaseService
 
-	timer    *time.Timer
+	timer    clock.Timer
 	tickChan chan timeoutInfo // for scheduling timeouts
 	tockChan chan timeoutInfo // for notifying about them
 }
 
 // NewTimeoutTicker returns a new TimeoutTicker.
 func NewTimeoutTicker() TimeoutTicker {
+	return newTimeoutTicker(clock.System)
+}
+
+func newTimeoutTicker(c clock.Clock) TimeoutTicker {
 	tt := &timeoutTicker{
-		timer:    time.NewTimer(0),
+		timer:    c.NewTimer(0),
 		tickChan: make(chan timeoutInfo, tickTockBufferSize),
 		tockChan: make(chan timeoutInfo, tickTockBufferSize),
 	}
@@ -81,7 +84,7 @@ func (t *timeoutTicker) stopTimer() {
 	// Stop() returns false if it was already fired or was stopped
 	if !t.timer.Stop() {
 		select {
-		case <-t.timer.C:
+		case <-t.timer.C():
 		default:
 			t.Logger.Debug("Timer already stopped")
 		}
@@ -120,7 +123,7 @@ func (t *timeoutTicker) timeoutRoutine() {
 			ti = newti
 			t.timer.Reset(ti.Duration)
 			t.Logger.Debug("Scheduled timeout", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
-		case <-t.timer.C:
+		case <-t.timer.C():
 			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
 			// go routine here guarantees timeoutRoutine doesn't block.
 			// Determinism comes from playback in the receiveRoutine.
@@ -47,6 +47,8 @@ type HeightVoteSet struct {
 	round             int32                  // max tracked round
 	roundVoteSets     map[int32]RoundVoteSet // keys: [0...round]
 	peerCatchupRounds map[p2p.ID][]int32     // keys: peer.ID; values: at most 2 rounds
+	votePeers         map[string]p2p.ID      // keys: vote signature; values: peer the vote came from
+	sigCache          *types.SignatureCache  // shared by all vote sets, may be nil
 }
 
 func NewHeightVoteSet(chainID string, height int64, valSet *types.ValidatorSet) *HeightVoteSet {
@@ -65,11 +67,24 @@ func (hvs *HeightVoteSet) Reset(height int64, valSet *types.ValidatorSet) {
 	hvs.valSet = valSet
 	hvs.roundVoteSets = make(map[int32]RoundVoteSet)
 	hvs.peerCatchupRounds = make(map[p2p.ID][]int32)
+	hvs.votePeers = make(map[string]p2p.ID)
 
 	hvs.addRound(0)
 	hvs.round = 0
 }
 
+// SetSignatureCache sets the cache of verified signatures used by all the
+// vote sets of this height, including the ones already created.
+func (hvs *HeightVoteSet) SetSignatureCache(cache *types.SignatureCache) {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+	hvs.sigCache = cache
+	for _, rvs := range hvs.roundVoteSets {
+		rvs.Prevotes.SetSignatureCache(cache)
+		rvs.Precommits.SetSignatureCache(cache)
+	}
+}
+
 func (hvs *HeightVoteSet) Height() int64 {
 	hvs.mtx.Lock()
 	defer hvs.mtx.Unlock()
@@ -106,6 +121,8 @@ func (hvs *HeightVoteSet) addRound(round int32) {
 	// log.Debug("addRound(round)", "round", round)
 	prevotes := types.NewVoteSet(hvs.chainID, hvs.height, round, tmproto.PrevoteType, hvs.valSet)
 	precommits := types.NewVoteSet(hvs.chainID, hvs.height, round, tmproto.PrecommitType, hvs.valSet)
+	prevotes.SetSignatureCache(hvs.sigCache)
+	precommits.SetSignatureCache(hvs.sigCache)
 	hvs.roundVoteSets[round] = RoundVoteSet{
 		Prevotes:   prevotes,
 		Precommits: precommits,
@@ -133,6 +150,18 @@ func (hvs *HeightVoteSet) AddVote(vote *types.Vote, peerID p2p.ID) (added bool,
 		}
 	}
 	added, err = voteSet.AddVote(vote)
+	if added {
+		hvs.votePeers[string(vote.Signature)] = peerID
+	}
+	return
+}
+
+// VotePeer returns the peer the given vote was added from, "" if it was our
+// own. ok is false if the vote was not added to this HeightVoteSet.
+func (hvs *HeightVoteSet) VotePeer(vote *types.Vote) (peerID p2p.ID, ok bool) {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+	peerID, ok = hvs.votePeers[string(vote.Signature)]
 	return
 }
 
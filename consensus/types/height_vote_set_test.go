@@ -5,6 +5,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -55,6 +58,40 @@ func TestPeerCatchupRounds(t *testing.T) {
 
 }
 
+func TestVotePeers(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(10, 1)
+
+	hvs := NewHeightVoteSet(config.ChainID(), 1, valSet)
+
+	vote := makeVoteHR(t, 1, 0, 0, privVals)
+	added, err := hvs.AddVote(vote, "peer1")
+	require.True(t, added)
+	require.NoError(t, err)
+	own := makeVoteHR(t, 1, 1, 0, privVals)
+	added, err = hvs.AddVote(own, "")
+	require.True(t, added)
+	require.NoError(t, err)
+
+	// a vote from the same validator for another block
+	conflicting := makeVoteHR(t, 1, 0, 0, privVals)
+	_, err = hvs.AddVote(conflicting, "peer2")
+	var conflictErr *types.ErrVoteConflictingVotes
+	require.ErrorAs(t, err, &conflictErr)
+
+	peerID, ok := hvs.VotePeer(conflictErr.VoteA)
+	assert.True(t, ok)
+	assert.EqualValues(t, "peer1", peerID)
+	peerID, ok = hvs.VotePeer(own)
+	assert.True(t, ok)
+	assert.EqualValues(t, "", peerID)
+	_, ok = hvs.VotePeer(conflicting)
+	assert.False(t, ok)
+
+	hvs.Reset(2, valSet)
+	_, ok = hvs.VotePeer(vote)
+	assert.False(t, ok)
+}
+
 func makeVoteHR(t *testing.T, height int64, valIndex, round int32, privVals []types.PrivValidator) *types.Vote {
 	privVal := privVals[valIndex]
 	pubKey, err := privVal.GetPubKey()
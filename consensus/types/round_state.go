@@ -161,13 +161,20 @@ func (rs *RoundState) CompleteProposalEvent() types.EventDataCompleteProposal {
 	}
 }
 
-// RoundStateEvent returns the H/R/S of the RoundState as an event.
+// RoundStateEvent returns the H/R/S of the RoundState and the votes of its
+// round as an event.
 func (rs *RoundState) RoundStateEvent() types.EventDataRoundState {
-	return types.EventDataRoundState{
+	ev := types.EventDataRoundState{
 		Height: rs.Height,
 		Round:  rs.Round,
 		Step:   rs.Step.String(),
 	}
+	if rs.Votes != nil {
+		prevotes, precommits := rs.Votes.Prevotes(rs.Round), rs.Votes.Precommits(rs.Round)
+		ev.Prevotes, ev.PrevotesPower = prevotes.BitArray(), prevotes.SumVotingPower()
+		ev.Precommits, ev.PrecommitsPower = precommits.BitArray(), precommits.SumVotingPower()
+	}
+	return ev
 }
 
 // String returns a string
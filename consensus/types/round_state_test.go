@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRoundStateEventVotes(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(4, 10)
+
+	rs := &RoundState{Height: 1, Round: 0, Step: RoundStepPrecommit}
+	ev := rs.RoundStateEvent()
+	assert.Nil(t, ev.Prevotes)
+	assert.Nil(t, ev.Precommits)
+
+	rs.Votes = NewHeightVoteSet(config.ChainID(), 1, valSet)
+	for _, i := range []int32{0, 2} {
+		added, err := rs.Votes.AddVote(makeVoteHR(t, 1, i, 0, privVals), "peer1")
+		require.True(t, added)
+		require.NoError(t, err)
+	}
+
+	ev = rs.RoundStateEvent()
+	assert.Equal(t, "BA{4:x_x_}", ev.Precommits.String())
+	assert.EqualValues(t, 20, ev.PrecommitsPower)
+	assert.Equal(t, "BA{4:____}", ev.Prevotes.String())
+	assert.Zero(t, ev.PrevotesPower)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	auto "github.com/tendermint/tendermint/libs/autofile"
+	"github.com/tendermint/tendermint/libs/clock"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -80,7 +81,8 @@ type BaseWAL struct {
 
 	enc *WALEncoder
 
-	flushTicker   *time.Ticker
+	clock         clock.Clock
+	flushTicker   clock.Ticker
 	flushInterval time.Duration
 }
 
@@ -101,6 +103,7 @@ func NewWAL(walFile string, groupOptions ...func(*auto.Group)) (*BaseWAL, error)
 	wal := &BaseWAL{
 		group:         group,
 		enc:           NewWALEncoder(group),
+		clock:         clock.System,
 		flushInterval: walDefaultFlushInterval,
 	}
 	wal.BaseService = *service.NewBaseService(nil, "baseWAL", wal)
@@ -112,6 +115,12 @@ func (wal *BaseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetClock overrides the clock used to timestamp messages and schedule the
+// periodic flush. It must be called before the WAL is started.
+func (wal *BaseWAL) SetClock(c clock.Clock) {
+	wal.clock = c
+}
+
 func (wal *BaseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -134,7 +143,7 @@ func (wal *BaseWAL) OnStart() error {
 	if err != nil {
 		return err
 	}
-	wal.flushTicker = time.NewTicker(wal.flushInterval)
+	wal.flushTicker = wal.clock.NewTicker(wal.flushInterval)
 	go wal.processFlushTicks()
 	return nil
 }
@@ -142,7 +151,7 @@ func (wal *BaseWAL) OnStart() error {
 func (wal *BaseWAL) processFlushTicks() {
 	for {
 		select {
-		case <-wal.flushTicker.C:
+		case <-wal.flushTicker.C():
 			if err := wal.FlushAndSync(); err != nil {
 				wal.Logger.Error("Periodic WAL flush failed", "err", err)
 			}
@@ -186,7 +195,7 @@ func (wal *BaseWAL) Write(msg WALMessage) error {
 		return nil
 	}
 
-	if err := wal.enc.Encode(&TimedWALMessage{tmtime.Now(), msg}); err != nil {
+	if err := wal.enc.Encode(&TimedWALMessage{tmtime.Canonical(wal.clock.Now()), msg}); err != nil {
 		wal.Logger.Error("Error writing msg to consensus wal. WARNING: recover may not be possible for the current height",
 			"err", err, "msg", msg)
 		return err
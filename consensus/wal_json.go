@@ -0,0 +1,71 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// The JSON encoding of a WAL message is larger than its binary encoding,
+// mostly because of the base64 encoded bytes.
+const maxJSONMsgSizeBytes = 2 * maxMsgSizeBytes
+
+// WALToJSON reads the binary WAL messages of rd and writes them to w as
+// line-delimited JSON, one TimedWALMessage per line.
+//
+// Unlike the binary encoding, which follows the protobuf definitions of the
+// messages, the JSON encoding follows their Go types. A WAL exported with
+// WALToJSON can thus be imported with JSONToWAL by a version of Tendermint
+// with a different wire format, as long as the types are compatible.
+func WALToJSON(rd io.Reader, w io.Writer) error {
+	dec := NewWALDecoder(rd)
+	for {
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode msg: %w", err)
+		}
+
+		bz, err := tmjson.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal msg: %w", err)
+		}
+		if _, err := w.Write(append(bz, '\n')); err != nil {
+			return fmt.Errorf("failed to write msg: %w", err)
+		}
+	}
+}
+
+// JSONToWAL reads the line-delimited JSON messages of rd, as written by
+// WALToJSON, and writes them to w in the binary WAL format. Empty lines and
+// the "ENDHEIGHT <height>" lines written by older versions of
+// scripts/wal2json are skipped.
+func JSONToWAL(rd io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxJSONMsgSizeBytes)
+	enc := NewWALEncoder(w)
+
+	for line := 1; scanner.Scan(); line++ {
+		msgJSON := bytes.TrimSpace(scanner.Bytes())
+		if len(msgJSON) == 0 || bytes.HasPrefix(msgJSON, []byte("ENDHEIGHT")) {
+			continue
+		}
+
+		var msg TimedWALMessage
+		if err := tmjson.Unmarshal(msgJSON, &msg); err != nil {
+			return fmt.Errorf("failed to unmarshal msg on line %d: %w", line, err)
+		}
+		if err := enc.Encode(&msg); err != nil {
+			return fmt.Errorf("failed to encode msg on line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read JSON: %w", err)
+	}
+	return nil
+}
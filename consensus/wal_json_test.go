@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+func TestWALToJSONAndBack(t *testing.T) {
+	var wal bytes.Buffer
+	require.NoError(t, WALGenerateNBlocks(t, &wal, 3))
+
+	var walJSON bytes.Buffer
+	require.NoError(t, WALToJSON(bytes.NewReader(wal.Bytes()), &walJSON))
+
+	lines := strings.Split(strings.TrimSuffix(walJSON.String(), "\n"), "\n")
+	require.NotEmpty(t, lines)
+	var msg TimedWALMessage
+	require.NoError(t, tmjson.Unmarshal([]byte(lines[0]), &msg))
+
+	var imported bytes.Buffer
+	require.NoError(t, JSONToWAL(&walJSON, &imported))
+	assert.Equal(t, wal.Bytes(), imported.Bytes())
+}
+
+func TestJSONToWALSkipsEndHeightLines(t *testing.T) {
+	msg := &TimedWALMessage{Msg: EndHeightMessage{Height: 1}}
+	bz, err := tmjson.Marshal(msg)
+	require.NoError(t, err)
+
+	var wal bytes.Buffer
+	input := string(bz) + "\nENDHEIGHT 1\n\n"
+	require.NoError(t, JSONToWAL(strings.NewReader(input), &wal))
+
+	dec := NewWALDecoder(&wal)
+	decoded, err := dec.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, msg.Msg, decoded.Msg)
+	_, err = dec.Decode()
+	assert.Error(t, err)
+}
+
+func TestJSONToWALInvalidLine(t *testing.T) {
+	err := JSONToWAL(strings.NewReader("\n{\"time\":"), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "line 2")
+}
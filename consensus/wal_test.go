@@ -16,6 +16,7 @@ import (
 	"github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/autofile"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
@@ -149,6 +150,93 @@ func TestWALWrite(t *testing.T) {
 	}
 }
 
+func TestWALTimestampsFromClock(t *testing.T) {
+	walDir, err := os.MkdirTemp("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	wal.SetClock(clock.NewMock(now))
+
+	// starting an empty WAL writes an EndHeightMessage for height 0
+	require.NoError(t, wal.Start())
+	defer func() {
+		if err := wal.Stop(); err != nil {
+			t.Error(err)
+		}
+		wal.Wait()
+	}()
+
+	f, err := os.Open(walFile)
+	require.NoError(t, err)
+	defer f.Close()
+	msg, err := NewWALDecoder(f).Decode()
+	require.NoError(t, err)
+	assert.Equal(t, now, msg.Time)
+	assert.Equal(t, EndHeightMessage{0}, msg.Msg)
+}
+
+func TestWALMaxSize(t *testing.T) {
+	walDir, err := os.MkdirTemp("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+
+	cs, _ := randState(1)
+	cs.config.WalMaxSize = 1 << 20
+	wal, err := cs.OpenWAL(filepath.Join(walDir, "wal"))
+	require.NoError(t, err)
+	defer func() {
+		if err := wal.Stop(); err != nil {
+			t.Error(err)
+		}
+		wal.Wait()
+	}()
+
+	assert.EqualValues(t, 1<<20, wal.(*BaseWAL).Group().TotalSizeLimit())
+}
+
+func TestWALRotatesAtMaxSize(t *testing.T) {
+	walDir, err := os.MkdirTemp("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+
+	// rotate the head every 4K and check the limits every millisecond, so
+	// that 60 blocks (nearly 70K) span many files.
+	const maxSize = 16 * 1024
+	wal, err := NewWAL(filepath.Join(walDir, "wal"),
+		autofile.GroupTotalSizeLimit(maxSize),
+		autofile.GroupHeadSizeLimit(4096),
+		autofile.GroupCheckDuration(1*time.Millisecond),
+	)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	require.NoError(t, wal.Start())
+	defer func() {
+		if err := wal.Stop(); err != nil {
+			t.Error(err)
+		}
+		wal.Wait()
+	}()
+
+	require.NoError(t, WALGenerateNBlocks(t, wal.Group(), 60))
+	require.NoError(t, wal.FlushAndSync())
+
+	// the oldest files are removed until the WAL fits in maxSize again
+	require.Eventually(t, func() bool {
+		info := wal.Group().ReadGroupInfo()
+		return info.MinIndex > 0 && info.TotalSize < maxSize
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the latest heights are still there
+	gr, found, err := wal.SearchForEndHeight(59, &WALSearchOptions{})
+	require.NoError(t, err)
+	assert.True(t, found, "expected to find end height 59")
+	require.NoError(t, gr.Close())
+}
+
 func TestWALSearchForEndHeight(t *testing.T) {
 	walBody, err := WALWithNBlocks(t, 6)
 	if err != nil {
@@ -194,6 +282,9 @@ func TestWALPeriodicSync(t *testing.T) {
 	// We should have data in the buffer now
 	assert.NotZero(t, wal.Group().Buffered())
 
+	clk := clock.NewMock(tmtime.Now())
+	wal.SetClock(clk)
+
 	require.NoError(t, wal.Start())
 	defer func() {
 		if err := wal.Stop(); err != nil {
@@ -202,10 +293,11 @@ func TestWALPeriodicSync(t *testing.T) {
 		wal.Wait()
 	}()
 
-	time.Sleep(walTestFlushInterval + (10 * time.Millisecond))
+	clk.Advance(walTestFlushInterval)
 
 	// The data should have been flushed by the periodic sync
-	assert.Zero(t, wal.Group().Buffered())
+	require.Eventually(t, func() bool { return wal.Group().Buffered() == 0 },
+		time.Second, time.Millisecond)
 
 	h := int64(4)
 	gr, found, err := wal.SearchForEndHeight(h, &WALSearchOptions{})
@@ -80,6 +80,12 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		pool.evidenceList.PushBack(ev)
 	}
 
+	// if the node stopped after committing the last block but before the pool was
+	// updated with it, the evidence in that block is still pending
+	if pool.Size() > 0 {
+		pool.markLastBlockEvidenceAsCommitted()
+	}
+
 	return pool, nil
 }
 
@@ -102,6 +108,8 @@ func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 //  2. Update the pool's state which contains evidence params relating to expiry.
 //  3. Moves pending evidence that has now been committed into the committed pool.
 //  4. Removes any expired evidence based on both height and time.
+//  5. Removes the record of committed evidence that has expired. Such evidence
+//     is rejected as too old, so it no longer needs to be remembered.
 func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	// sanity check
 	if state.LastBlockHeight <= evpool.state.LastBlockHeight {
@@ -128,6 +136,8 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 		state.LastBlockTime.After(evpool.pruningTime) {
 		evpool.pruningHeight, evpool.pruningTime = evpool.removeExpiredPendingEvidence()
 	}
+
+	evpool.removeExpiredCommittedEvidence()
 }
 
 // AddEvidence checks the evidence is valid and adds it to the pool.
@@ -324,15 +334,11 @@ func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 }
 
 // markEvidenceAsCommitted processes all the evidence in the block, marking it as
-// committed and removing it from the pending database.
+// committed and removing it from the pending database. The evidence is marked
+// before it is removed, so that it is never lost if the node stops in between.
 func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 	blockEvidenceMap := make(map[string]struct{}, len(evidence))
 	for _, ev := range evidence {
-		if evpool.isPending(ev) {
-			evpool.removePendingEvidence(ev)
-			blockEvidenceMap[evMapKey(ev)] = struct{}{}
-		}
-
 		// Add evidence to the committed list. As the evidence is stored in the block store
 		// we only need to record the height that it was saved at.
 		key := keyCommitted(ev)
@@ -346,6 +352,12 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 
 		if err := evpool.evidenceStore.Set(key, evBytes); err != nil {
 			evpool.logger.Error("Unable to save committed evidence", "err", err, "key(height/hash)", key)
+			continue
+		}
+
+		if evpool.isPending(ev) {
+			evpool.removePendingEvidence(ev)
+			blockEvidenceMap[evMapKey(ev)] = struct{}{}
 		}
 	}
 
@@ -355,6 +367,20 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 	}
 }
 
+// markLastBlockEvidenceAsCommitted marks the evidence in the block at the
+// height of the pool's state as committed. Blocks replayed on startup are
+// applied without the evidence pool, so this is the only block whose evidence
+// may not have been marked.
+func (evpool *Pool) markLastBlockEvidenceAsCommitted() {
+	block := evpool.blockStore.LoadBlock(evpool.State().LastBlockHeight)
+	if block == nil || len(block.Evidence.Evidence) == 0 {
+		return
+	}
+	evpool.logger.Info("Marking evidence of the last block as committed",
+		"height", block.Height, "num", len(block.Evidence.Evidence))
+	evpool.markEvidenceAsCommitted(block.Evidence.Evidence)
+}
+
 // listEvidence retrieves lists evidence from oldest to newest within maxBytes.
 // If maxBytes is -1, there's no cap on the size of returned evidence.
 func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Evidence, int64, error) {
@@ -433,6 +459,46 @@ func (evpool *Pool) removeExpiredPendingEvidence() (int64, time.Time) {
 	return evpool.State().LastBlockHeight, evpool.State().LastBlockTime
 }
 
+// removeExpiredCommittedEvidence deletes the committed evidence records, which
+// are ordered by height, up to the first one that has not expired. The time of
+// the evidence is the time of the block at its height; if that block has been
+// pruned the evidence can no longer be verified and the record is deleted too.
+func (evpool *Pool) removeExpiredCommittedEvidence() {
+	iter, err := dbm.IteratePrefix(evpool.evidenceStore, []byte{baseKeyCommitted})
+	if err != nil {
+		evpool.logger.Error("Unable to iterate over committed evidence", "err", err)
+		return
+	}
+
+	var expired [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var h gogotypes.Int64Value
+		if err := proto.Unmarshal(iter.Value(), &h); err != nil {
+			evpool.logger.Error("Unable to unmarshal committed evidence height", "err", err)
+			break
+		}
+		if blockMeta := evpool.blockStore.LoadBlockMeta(h.Value); blockMeta != nil &&
+			!evpool.isExpired(h.Value, blockMeta.Header.Time) {
+			break
+		}
+		expired = append(expired, iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		evpool.logger.Error("Unable to iterate over committed evidence", "err", err)
+	}
+	// the iterator must be closed before deleting from the store
+	iter.Close()
+
+	for _, key := range expired {
+		if err := evpool.evidenceStore.Delete(key); err != nil {
+			evpool.logger.Error("Unable to delete committed evidence", "err", err, "key(height/hash)", key)
+		}
+	}
+	if len(expired) > 0 {
+		evpool.logger.Debug("Deleted expired committed evidence", "num", len(expired))
+	}
+}
+
 func (evpool *Pool) removeEvidenceFromList(
 	blockEvidenceMap map[string]struct{}) {
 
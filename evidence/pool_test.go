@@ -212,6 +212,37 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	}
 }
 
+func TestEvidencePoolUpdateRemovesExpiredCommittedEvidence(t *testing.T) {
+	height := int64(21)
+	pool, val := defaultTestPool(height)
+	state := pool.State()
+
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(10, defaultEvidenceTime.Add(10*time.Minute),
+		val, evidenceChainID)
+	require.NoError(t, pool.CheckEvidence(types.EvidenceList{ev}))
+
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
+	pool.Update(state, types.EvidenceList{ev})
+
+	err := pool.CheckEvidence(types.EvidenceList{ev})
+	if assert.Error(t, err) {
+		assert.Equal(t, "evidence was already committed", err.(*types.ErrInvalidEvidence).Reason.Error())
+	}
+
+	// once the evidence has expired it is rejected as too old instead
+	state.LastBlockHeight = height + 2
+	state.LastBlockTime = defaultEvidenceTime.Add(23 * time.Minute)
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 5
+	state.ConsensusParams.Evidence.MaxAgeDuration = 5 * time.Minute
+	pool.Update(state, types.EvidenceList{})
+
+	err = pool.CheckEvidence(types.EvidenceList{ev})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is too old")
+	}
+}
+
 func TestVerifyPendingEvidencePasses(t *testing.T) {
 	var height int64 = 1
 	pool, val := defaultTestPool(height)
@@ -346,6 +377,49 @@ func TestRecoverPendingEvidence(t *testing.T) {
 
 }
 
+// Tests that restarting the evidence pool after the node stopped between committing
+// a block and updating the pool removes the evidence in that block from the pending
+// evidence
+func TestRecoverCommittedPendingEvidence(t *testing.T) {
+	height := int64(10)
+	val := types.NewMockPV()
+	valAddress := val.PrivKey.PubKey().Address()
+	evidenceDB := dbm.NewMemDB()
+	stateStore := initializeValidatorState(val, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(dbm.NewMemDB(), state, valAddress)
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(height,
+		defaultEvidenceTime.Add(10*time.Minute), val, evidenceChainID)
+	require.NoError(t, pool.AddEvidence(ev))
+
+	// commit the next block with the evidence, without updating the pool
+	lastCommit := makeCommit(height, valAddress)
+	lastCommit.BlockID = blockStore.LoadBlockMeta(height).BlockID
+	block, _ := state.MakeBlock(height+1, []types.Tx{}, lastCommit,
+		[]types.Evidence{ev}, state.Validators.GetProposer().Address)
+	block.Header.Time = defaultEvidenceTime.Add(time.Duration(height+1) * time.Minute)
+	block.Header.Version = tmversion.Consensus{Block: version.BlockProtocol, App: 1}
+	blockStore.SaveBlock(block, block.MakePartSet(1), makeCommit(height+1, valAddress))
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = block.Header.Time
+	require.NoError(t, stateStore.Save(state))
+
+	newPool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	assert.Zero(t, newPool.Size())
+	evList, _ := newPool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Empty(t, evList)
+	assert.Nil(t, newPool.EvidenceFront())
+
+	err = newPool.CheckEvidence(types.EvidenceList{ev})
+	if assert.Error(t, err) {
+		assert.Equal(t, "evidence was already committed", err.(*types.ErrInvalidEvidence).Reason.Error())
+	}
+}
+
 func initializeStateFromValidatorSet(valSet *types.ValidatorSet, height int64) sm.Store {
 	stateDB := dbm.NewMemDB()
 	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
@@ -7,6 +7,7 @@ import (
 //go:generate ../scripts/mockery_generate.sh BlockStore
 
 type BlockStore interface {
+	LoadBlock(height int64) *types.Block
 	LoadBlockMeta(height int64) *types.BlockMeta
 	LoadBlockCommit(height int64) *types.Commit
 	Height() int64
@@ -20,6 +20,17 @@ const (
 //
 //	ParseLogLevel("consensus:debug,mempool:debug,*:error", log.NewTMLogger(os.Stdout), "info")
 func ParseLogLevel(lvl string, logger log.Logger, defaultLogLevelValue string) (log.Logger, error) {
+	options, err := ParseLogLevelOptions(lvl, defaultLogLevelValue)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewFilter(logger, options...), nil
+}
+
+// ParseLogLevelOptions parses complex log level like ParseLogLevel, and
+// returns the options of the filter, e.g. to change the levels of a running
+// logger with log.LevelSetter.
+func ParseLogLevelOptions(lvl string, defaultLogLevelValue string) ([]log.Option, error) {
 	if lvl == "" {
 		return nil, errors.New("empty log level")
 	}
@@ -86,5 +97,5 @@ func ParseLogLevel(lvl string, logger log.Logger, defaultLogLevelValue string) (
 		options = append(options, option)
 	}
 
-	return log.NewFilter(logger, options...), nil
+	return options, nil
 }
@@ -0,0 +1,145 @@
+package clock
+
+import (
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// Mock is a Clock whose time only moves when it is advanced, firing the
+// timers and tickers that become due. It is meant for tests.
+type Mock struct {
+	mtx    tmsync.Mutex
+	now    time.Time
+	timers map[*mockTimer]struct{} // active timers and tickers
+}
+
+var _ Clock = (*Mock)(nil)
+
+// NewMock returns a Mock set to now.
+func NewMock(now time.Time) *Mock {
+	return &Mock{
+		now:    now,
+		timers: make(map[*mockTimer]struct{}),
+	}
+}
+
+// Now implements Clock.
+func (m *Mock) Now() time.Time {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.now
+}
+
+// NewTimer implements Clock.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	t := &mockTimer{clock: m, ch: make(chan time.Time, 1)}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.schedule(t, d)
+	return t
+}
+
+// NewTicker implements Clock.
+func (m *Mock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	t := &mockTimer{clock: m, ch: make(chan time.Time, 1), period: d}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.schedule(t, d)
+	return mockTicker{t}
+}
+
+// Advance moves the time forward by d, firing the timers and tickers that
+// become due in the order of their deadlines. Like time.Ticker, a ticker
+// drops ticks its receiver is not keeping up with.
+func (m *Mock) Advance(d time.Duration) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	end := m.now.Add(d)
+	for {
+		var next *mockTimer
+		for t := range m.timers {
+			if !t.deadline.After(end) && (next == nil || t.deadline.Before(next.deadline)) {
+				next = t
+			}
+		}
+		if next == nil {
+			break
+		}
+		m.now = next.deadline
+		m.fire(next)
+	}
+	m.now = end
+}
+
+// NumTimers returns the number of active timers and tickers. Tests can use
+// it to wait until the code under test is waiting on the clock.
+func (m *Mock) NumTimers() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return len(m.timers)
+}
+
+// schedule arms t to fire after d. A timer with a non-positive d fires
+// immediately. Must be called with the lock held.
+func (m *Mock) schedule(t *mockTimer, d time.Duration) {
+	t.deadline = m.now.Add(d)
+	m.timers[t] = struct{}{}
+	if d <= 0 {
+		m.fire(t)
+	}
+}
+
+// fire sends the current time on t and reschedules it if it is a ticker.
+// Must be called with the lock held.
+func (m *Mock) fire(t *mockTimer) {
+	select {
+	case t.ch <- m.now:
+	default:
+	}
+	if t.period > 0 {
+		t.deadline = t.deadline.Add(t.period)
+	} else {
+		delete(m.timers, t)
+	}
+}
+
+// stop disarms t and returns whether it was active.
+func (m *Mock) stop(t *mockTimer) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	_, active := m.timers[t]
+	delete(m.timers, t)
+	return active
+}
+
+// mockTimer is a timer or, with a period, a ticker of the Mock clock.
+type mockTimer struct {
+	clock    *Mock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration // zero for timers
+}
+
+func (t *mockTimer) C() <-chan time.Time { return t.ch }
+
+func (t *mockTimer) Stop() bool { return t.clock.stop(t) }
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	m := t.clock
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	_, active := m.timers[t]
+	m.schedule(t, d)
+	return active
+}
+
+type mockTicker struct {
+	*mockTimer
+}
+
+func (t mockTicker) Stop() { t.mockTimer.Stop() }
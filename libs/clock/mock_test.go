@@ -0,0 +1,76 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestMockTimer(t *testing.T) {
+	m := NewMock(start)
+	timer := m.NewTimer(time.Second)
+	assert.Equal(t, 1, m.NumTimers())
+
+	m.Advance(999 * time.Millisecond)
+	assertNotFired(t, timer.C())
+
+	m.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-timer.C())
+	assert.Equal(t, 0, m.NumTimers())
+	assert.False(t, timer.Stop())
+
+	// reset an expired timer
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Stop())
+	m.Advance(time.Hour)
+	assertNotFired(t, timer.C())
+
+	// non-positive durations fire immediately
+	assert.False(t, timer.Reset(0))
+	assert.Equal(t, start.Add(time.Hour+time.Second), <-timer.C())
+}
+
+func TestMockTicker(t *testing.T) {
+	m := NewMock(start)
+	ticker := m.NewTicker(time.Second)
+
+	m.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ticker.C())
+
+	// ticks the receiver doesn't keep up with are dropped
+	m.Advance(3 * time.Second)
+	assert.Equal(t, start.Add(2*time.Second), <-ticker.C())
+	assertNotFired(t, ticker.C())
+	assert.Equal(t, start.Add(4*time.Second), m.Now())
+
+	ticker.Stop()
+	m.Advance(time.Hour)
+	assertNotFired(t, ticker.C())
+	assert.Equal(t, 0, m.NumTimers())
+
+	require.Panics(t, func() { m.NewTicker(0) })
+}
+
+func TestMockFiresInDeadlineOrder(t *testing.T) {
+	m := NewMock(start)
+	late := m.NewTimer(2 * time.Second)
+	early := m.NewTimer(time.Second)
+
+	m.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Second), <-early.C())
+	assert.Equal(t, start.Add(2*time.Second), <-late.C())
+	assert.Equal(t, start.Add(time.Minute), m.Now())
+}
+
+func assertNotFired(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+	select {
+	case tm := <-c:
+		t.Fatalf("unexpected fire at %v", tm)
+	default:
+	}
+}
@@ -0,0 +1,74 @@
+package flowrate
+
+import (
+	"time"
+
+	tmclock "github.com/tendermint/tendermint/libs/clock"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// TokenBucket limits the aggregate transfer rate of any number of data
+// streams. Unlike Monitor, which limits a single stream, a TokenBucket is
+// meant to be shared, e.g. by all the connections of a node.
+//
+// The bucket holds up to one second worth of tokens (bytes). Transfers are
+// paid for after the fact: Wait takes the transferred bytes out of the bucket,
+// possibly going into debt, and blocks until the debt is repaid.
+//
+// A nil *TokenBucket imposes no limit.
+type TokenBucket struct {
+	mtx    tmsync.Mutex
+	clock  tmclock.Clock
+	rate   float64 // tokens per second
+	tokens float64 // currently available tokens, negative when in debt
+	last   time.Time
+}
+
+// NewTokenBucket returns a TokenBucket allowing rate bytes per second. If rate
+// is not positive, nil is returned, which imposes no limit.
+func NewTokenBucket(rate int64) *TokenBucket {
+	return newTokenBucket(rate, tmclock.System)
+}
+
+func newTokenBucket(rate int64, clock tmclock.Clock) *TokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	return &TokenBucket{
+		clock:  clock,
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   clock.Now(),
+	}
+}
+
+// Wait takes n tokens out of the bucket and blocks until the bucket is no
+// longer in debt. It is safe for concurrent use.
+func (tb *TokenBucket) Wait(n int) {
+	if tb == nil || n <= 0 {
+		return
+	}
+	if d := tb.take(n); d > 0 {
+		<-tb.clock.NewTimer(d).C()
+	}
+}
+
+// take removes n tokens and returns how long the caller must wait for the
+// bucket to be refilled to zero.
+func (tb *TokenBucket) take(n int) time.Duration {
+	tb.mtx.Lock()
+	defer tb.mtx.Unlock()
+
+	now := tb.clock.Now()
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	if tb.tokens > tb.rate {
+		tb.tokens = tb.rate
+	}
+	tb.last = now
+
+	tb.tokens -= float64(n)
+	if tb.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+}
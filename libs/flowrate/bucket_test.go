@@ -0,0 +1,65 @@
+package flowrate
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tmclock "github.com/tendermint/tendermint/libs/clock"
+)
+
+func TestTokenBucketNil(t *testing.T) {
+	var tb *TokenBucket
+	if NewTokenBucket(0) != nil {
+		t.Fatal("expected a nil bucket for a zero rate")
+	}
+	// must not block
+	tb.Wait(1 << 30)
+}
+
+func TestTokenBucketShared(t *testing.T) {
+	// 1000 B/s with a full bucket: the first 1000 bytes are free, the next
+	// 400 bytes (split among two streams) take 200ms and 400ms.
+	clock := tmclock.NewMock(time.Now())
+	tb := newTokenBucket(1000, clock)
+	tb.Wait(1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tb.Wait(200)
+		}()
+	}
+	for clock.NumTimers() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	clock.Advance(200 * time.Millisecond)
+	if n := clock.NumTimers(); n != 1 {
+		t.Fatalf("expected one stream to be throttled after 200ms, got %d", n)
+	}
+	clock.Advance(200 * time.Millisecond)
+	if n := clock.NumTimers(); n != 0 {
+		t.Fatalf("expected no stream to be throttled after 400ms, got %d", n)
+	}
+	wg.Wait()
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	clock := tmclock.NewMock(time.Now())
+	tb := newTokenBucket(1000, clock)
+
+	if d := tb.take(1500); d != 500*time.Millisecond {
+		t.Fatalf("expected to wait 500ms, got %v", d)
+	}
+	// the bucket holds at most one second worth of tokens
+	clock.Advance(5 * time.Second)
+	if d := tb.take(1000); d != 0 {
+		t.Fatalf("expected not to wait, got %v", d)
+	}
+	if d := tb.take(1); d != time.Millisecond {
+		t.Fatalf("expected to wait 1ms, got %v", d)
+	}
+}
@@ -1,6 +1,9 @@
 package log
 
-import "fmt"
+import (
+	"fmt"
+	"sync/atomic"
+)
 
 type level byte
 
@@ -11,10 +14,30 @@ const (
 )
 
 type filter struct {
-	next             Logger
-	allowed          level            // XOR'd levels for default case
-	initiallyAllowed level            // XOR'd levels for initial case
-	allowedKeyvals   map[keyval]level // When key-value match, use this level
+	next   Logger
+	config *filterConfigHolder
+	// keyvals of the calls to With deriving the filter, in order, from which
+	// its level is computed
+	withs [][]interface{}
+	// *filterLevel, the level computed for the current config
+	level atomic.Value
+}
+
+// filterConfig is the configuration of the levels, set by the options. It is
+// shared by a filter and all the filters derived from it, and replaced as a
+// whole by SetLevels.
+type filterConfig struct {
+	allowed        level            // XOR'd levels for default case
+	allowedKeyvals map[keyval]level // When key-value match, use this level
+}
+
+type filterConfigHolder struct {
+	v atomic.Value // *filterConfig
+}
+
+type filterLevel struct {
+	config  *filterConfig
+	allowed level
 }
 
 type keyval struct {
@@ -22,24 +45,93 @@ type keyval struct {
 	value interface{}
 }
 
+// LevelSetter is implemented by the loggers whose levels can be changed at
+// runtime.
+type LevelSetter interface {
+	// SetLevels replaces the levels with the ones configured by the options,
+	// as given to NewFilter.
+	SetLevels(options ...Option) error
+}
+
+var _ LevelSetter = (*filter)(nil)
+
 // NewFilter wraps next and implements filtering. See the commentary on the
 // Option functions for a detailed description of how to configure levels. If
 // no options are provided, all leveled log events created with Debug, Info or
 // Error helper methods are squelched.
+//
+// The levels of the filter, and of all the loggers derived from it with With,
+// can be changed at runtime with SetLevels.
 func NewFilter(next Logger, options ...Option) Logger {
 	l := &filter{
-		next:           next,
-		allowedKeyvals: make(map[keyval]level),
+		next:   next,
+		config: &filterConfigHolder{},
 	}
+	l.config.v.Store(newFilterConfig(options...))
+	return l
+}
+
+func newFilterConfig(options ...Option) *filterConfig {
+	c := &filterConfig{allowedKeyvals: make(map[keyval]level)}
 	for _, option := range options {
-		option(l)
+		option(c)
 	}
-	l.initiallyAllowed = l.allowed
-	return l
+	return c
+}
+
+// SetLevels implements LevelSetter. The levels change for the filter it was
+// derived from with With, and all the filters derived from the latter.
+func (l *filter) SetLevels(options ...Option) error {
+	l.config.v.Store(newFilterConfig(options...))
+	return nil
+}
+
+// allowed returns the levels allowed by the current config.
+func (l *filter) allowed() level {
+	config := l.config.v.Load().(*filterConfig)
+	if cached, ok := l.level.Load().(*filterLevel); ok && cached.config == config {
+		return cached.allowed
+	}
+
+	allowed := config.allowed
+	for _, keyvals := range l.withs {
+		allowed = config.allowedWith(allowed, keyvals)
+	}
+	l.level.Store(&filterLevel{config: config, allowed: allowed})
+	return allowed
+}
+
+// allowedWith returns the levels allowed for a logger derived with keyvals
+// from a logger allowing the given levels.
+func (c *filterConfig) allowedWith(allowed level, keyvals []interface{}) level {
+	keyInAllowedKeyvals := false
+
+	for i := len(keyvals) - 2; i >= 0; i -= 2 {
+		for kv, kvAllowed := range c.allowedKeyvals {
+			if keyvals[i] == kv.key {
+				keyInAllowedKeyvals = true
+				// Example:
+				//		logger = log.NewFilter(logger, log.AllowError(), log.AllowInfoWith("module", "crypto"))
+				//		logger.With("module", "crypto")
+				if keyvals[i+1] == kv.value {
+					return kvAllowed // set the desired level
+				}
+			}
+		}
+	}
+
+	// Example:
+	//		logger = log.NewFilter(logger, log.AllowError(), log.AllowInfoWith("module", "crypto"))
+	//		logger.With("module", "main")
+	if keyInAllowedKeyvals {
+		return c.allowed // return back to initially allowed
+	}
+
+	return allowed // simply continue with the current level
 }
 
 func (l *filter) Info(msg string, keyvals ...interface{}) {
-	levelAllowed := l.allowed&levelInfo != 0
+	levelAllowed := l.allowed()&levelInfo != 0
 	if !levelAllowed {
 		return
 	}
@@ -47,7 +139,7 @@ func (l *filter) Info(msg string, keyvals ...interface{}) {
 }
 
 func (l *filter) Debug(msg string, keyvals ...interface{}) {
-	levelAllowed := l.allowed&levelDebug != 0
+	levelAllowed := l.allowed()&levelDebug != 0
 	if !levelAllowed {
 		return
 	}
@@ -55,7 +147,7 @@ func (l *filter) Debug(msg string, keyvals ...interface{}) {
 }
 
 func (l *filter) Error(msg string, keyvals ...interface{}) {
-	levelAllowed := l.allowed&levelError != 0
+	levelAllowed := l.allowed()&levelError != 0
 	if !levelAllowed {
 		return
 	}
@@ -83,51 +175,21 @@ func (l *filter) Error(msg string, keyvals ...interface{}) {
 //					log.AllowInfoWith("module", "crypto"), log.AllowNoneWith("user", "Sam"))
 //			 logger.With("user", "Sam").With("module", "crypto").Info("Hello") # produces "I... Hello module=crypto user=Sam"
 func (l *filter) With(keyvals ...interface{}) Logger {
-	keyInAllowedKeyvals := false
-
-	for i := len(keyvals) - 2; i >= 0; i -= 2 {
-		for kv, allowed := range l.allowedKeyvals {
-			if keyvals[i] == kv.key {
-				keyInAllowedKeyvals = true
-				// Example:
-				//		logger = log.NewFilter(logger, log.AllowError(), log.AllowInfoWith("module", "crypto"))
-				//		logger.With("module", "crypto")
-				if keyvals[i+1] == kv.value {
-					return &filter{
-						next:             l.next.With(keyvals...),
-						allowed:          allowed, // set the desired level
-						allowedKeyvals:   l.allowedKeyvals,
-						initiallyAllowed: l.initiallyAllowed,
-					}
-				}
-			}
-		}
-	}
-
-	// Example:
-	//		logger = log.NewFilter(logger, log.AllowError(), log.AllowInfoWith("module", "crypto"))
-	//		logger.With("module", "main")
-	if keyInAllowedKeyvals {
-		return &filter{
-			next:             l.next.With(keyvals...),
-			allowed:          l.initiallyAllowed, // return back to initially allowed
-			allowedKeyvals:   l.allowedKeyvals,
-			initiallyAllowed: l.initiallyAllowed,
-		}
-	}
+	withs := make([][]interface{}, len(l.withs), len(l.withs)+1)
+	copy(withs, l.withs)
+	withs = append(withs, append([]interface{}(nil), keyvals...))
 
 	return &filter{
-		next:             l.next.With(keyvals...),
-		allowed:          l.allowed, // simply continue with the current level
-		allowedKeyvals:   l.allowedKeyvals,
-		initiallyAllowed: l.initiallyAllowed,
+		next:   l.next.With(keyvals...),
+		config: l.config,
+		withs:  withs,
 	}
 }
 
 //--------------------------------------------------------------------------------
 
 // Option sets a parameter for the filter.
-type Option func(*filter)
+type Option func(*filterConfig)
 
 // AllowLevel returns an option for the given level or error if no option exist
 // for such level.
@@ -172,25 +234,25 @@ func AllowNone() Option {
 }
 
 func allowed(allowed level) Option {
-	return func(l *filter) { l.allowed = allowed }
+	return func(c *filterConfig) { c.allowed = allowed }
 }
 
 // AllowDebugWith allows error, info and debug level log events to pass for a specific key value pair.
 func AllowDebugWith(key interface{}, value interface{}) Option {
-	return func(l *filter) { l.allowedKeyvals[keyval{key, value}] = levelError | levelInfo | levelDebug }
+	return func(c *filterConfig) { c.allowedKeyvals[keyval{key, value}] = levelError | levelInfo | levelDebug }
 }
 
 // AllowInfoWith allows error and info level log events to pass for a specific key value pair.
 func AllowInfoWith(key interface{}, value interface{}) Option {
-	return func(l *filter) { l.allowedKeyvals[keyval{key, value}] = levelError | levelInfo }
+	return func(c *filterConfig) { c.allowedKeyvals[keyval{key, value}] = levelError | levelInfo }
 }
 
 // AllowErrorWith allows only error level log events to pass for a specific key value pair.
 func AllowErrorWith(key interface{}, value interface{}) Option {
-	return func(l *filter) { l.allowedKeyvals[keyval{key, value}] = levelError }
+	return func(c *filterConfig) { c.allowedKeyvals[keyval{key, value}] = levelError }
 }
 
 // AllowNoneWith allows no leveled log events to pass for a specific key value pair.
 func AllowNoneWith(key interface{}, value interface{}) Option {
-	return func(l *filter) { l.allowedKeyvals[keyval{key, value}] = 0 }
+	return func(c *filterConfig) { c.allowedKeyvals[keyval{key, value}] = 0 }
 }
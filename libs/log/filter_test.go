@@ -138,3 +138,43 @@ func TestVariousAllowWith(t *testing.T) {
 		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
 	}
 }
+
+func TestFilterSetLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewFilter(log.NewTMJSONLoggerNoTS(&buf), log.AllowError())
+	mempoolLogger := logger.With("module", "mempool")
+	p2pLogger := logger.With("module", "p2p")
+
+	mempoolLogger.Info("foo")
+	p2pLogger.Info("bar")
+	if want, have := ``, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	// the levels change for the loggers derived before and after
+	err := mempoolLogger.(log.LevelSetter).SetLevels(log.AllowError(), log.AllowDebugWith("module", "mempool"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mempoolLogger.Debug("foo")
+	p2pLogger.Info("bar")
+	logger.With("module", "mempool").With("peer", "1").Debug("baz")
+	want := strings.Join([]string{
+		`{"_msg":"foo","level":"debug","module":"mempool"}`,
+		`{"_msg":"baz","level":"debug","module":"mempool","peer":"1"}`,
+	}, "\n")
+	if have := strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	buf.Reset()
+	err = log.NewTracingLogger(logger).(log.LevelSetter).SetLevels(log.AllowInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mempoolLogger.Debug("foo")
+	p2pLogger.Info("bar")
+	if want, have := `{"_msg":"bar","level":"info","module":"p2p"}`, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+}
@@ -44,6 +44,15 @@ func (l *tracingLogger) With(keyvals ...interface{}) Logger {
 	return &tracingLogger{next: l.next.With(formatErrors(keyvals)...)}
 }
 
+// SetLevels implements LevelSetter if the wrapped logger does.
+func (l *tracingLogger) SetLevels(options ...Option) error {
+	ls, ok := l.next.(LevelSetter)
+	if !ok {
+		return fmt.Errorf("logger %T doesn't support setting levels", l.next)
+	}
+	return ls.SetLevels(options...)
+}
+
 func formatErrors(keyvals []interface{}) []interface{} {
 	newKeyvals := make([]interface{}, len(keyvals))
 	copy(newKeyvals, keyvals)
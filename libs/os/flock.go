@@ -0,0 +1,40 @@
+package os
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrFileLocked is returned by LockFile if the file is already locked by
+// another process.
+var ErrFileLocked = errors.New("file is locked by another process")
+
+// FileLock is an exclusive advisory lock held on a file. The lock is released
+// by calling Unlock or when the process exits.
+type FileLock struct {
+	f *os.File
+}
+
+// LockFile acquires an exclusive advisory lock on the file at path without
+// blocking. The file must exist. If another process holds the lock,
+// ErrFileLocked is returned.
+func LockFile(path string) (*FileLock, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &FileLock{f: f}, nil
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	if err := unlockFile(l.f); err != nil {
+		l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
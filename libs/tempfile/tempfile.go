@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -125,5 +126,28 @@ func WriteFileAtomic(filename string, data []byte, perm os.FileMode) (err error)
 	// cannot access the file because it is being used by another process." on windows.
 	f.Close()
 
-	return os.Rename(f.Name(), filename)
+	if err := os.Rename(f.Name(), filename); err != nil {
+		return err
+	}
+	// The rename is only durable once the directory is flushed too, otherwise
+	// a crash may bring back the previous file, e.g. the last signed state of
+	// a private validator.
+	return syncDir(dir)
+}
+
+// syncDir flushes the entries of a directory to disk. It is a no-op on
+// Windows, where directories cannot be opened for syncing.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
 }
@@ -49,6 +49,12 @@ type Mempool interface {
 	// (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// ListTxs returns up to limit transactions from the mempool, skipping the
+	// first offset of them in the given order. If limit is negative, there is
+	// no cap on the number of returned transactions. Listing transactions does
+	// not remove them from the mempool.
+	ListTxs(offset, limit int, order TxOrder) types.Txs
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely
 	// update.
 	Lock()
@@ -98,6 +104,22 @@ type Mempool interface {
 	SizeBytes() int64
 }
 
+// TxOrder is the order in which ListTxs returns transactions.
+type TxOrder uint8
+
+const (
+	// TxOrderDefault lists transactions in the order they would be reaped
+	// for a block.
+	TxOrderDefault TxOrder = iota
+	// TxOrderArrival lists transactions in the order they were added to the
+	// mempool.
+	TxOrderArrival
+	// TxOrderPriority lists transactions by nonincreasing priority, with ties
+	// broken by order of arrival. Mempools without a notion of priority fall
+	// back to order of arrival.
+	TxOrderPriority
+)
+
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
 // transaction if false is returned. An example would be to ensure that a
 // transaction doesn't exceeded the block size.
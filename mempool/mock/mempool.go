@@ -18,9 +18,10 @@ func (Mempool) Size() int { return 0 }
 func (Mempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempool.TxInfo) error {
 	return nil
 }
-func (Mempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
-func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
-func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (Mempool) RemoveTxByKey(txKey types.TxKey) error         { return nil }
+func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs       { return types.Txs{} }
+func (Mempool) ReapMaxTxs(n int) types.Txs                    { return types.Txs{} }
+func (Mempool) ListTxs(_, _ int, _ mempool.TxOrder) types.Txs { return types.Txs{} }
 func (Mempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -14,4 +14,15 @@ type TxInfo struct {
 
 	// SenderP2PID is the actual p2p.ID of the sender, used e.g. for logging.
 	SenderP2PID p2p.ID
+
+	// TraceID identifies the external request (e.g. an RPC call) that
+	// submitted the transaction. It is empty for transactions received from
+	// peers.
+	TraceID string
+}
+
+// Local reports whether the transaction was submitted to this node (e.g. via
+// RPC), rather than received from a peer.
+func (info TxInfo) Local() bool {
+	return info.SenderID == UnknownPeerID
 }
@@ -13,7 +13,6 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/mempool"
-	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
@@ -251,8 +250,12 @@ func (mem *CListMempool) CheckTx(
 		return mempool.ErrTxInCache
 	}
 
-	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
-	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
+	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
+		Tx:      tx,
+		TraceId: txInfo.TraceID,
+		Local:   txInfo.Local(),
+	})
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo, cb))
 
 	return nil
 }
@@ -289,8 +292,7 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 // Used in CheckTx to record PeerID who sent us the tx.
 func (mem *CListMempool) reqResCb(
 	tx []byte,
-	peerID uint16,
-	peerP2PID p2p.ID,
+	txInfo mempool.TxInfo,
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
@@ -299,7 +301,7 @@ func (mem *CListMempool) reqResCb(
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
+		mem.resCbFirstTime(tx, txInfo, res)
 
 		// update metrics
 		mem.metrics.Size.Set(float64(mem.Size()))
@@ -371,8 +373,7 @@ func (mem *CListMempool) isFull(txSize int) error {
 // handled by the resCbRecheck callback.
 func (mem *CListMempool) resCbFirstTime(
 	tx []byte,
-	peerID uint16,
-	peerP2PID p2p.ID,
+	txInfo mempool.TxInfo,
 	res *abci.Response,
 ) {
 	switch r := res.Value.(type) {
@@ -395,8 +396,9 @@ func (mem *CListMempool) resCbFirstTime(
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
+				local:     txInfo.Local(),
 			}
-			memTx.senders.Store(peerID, true)
+			memTx.senders.Store(txInfo.SenderID, true)
 			mem.addTx(memTx)
 			mem.logger.Debug(
 				"added good transaction",
@@ -404,6 +406,7 @@ func (mem *CListMempool) resCbFirstTime(
 				"res", r,
 				"height", memTx.height,
 				"total", mem.Size(),
+				"trace_id", txInfo.TraceID,
 			)
 			mem.notifyTxsAvailable()
 		} else {
@@ -411,9 +414,10 @@ func (mem *CListMempool) resCbFirstTime(
 			mem.logger.Debug(
 				"rejected bad transaction",
 				"tx", types.Tx(tx).Hash(),
-				"peerID", peerP2PID,
+				"peerID", txInfo.SenderP2PID,
 				"res", r,
 				"err", postCheckErr,
+				"trace_id", txInfo.TraceID,
 			)
 			mem.metrics.FailedTxs.Add(1)
 
@@ -575,6 +579,29 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	return txs
 }
 
+// ListTxs returns up to limit transactions after skipping offset, in order
+// of arrival. CListMempool has no notion of priority, so all orders are
+// equivalent.
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ListTxs(offset, limit int, _ mempool.TxOrder) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	if limit < 0 {
+		limit = mem.txs.Len()
+	}
+
+	txs := make([]types.Tx, 0, tmmath.MinInt(tmmath.MaxInt(mem.txs.Len()-offset, 0), limit))
+	i := 0
+	for e := mem.txs.Front(); e != nil && len(txs) < limit; e = e.Next() {
+		if i >= offset {
+			txs = append(txs, e.Value.(*mempoolTx).tx)
+		}
+		i++
+	}
+	return txs
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
@@ -651,8 +678,9 @@ func (mem *CListMempool) recheckTxs() {
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
 		mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
-			Tx:   memTx.tx,
-			Type: abci.CheckTxType_Recheck,
+			Tx:    memTx.tx,
+			Type:  abci.CheckTxType_Recheck,
+			Local: memTx.local,
 		})
 	}
 
@@ -666,6 +694,7 @@ type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
 	tx        types.Tx //
+	local     bool     // whether this tx was submitted to this node
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -23,6 +23,7 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/proxy"
@@ -167,6 +168,20 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+func TestListTxs(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := checkTxs(t, mp, 20, mempool.UnknownPeerID)
+
+	assert.Equal(t, txs, mp.ListTxs(0, -1, mempool.TxOrderArrival))
+	assert.Equal(t, txs[5:10], mp.ListTxs(5, 5, mempool.TxOrderArrival))
+	assert.Equal(t, txs[15:], mp.ListTxs(15, 10, mempool.TxOrderPriority))
+	assert.Empty(t, mp.ListTxs(20, 10, mempool.TxOrderDefault))
+}
+
 func TestMempoolFilters(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -346,6 +361,55 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	}
 }
 
+// originApp records the CheckTx requests by tx.
+type originApp struct {
+	*kvstore.Application
+
+	mtx      tmsync.Mutex
+	requests map[string][]abci.RequestCheckTx
+}
+
+func (app *originApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.mtx.Lock()
+	app.requests[string(req.Tx)] = append(app.requests[string(req.Tx)], req)
+	app.mtx.Unlock()
+	return app.Application.CheckTx(req)
+}
+
+func (app *originApp) Requests(tx string) []abci.RequestCheckTx {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.requests[tx]
+}
+
+func TestMempoolCheckTxOrigin(t *testing.T) {
+	app := &originApp{
+		Application: kvstore.NewApplication(),
+		requests:    make(map[string][]abci.RequestCheckTx),
+	}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+
+	require.NoError(t, mp.CheckTx([]byte("local"), nil, mempool.TxInfo{}))
+	require.NoError(t, mp.CheckTx([]byte("remote"), nil, mempool.TxInfo{SenderID: 1}))
+	require.NoError(t, mp.CheckTx([]byte("committed"), nil, mempool.TxInfo{}))
+
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{[]byte("committed")},
+		abciResponses(1, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+
+	// rechecks keep the origin of the first check
+	for tx, local := range map[string]bool{"local": true, "remote": false} {
+		reqs := app.Requests(tx)
+		require.Len(t, reqs, 2, tx)
+		require.Equal(t, abci.CheckTxType_New, reqs[0].Type)
+		require.Equal(t, local, reqs[0].Local, tx)
+		require.Equal(t, abci.CheckTxType_Recheck, reqs[1].Type)
+		require.Equal(t, local, reqs[1].Local, tx)
+	}
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -183,7 +183,7 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		}
 	default:
 		memR.Logger.Error("unknown message type", "src", e.Src, "chId", e.ChannelID, "msg", e.Message)
-		memR.Switch.StopPeerForError(e.Src, fmt.Errorf("mempool cannot handle message of type: %T", e.Message))
+		memR.Switch.StopPeerForMisbehavior(e.Src, fmt.Errorf("mempool cannot handle message of type: %T", e.Message))
 		return
 	}
 
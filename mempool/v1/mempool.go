@@ -6,13 +6,13 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/creachadair/taskgroup"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/clist"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/proxy"
@@ -40,6 +40,7 @@ type TxMempool struct {
 	proxyAppConn proxy.AppConnMempool
 	metrics      *mempool.Metrics
 	cache        mempool.TxCache // seen transactions
+	clock        clock.Clock     // for transaction timestamps and TTL expiry
 
 	// Atomically-updated fields
 	txsBytes int64 // atomic: the total size of all transactions in the mempool, in bytes
@@ -73,6 +74,7 @@ func NewTxMempool(
 		proxyAppConn: proxyAppConn,
 		metrics:      mempool.NopMetrics(),
 		cache:        mempool.NopTxCache{},
+		clock:        clock.System,
 		txs:          clist.New(),
 		mtx:          new(sync.RWMutex),
 		height:       height,
@@ -109,6 +111,12 @@ func WithMetrics(metrics *mempool.Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// WithClock sets the clock used to timestamp transactions and expire them
+// after TTLDuration.
+func WithClock(c clock.Clock) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.clock = c }
+}
+
 // Lock obtains a write-lock on the mempool. A caller must be sure to explicitly
 // release the lock when finished.
 func (txmp *TxMempool) Lock() { txmp.mtx.Lock() }
@@ -218,7 +226,11 @@ func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo memp
 	}
 
 	// Invoke an ABCI CheckTx for this transaction.
-	rsp, err := txmp.proxyAppConn.CheckTxSync(abci.RequestCheckTx{Tx: tx})
+	rsp, err := txmp.proxyAppConn.CheckTxSync(abci.RequestCheckTx{
+		Tx:      tx,
+		TraceId: txInfo.TraceID,
+		Local:   txInfo.Local(),
+	})
 	if err != nil {
 		txmp.cache.Remove(tx)
 		return err
@@ -226,8 +238,10 @@ func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo memp
 	wtx := &WrappedTx{
 		tx:        tx,
 		hash:      tx.Key(),
-		timestamp: time.Now().UTC(),
+		timestamp: txmp.clock.Now().UTC(),
 		height:    height,
+		traceID:   txInfo.TraceID,
+		local:     txInfo.Local(),
 	}
 	wtx.SetPeer(txInfo.SenderID)
 	txmp.addNewTransaction(wtx, rsp)
@@ -311,6 +325,19 @@ func (txmp *TxMempool) allEntriesSorted() []*WrappedTx {
 	return all
 }
 
+// allEntriesArrival returns a slice of all the transactions currently in the
+// mempool, in order of arrival.
+func (txmp *TxMempool) allEntriesArrival() []*WrappedTx {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	all := make([]*WrappedTx, 0, txmp.txs.Len())
+	for cur := txmp.txs.Front(); cur != nil; cur = cur.Next() {
+		all = append(all, cur.Value.(*WrappedTx))
+	}
+	return all
+}
+
 // ReapMaxBytesMaxGas returns a slice of valid transactions that fit within the
 // size and gas constraints. The results are ordered by nonincreasing priority,
 // with ties broken by increasing order of arrival.  Reaping transactions does
@@ -366,6 +393,32 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 	return keep
 }
 
+// ListTxs returns up to limit transactions from the mempool after skipping
+// offset, ordered as requested. The default order is by priority, matching
+// the order in which transactions are reaped.
+func (txmp *TxMempool) ListTxs(offset, limit int, order mempool.TxOrder) types.Txs {
+	var all []*WrappedTx
+	if order == mempool.TxOrderArrival {
+		all = txmp.allEntriesArrival()
+	} else {
+		all = txmp.allEntriesSorted()
+	}
+
+	if offset >= len(all) {
+		return types.Txs{}
+	}
+	all = all[offset:]
+	if limit >= 0 && limit < len(all) {
+		all = all[:limit]
+	}
+
+	txs := make(types.Txs, len(all))
+	for i, w := range all {
+		txs[i] = w.tx
+	}
+	return txs
+}
+
 // Update removes all the given transactions from the mempool and the cache,
 // and updates the current block height. The blockTxs and deliverTxResponses
 // must have the same length with each response corresponding to the tx at the
@@ -461,6 +514,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 			"peer_id", wtx.peers,
 			"code", checkTxRes.Code,
 			"post_check_err", err,
+			"trace_id", wtx.traceID,
 		)
 
 		txmp.metrics.FailedTxs.Add(1)
@@ -588,6 +642,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 		"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
 		"height", txmp.height,
 		"num_txs", txmp.Size(),
+		"trace_id", wtx.traceID,
 	)
 	txmp.notifyTxsAvailable()
 }
@@ -680,8 +735,9 @@ func (txmp *TxMempool) recheckTransactions() {
 			start(func() error {
 				// The response for this CheckTx is handled by the default recheckTxCallback.
 				rsp, err := txmp.proxyAppConn.CheckTxSync(abci.RequestCheckTx{
-					Tx:   wtx.tx,
-					Type: abci.CheckTxType_Recheck,
+					Tx:    wtx.tx,
+					Type:  abci.CheckTxType_Recheck,
+					Local: wtx.local,
 				})
 				if err != nil {
 					txmp.logger.Error("failed to execute CheckTx during recheck",
@@ -731,7 +787,7 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 		return // nothing to do
 	}
 
-	now := time.Now()
+	now := txmp.clock.Now()
 	cur := txmp.txs.Front()
 	for cur != nil {
 		// N.B. Grab the next element first, since if we remove cur its successor
@@ -19,6 +19,7 @@ import (
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/clock"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/proxy"
@@ -401,6 +402,35 @@ func TestTxMempool_ReapMaxTxs(t *testing.T) {
 	require.Len(t, reapedTxs, len(tTxs)/2)
 }
 
+func TestTxMempool_ListTxs(t *testing.T) {
+	txmp := setup(t, 0)
+	tTxs := checkTxs(t, txmp, 50, 0)
+	require.Equal(t, len(tTxs), txmp.Size())
+
+	// arrival order matches the order in which txs were checked
+	listed := txmp.ListTxs(0, -1, mempool.TxOrderArrival)
+	require.Len(t, listed, len(tTxs))
+	for i, tTx := range tTxs {
+		require.Equal(t, tTx.tx, listed[i])
+	}
+
+	// paging in arrival order
+	listed = txmp.ListTxs(10, 5, mempool.TxOrderArrival)
+	require.Len(t, listed, 5)
+	for i, tx := range listed {
+		require.Equal(t, tTxs[10+i].tx, tx)
+	}
+
+	// priority and default order match the reap order
+	reaped := txmp.ReapMaxTxs(-1)
+	require.Equal(t, reaped, txmp.ListTxs(0, -1, mempool.TxOrderPriority))
+	require.Equal(t, reaped[20:30], txmp.ListTxs(20, 10, mempool.TxOrderDefault))
+
+	// offsets past the end yield no txs
+	require.Empty(t, txmp.ListTxs(len(tTxs), 10, mempool.TxOrderPriority))
+	require.Empty(t, txmp.ListTxs(len(tTxs)+1, -1, mempool.TxOrderArrival))
+}
+
 func TestTxMempool_CheckTxExceedsMaxSize(t *testing.T) {
 	txmp := setup(t, 0)
 
@@ -433,6 +463,62 @@ func TestTxMempool_CheckTxSamePeer(t *testing.T) {
 	require.Error(t, txmp.CheckTx(tx, nil, mempool.TxInfo{SenderID: peerID}))
 }
 
+// originApp records the origin of the CheckTx requests by tx.
+type originApp struct {
+	*application
+
+	mtx      sync.Mutex
+	requests map[string][]abci.RequestCheckTx
+}
+
+func (app *originApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.mtx.Lock()
+	app.requests[string(req.Tx)] = append(app.requests[string(req.Tx)], req)
+	app.mtx.Unlock()
+	return app.application.CheckTx(req)
+}
+
+func (app *originApp) Requests(tx string) []abci.RequestCheckTx {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.requests[tx]
+}
+
+func TestTxMempool_CheckTxOrigin(t *testing.T) {
+	app := &originApp{
+		application: &application{kvstore.NewApplication()},
+		requests:    make(map[string][]abci.RequestCheckTx),
+	}
+	cfg := config.ResetTestRoot(t.Name())
+	appConnMem, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() {
+		os.RemoveAll(cfg.RootDir)
+		require.NoError(t, appConnMem.Stop())
+	})
+	txmp := NewTxMempool(log.TestingLogger(), cfg.Mempool, appConnMem, 0)
+
+	require.NoError(t, txmp.CheckTx([]byte("local=1=10"), nil, mempool.TxInfo{}))
+	require.NoError(t, txmp.CheckTx([]byte("remote=1=10"), nil, mempool.TxInfo{SenderID: 1}))
+	require.NoError(t, txmp.CheckTx([]byte("committed=1=10"), nil, mempool.TxInfo{}))
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, types.Txs{[]byte("committed=1=10")},
+		[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}}, nil, nil))
+	txmp.Unlock()
+
+	// rechecks keep the origin of the first check
+	for tx, local := range map[string]bool{"local=1=10": true, "remote=1=10": false} {
+		require.Eventually(t, func() bool { return len(app.Requests(tx)) == 2 }, time.Second, 10*time.Millisecond)
+		reqs := app.Requests(tx)
+		require.Equal(t, abci.CheckTxType_New, reqs[0].Type)
+		require.Equal(t, local, reqs[0].Local, tx)
+		require.Equal(t, abci.CheckTxType_Recheck, reqs[1].Type)
+		require.Equal(t, local, reqs[1].Local, tx)
+	}
+}
+
 func TestTxMempool_CheckTxSameSender(t *testing.T) {
 	txmp := setup(t, 100)
 	peerID := uint16(1)
@@ -520,7 +606,8 @@ func TestTxMempool_ConcurrentTxs(t *testing.T) {
 }
 
 func TestTxMempool_ExpiredTxs_Timestamp(t *testing.T) {
-	txmp := setup(t, 5000)
+	clk := clock.NewMock(time.Now())
+	txmp := setup(t, 5000, WithClock(clk))
 	txmp.config.TTLDuration = 5 * time.Millisecond
 
 	added1 := checkTxs(t, txmp, 10, 0)
@@ -535,14 +622,11 @@ func TestTxMempool_ExpiredTxs_Timestamp(t *testing.T) {
 	//     |           |       +------ first batch expires
 	//     |           +-------------- second batch added
 	//     +-------------------------- first batch added
-	//
-	// The exact intervals are not important except that the delta should be
-	// large relative to the cost of CheckTx (ms vs. ns is fine here).
-	time.Sleep(3 * time.Millisecond)
+	clk.Advance(3 * time.Millisecond)
 	added2 := checkTxs(t, txmp, 10, 1)
 
 	// Wait a while longer, so that the first batch will expire.
-	time.Sleep(3 * time.Millisecond)
+	clk.Advance(3 * time.Millisecond)
 
 	// Trigger an update so that pruning will occur.
 	txmp.Lock()
@@ -14,6 +14,8 @@ type WrappedTx struct {
 	hash      types.TxKey // the transaction hash
 	height    int64       // height when this transaction was initially checked (for expiry)
 	timestamp time.Time   // time when transaction was entered (for TTL)
+	traceID   string      // ID of the external request that submitted this transaction
+	local     bool        // whether this transaction was submitted to this node
 
 	mtx       sync.Mutex
 	gasWanted int64           // app: gas required to execute this transaction
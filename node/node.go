@@ -3,10 +3,13 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	bc "github.com/tendermint/tendermint/blockchain"
 	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
 	bcv1 "github.com/tendermint/tendermint/blockchain/v1"
 	bcv2 "github.com/tendermint/tendermint/blockchain/v2"
@@ -28,12 +32,14 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/light"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
 	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
+	"github.com/tendermint/tendermint/p2p/upnp"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	rpccore "github.com/tendermint/tendermint/rpc/core"
@@ -78,6 +84,67 @@ func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
 }
 
+// SharedDBProvider returns a DBProvider which opens a single DB, named name,
+// with DefaultDBProvider, and returns a view of it scoped to the prefix
+// "<ID>/" for each DBContext, so that the stores of the node share one
+// physical DB rather than opening one each. The DB is closed once all of the
+// views are.
+//
+// The commands opening the DBs of a node, such as migrate and rollback, open
+// the shared DB with --shared-db.
+//
+// NOTE: the data of the DBs opened by DefaultDBProvider is not moved to the
+// shared DB, so switching provider requires resetting the node.
+func SharedDBProvider(name string) DBProvider {
+	var (
+		mtx  tmsync.Mutex
+		db   dbm.DB
+		refs int
+	)
+	return func(ctx *DBContext) (dbm.DB, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if refs == 0 {
+			var err error
+			db, err = DefaultDBProvider(&DBContext{ID: name, Config: ctx.Config})
+			if err != nil {
+				return nil, err
+			}
+		}
+		refs++
+
+		shared, closed := db, false
+		return &sharedDB{
+			PrefixDB: dbm.NewPrefixDB(shared, []byte(ctx.ID+"/")),
+			close: func() error {
+				mtx.Lock()
+				defer mtx.Unlock()
+				if closed {
+					return nil
+				}
+				closed = true
+				refs--
+				if refs > 0 {
+					return nil
+				}
+				return shared.Close()
+			},
+		}, nil
+	}
+}
+
+// sharedDB is a view of a DB shared by SharedDBProvider.
+type sharedDB struct {
+	*dbm.PrefixDB
+	close func() error
+}
+
+// Close implements dbm.DB, closing the shared DB if this is its last view.
+func (db *sharedDB) Close() error {
+	return db.close()
+}
+
 // GenesisDocProvider returns a GenesisDoc.
 // It allows the GenesisDoc to be pulled from sources other than the
 // filesystem, for instance from a distributed key-value store cluster.
@@ -103,8 +170,18 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	// only validators need a private validator
+	var pv types.PrivValidator
+	if config.IsValidatorMode() {
+		filePV := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+		if err := filePV.Lock(); err != nil {
+			return nil, err
+		}
+		pv = filePV
+	}
+
 	return NewNode(config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		pv,
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
@@ -144,7 +221,8 @@ type fastSyncReactor interface {
 // the node's Switch.
 //
 // WARNING: using any name from the below list of the existing reactors will
-// result in replacing it with the custom one.
+// result in replacing it with the custom one. The channels of the replaced
+// reactor are no longer advertised to peers.
 //
 //   - MEMPOOL
 //   - BLOCKCHAIN
@@ -159,6 +237,18 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 				n.sw.Logger.Info("Replacing existing reactor with a custom one",
 					"name", name, "existing", existingReactor, "custom", reactor)
 				n.sw.RemoveReactor(name, existingReactor)
+				// stop advertising the channels of the replaced reactor, peers
+				// sending on them would be disconnected
+				if ni, ok := n.nodeInfo.(p2p.DefaultNodeInfo); ok {
+					channels := make([]byte, 0, len(ni.Channels))
+					for _, chID := range ni.Channels {
+						if !hasChannel(existingReactor, chID) {
+							channels = append(channels, chID)
+						}
+					}
+					ni.Channels = channels
+					n.nodeInfo = ni
+				}
 			}
 			n.sw.AddReactor(name, reactor)
 			// register the new channels to the nodeInfo
@@ -169,7 +259,6 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 				for _, chDesc := range reactor.GetChannels() {
 					if !ni.HasChannel(chDesc.ID) {
 						ni.Channels = append(ni.Channels, chDesc.ID)
-						n.transport.AddChannel(chDesc.ID)
 					}
 				}
 				n.nodeInfo = ni
@@ -177,9 +266,20 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 				n.Logger.Error("Node info is not of type DefaultNodeInfo. Custom reactor channels can not be added.")
 			}
 		}
+		n.sw.SetNodeInfo(n.nodeInfo)
+		n.transport.SetNodeInfo(n.nodeInfo)
 	}
 }
 
+func hasChannel(reactor p2p.Reactor, chID byte) bool {
+	for _, chDesc := range reactor.GetChannels() {
+		if chDesc.ID == chID {
+			return true
+		}
+	}
+	return false
+}
+
 // StateProvider overrides the state provider used by state sync to retrieve trusted app hashes and
 // build a State object for bootstrapping the node.
 // WARNING: this interface is considered unstable and subject to change.
@@ -189,6 +289,17 @@ func StateProvider(stateProvider statesync.StateProvider) Option {
 	}
 }
 
+// OnSwitchToConsensus sets a function to call once the node runs consensus,
+// with the height of the last block applied before consensus took over. If
+// the node fast syncs or state syncs, this is when the sync finishes;
+// otherwise it is when the node starts. Embedders can use it to start services
+// that need consensus to be live. The function should not block.
+func OnSwitchToConsensus(fn func(height int64)) Option {
+	return func(n *Node) {
+		n.consensusReactor.SetSwitchToConsensusCallback(fn)
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // Node is the highest level interface to a full Tendermint node.
@@ -199,7 +310,8 @@ type Node struct {
 	// config
 	config        *cfg.Config
 	genesisDoc    *types.GenesisDoc   // initial validator set
-	privValidator types.PrivValidator // local node's validator key
+	privValidator types.PrivValidator // local node's validator key, nil unless in validator mode
+	pubKey        crypto.PubKey       // public key of privValidator, nil unless in validator mode
 
 	// network
 	transport   *p2p.MultiplexTransport
@@ -207,12 +319,16 @@ type Node struct {
 	addrBook    pex.AddrBook // known peers
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
+	natMtx      tmsync.Mutex
+	nat         upnp.NAT      // gateway forwarding the p2p port, if upnp is on
+	natDone     chan struct{} // closed once the p2p port is mapped or failed to
 	isListening bool
 
 	// services
 	eventBus          *types.EventBus // pub/sub for services
 	stateStore        sm.Store
 	blockStore        *store.BlockStore // store the blockchain to disk
+	blockPartsDB      dbm.DB            // where big proposal blocks spill (nil if disabled)
 	bcReactor         p2p.Reactor       // for fast-syncing
 	mempoolReactor    p2p.Reactor       // for gossipping transactions
 	mempool           mempl.Mempool
@@ -232,19 +348,32 @@ type Node struct {
 	prometheusSrv     *http.Server
 }
 
-func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
+// InitDBs opens the block store and the state DB of a node with dbProvider,
+// bringing them to the schema versions of this binary, as the node does when
+// it starts. Commands working on the stores of a stopped node use it, so that
+// they read the same data as the node.
+func InitDBs(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	logger log.Logger,
+) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
 	var blockStoreDB dbm.DB
 	blockStoreDB, err = dbProvider(&DBContext{"blockstore", config})
 	if err != nil {
 		return
 	}
+	// bring the block store DB to the schema version of this binary
+	if err = store.Migrate(blockStoreDB, logger.With("module", "store")); err != nil {
+		return
+	}
 	blockStore = store.NewBlockStore(blockStoreDB)
 
 	stateDB, err = dbProvider(&DBContext{"state", config})
 	if err != nil {
 		return
 	}
-
+	// bring the state DB to the schema version of this binary
+	err = sm.Migrate(stateDB, logger.With("module", "state"))
 	return
 }
 
@@ -285,8 +414,11 @@ func createAndStartIndexerService(
 			return nil, nil, nil, err
 		}
 
-		txIndexer = kv.NewTxIndex(store)
-		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+		txIdx := kv.NewTxIndex(store)
+		txIdx.SetSyncWrites(config.TxIndex.SyncWrites)
+		blockIdx := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+		blockIdx.SetSyncWrites(config.TxIndex.SyncWrites)
+		txIndexer, blockIndexer = txIdx, blockIdx
 
 	case "psql":
 		if config.TxIndex.PsqlConn == "" {
@@ -348,6 +480,11 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 		)
 	}
 
+	if pubKey == nil {
+		consensusLogger.Info("This node is not a validator (no private validator)")
+		return
+	}
+
 	addr := pubKey.Address()
 	// Log whether this node is a validator or an observer
 	if state.Validators.HasAddress(addr) {
@@ -358,7 +495,7 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 }
 
 func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
-	if state.Validators.Size() > 1 {
+	if pubKey == nil || state.Validators.Size() > 1 {
 		return false
 	}
 	addr, _ := state.Validators.GetByIndex(0)
@@ -451,7 +588,8 @@ func createBlockchainReactor(config *cfg.Config,
 	case "v0":
 		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
 	case "v1":
-		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync,
+			bcv1.WithMaxPendingRequests(config.FastSync.MaxPendingRequests))
 	case "v2":
 		bcReactor = bcv2.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
 	default:
@@ -473,6 +611,7 @@ func createConsensusReactor(config *cfg.Config,
 	waitSync bool,
 	eventBus *types.EventBus,
 	consensusLogger log.Logger,
+	options ...cs.StateOption,
 ) (*cs.Reactor, *cs.State) {
 	consensusState := cs.NewState(
 		config.Consensus,
@@ -481,10 +620,10 @@ func createConsensusReactor(config *cfg.Config,
 		blockStore,
 		mempool,
 		evidencePool,
-		cs.StateMetrics(csMetrics),
+		append([]cs.StateOption{cs.StateMetrics(csMetrics)}, options...)...,
 	)
 	consensusState.SetLogger(consensusLogger)
-	if privValidator != nil {
+	if config.IsValidatorMode() {
 		consensusState.SetPrivValidator(privValidator)
 	}
 	consensusReactor := cs.NewReactor(consensusState, waitSync, cs.ReactorMetrics(csMetrics))
@@ -518,41 +657,8 @@ func createTransport(
 	// Filter peers by addr or pubkey with an ABCI query.
 	// If the query return code is OK, add peer.
 	if config.FilterPeers {
-		connFilters = append(
-			connFilters,
-			// ABCI query for address filtering.
-			func(_ p2p.ConnSet, c net.Conn, _ []net.IP) error {
-				res, err := proxyApp.Query().QuerySync(abci.RequestQuery{
-					Path: fmt.Sprintf("/p2p/filter/addr/%s", c.RemoteAddr().String()),
-				})
-				if err != nil {
-					return err
-				}
-				if res.IsErr() {
-					return fmt.Errorf("error querying abci app: %v", res)
-				}
-
-				return nil
-			},
-		)
-
-		peerFilters = append(
-			peerFilters,
-			// ABCI query for ID filtering.
-			func(_ p2p.IPeerSet, p p2p.Peer) error {
-				res, err := proxyApp.Query().QuerySync(abci.RequestQuery{
-					Path: fmt.Sprintf("/p2p/filter/id/%s", p.ID()),
-				})
-				if err != nil {
-					return err
-				}
-				if res.IsErr() {
-					return fmt.Errorf("error querying abci app: %v", res)
-				}
-
-				return nil
-			},
-		)
+		connFilters = append(connFilters, abciAddrFilter(proxyApp.Query()))
+		peerFilters = append(peerFilters, abciIDFilter(proxyApp.Query()))
 	}
 
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
@@ -564,6 +670,55 @@ func createTransport(
 	return transport, peerFilters
 }
 
+// abciAddrFilter returns a connection filter, which rejects connections
+// unless the app answers the query "/p2p/filter/addr/<IP:PORT>" with an OK
+// code.
+func abciAddrFilter(query proxy.AppConnQuery) p2p.ConnFilterFunc {
+	return func(_ p2p.ConnSet, c net.Conn, _ []net.IP) error {
+		return abciFilterQuery(query, fmt.Sprintf("/p2p/filter/addr/%s", c.RemoteAddr().String()))
+	}
+}
+
+// abciIDFilter returns a peer filter, which rejects peers unless the app
+// answers the query "/p2p/filter/id/<ID>" with an OK code.
+func abciIDFilter(query proxy.AppConnQuery) p2p.PeerFilterFunc {
+	return func(_ p2p.IPeerSet, p p2p.Peer) error {
+		return abciFilterQuery(query, fmt.Sprintf("/p2p/filter/id/%s", p.ID()))
+	}
+}
+
+// consensusChannelFilter returns a peer filter which, while the node is a
+// validator, rejects the inbound peers without the consensus channels, e.g.
+// seed nodes crawling the network, so that they don't take the inbound slots
+// of the validator. The outbound peers, which are dialed on purpose (seeds,
+// persistent peers), are accepted.
+func consensusChannelFilter(consensusState *cs.State, pubKey crypto.PubKey) p2p.PeerFilterFunc {
+	return func(_ p2p.IPeerSet, p p2p.Peer) error {
+		if p.IsOutbound() {
+			return nil
+		}
+		ni, ok := p.NodeInfo().(p2p.DefaultNodeInfo)
+		if !ok || (ni.HasChannel(cs.StateChannel) && ni.HasChannel(cs.VoteChannel)) {
+			return nil
+		}
+		if consensusState.IsValidator(pubKey.Address()) {
+			return errors.New("peer has no consensus channels, but we are a validator")
+		}
+		return nil
+	}
+}
+
+func abciFilterQuery(query proxy.AppConnQuery, path string) error {
+	res, err := query.QuerySync(abci.RequestQuery{Path: path})
+	if err != nil {
+		return err
+	}
+	if res.IsErr() {
+		return fmt.Errorf("error querying abci app: %v", res)
+	}
+	return nil
+}
+
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
 	p2pMetrics *p2p.Metrics,
@@ -631,7 +786,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 	pexReactor := pex.NewReactor(addrBook,
 		&pex.ReactorConfig{
 			Seeds:    splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
-			SeedMode: config.P2P.SeedMode,
+			SeedMode: config.P2P.SeedMode || config.Mode == cfg.ModeSeed,
 			// See consensus/reactor.go: blocksToContributeToBecomeGoodPeer 10000
 			// blocks assuming 10s blocks ~ 28 hours.
 			// TODO (melekes): make it dynamic based on the actual block latencies
@@ -713,7 +868,7 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option,
 ) (*Node, error) {
-	blockStore, stateDB, err := initDBs(config, dbProvider)
+	blockStore, stateDB, err := InitDBs(config, dbProvider, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -748,19 +903,30 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
-	if config.PrivValidatorListenAddr != "" {
-		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
-		if err != nil {
-			return nil, fmt.Errorf("error with private validator socket client: %w", err)
+	// The mode decides whether the node signs: from here on, privValidator and
+	// pubKey are nil unless it is a validator.
+	var pubKey crypto.PubKey
+	if config.IsValidatorMode() {
+		// If an address is provided, listen on the socket for a connection from an
+		// external signing process.
+		if config.PrivValidatorListenAddr != "" {
+			// FIXME: we should start services inside OnStart
+			privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+			if err != nil {
+				return nil, fmt.Errorf("error with private validator socket client: %w", err)
+			}
+		}
+		if privValidator == nil {
+			return nil, errors.New("a validator node needs a private validator")
 		}
-	}
 
-	pubKey, err := privValidator.GetPubKey()
-	if err != nil {
-		return nil, fmt.Errorf("can't get pubkey: %w", err)
+		pubKey, err = privValidator.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("can't get pubkey: %w", err)
+		}
+	} else {
+		// full and seed nodes never sign
+		privValidator = nil
 	}
 
 	// Determine whether we should attempt state sync.
@@ -812,6 +978,10 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evidencePool,
 		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithMaxInFlightDeliverTxs(config.MaxInFlightDeliverTxs),
+		// shared with consensus, which fills it with the votes it verifies
+		sm.BlockExecutorWithSignatureCache(types.NewSignatureCache(
+			config.Consensus.VoteSignatureCacheSize, config.Consensus.VoteSignatureCacheBytes)),
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
@@ -827,9 +997,21 @@ func NewNode(config *cfg.Config,
 	} else if fastSync {
 		csMetrics.FastSyncing.Set(1)
 	}
+	var (
+		blockPartsDB     dbm.DB
+		consensusOptions []cs.StateOption
+	)
+	if config.Consensus.BlockPartsMemoryBudget > 0 {
+		blockPartsDB, err = dbProvider(&DBContext{"blockparts", config})
+		if err != nil {
+			return nil, err
+		}
+		consensusOptions = append(consensusOptions, cs.StateBlockPartSpill(blockPartsDB))
+	}
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
 		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
+		consensusOptions...,
 	)
 
 	// Set up state sync reactor, and schedule a sync if requested.
@@ -851,6 +1033,9 @@ func NewNode(config *cfg.Config,
 
 	// Setup Transport.
 	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	if pubKey != nil {
+		peerFilters = append(peerFilters, consensusChannelFilter(consensusState, pubKey))
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
@@ -903,6 +1088,7 @@ func NewNode(config *cfg.Config,
 		config:        config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
+		pubKey:        pubKey,
 
 		transport: transport,
 		sw:        sw,
@@ -912,6 +1098,7 @@ func NewNode(config *cfg.Config,
 
 		stateStore:       stateStore,
 		blockStore:       blockStore,
+		blockPartsDB:     blockPartsDB,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
@@ -969,6 +1156,15 @@ func (n *Node) OnStart() error {
 	if err != nil {
 		return err
 	}
+	if n.config.P2P.UPNP && n.config.P2P.ExternalAddress == "" {
+		// discovering the gateway can take several seconds, peers meanwhile
+		// learn the listen address
+		n.natDone = make(chan struct{})
+		go func() {
+			defer close(n.natDone)
+			n.mapUPNPPort(addr)
+		}()
+	}
 	if err := n.transport.Listen(*addr); err != nil {
 		return err
 	}
@@ -1026,6 +1222,16 @@ func (n *Node) OnStop() {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
 
+	n.natMtx.Lock()
+	if n.nat != nil {
+		port := int(n.transport.NetAddress().Port)
+		if err := n.nat.DeletePortMapping("tcp", port, port); err != nil {
+			n.Logger.Error("Error deleting UPnP port mapping", "err", err)
+		}
+		n.nat = nil
+	}
+	n.natMtx.Unlock()
+
 	n.isListening = false
 
 	// finally stop the listeners / external services
@@ -1041,6 +1247,11 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
 	}
+	if fpv, ok := n.privValidator.(*privval.FilePV); ok {
+		if err := fpv.Unlock(); err != nil {
+			n.Logger.Error("Error unlocking private validator key file", "err", err)
+		}
+	}
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -1058,15 +1269,52 @@ func (n *Node) OnStop() {
 			n.Logger.Error("problem closing statestore", "err", err)
 		}
 	}
+	if n.blockPartsDB != nil {
+		if err := n.blockPartsDB.Close(); err != nil {
+			n.Logger.Error("problem closing blockparts DB", "err", err)
+		}
+	}
 }
 
-// ConfigureRPC makes sure RPC has all the objects it needs to operate.
-func (n *Node) ConfigureRPC() error {
-	pubKey, err := n.privValidator.GetPubKey()
+// mapUPNPPort forwards the p2p port on the internet gateway and advertises the
+// gateway's external address to peers instead of the listen address. Failing
+// to do so is not fatal, the node then only accepts peers which can reach the
+// listen address.
+func (n *Node) mapUPNPPort(laddr *p2p.NetAddress) {
+	port := int(laddr.Port)
+	nat, ext, err := upnp.MapPort(port, port, "Tendermint")
 	if err != nil {
-		return fmt.Errorf("can't get pubkey: %w", err)
+		n.Logger.Error("Failed to map p2p port with UPnP", "port", port, "err", err)
+		return
+	}
+
+	n.natMtx.Lock()
+	defer n.natMtx.Unlock()
+	// the node was stopped while the port was being mapped
+	if !n.IsRunning() {
+		if err := nat.DeletePortMapping("tcp", port, port); err != nil {
+			n.Logger.Error("Error deleting UPnP port mapping", "err", err)
+		}
+		return
+	}
+	n.nat = nat
+
+	extAddr := p2p.NewNetAddressIPPort(ext, laddr.Port)
+	extAddr.ID = n.nodeKey.ID()
+	n.addrBook.AddOurAddress(extAddr)
+
+	if ni, ok := n.sw.NodeInfo().(p2p.DefaultNodeInfo); ok {
+		ni.ListenAddr = extAddr.DialString()
+		n.sw.SetNodeInfo(ni)
+		n.transport.SetNodeInfo(ni)
 	}
-	rpccore.SetEnvironment(&rpccore.Environment{
+
+	n.Logger.Info("Mapped p2p port with UPnP", "external_address", extAddr)
+}
+
+// ConfigureRPC makes sure RPC has all the objects it needs to operate.
+func (n *Node) ConfigureRPC() error {
+	env := &rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
 
@@ -1076,8 +1324,9 @@ func (n *Node) ConfigureRPC() error {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		AddrBook:       n.addrBook,
 
-		PubKey:           pubKey,
+		PubKey:           n.pubKey,
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
 		BlockIndexer:     n.blockIndexer,
@@ -1088,7 +1337,11 @@ func (n *Node) ConfigureRPC() error {
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
-	})
+	}
+	if fs, ok := n.bcReactor.(interface{ SyncStatus() bc.SyncStatus }); ok {
+		env.FastSync = fs
+	}
+	rpccore.SetEnvironment(env)
 	if err := rpccore.InitGenesisChunks(); err != nil {
 		return err
 	}
@@ -1118,6 +1371,19 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
+	// Client certificates are requested, but only required by the unsafe
+	// endpoints, see rpccore.authorizeUnsafe.
+	if n.config.RPC.TLSClientCAFile != "" {
+		clientCAs, err := loadCertPool(n.config.RPC.ClientCAFile())
+		if err != nil {
+			return nil, err
+		}
+		config.TLSConfig = &tls.Config{
+			ClientCAs:  clientCAs,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
 
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
@@ -1134,6 +1400,9 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
+			rpcserver.ReadWait(n.config.RPC.WebSocketReadTimeout),
+			rpcserver.PingPeriod(n.config.RPC.WebSocketReadTimeout*9/10),
+			rpcserver.WriteWait(n.config.RPC.WebSocketWriteTimeout),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
@@ -1214,6 +1483,19 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// loadCertPool reads the PEM encoded certificates in the given file.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificates from %s: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return nil, fmt.Errorf("no valid certificates found in %s", path)
+	}
+	return pool, nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer(addr string) *http.Server {
@@ -1281,7 +1563,8 @@ func (n *Node) EventBus() *types.EventBus {
 	return n.eventBus
 }
 
-// PrivValidator returns the Node's PrivValidator.
+// PrivValidator returns the Node's PrivValidator, or nil unless it is in
+// validator mode.
 // XXX: for convenience only!
 func (n *Node) PrivValidator() types.PrivValidator {
 	return n.privValidator
@@ -1316,7 +1599,7 @@ func (n *Node) IsListening() bool {
 
 // NodeInfo returns the Node's Info from the Switch.
 func (n *Node) NodeInfo() p2p.NodeInfo {
-	return n.nodeInfo
+	return n.sw.NodeInfo()
 }
 
 func makeNodeInfo(
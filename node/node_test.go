@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
@@ -15,7 +17,10 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
 	cfg "github.com/tendermint/tendermint/config"
+	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/libs/log"
@@ -26,6 +31,7 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/conn"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -77,6 +83,91 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeRPCListeners(t *testing.T) {
+	config := cfg.ResetTestRoot("node_rpc_listeners_test")
+	defer os.RemoveAll(config.RootDir)
+	sock := filepath.Join(config.RootDir, "rpc.sock")
+	config.RPC.ListenAddress = "tcp://127.0.0.1:0, unix://" + sock
+	config.RPC.GRPCListenAddress = ""
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	require.Len(t, n.rpcListeners, 2)
+	assert.Equal(t, "tcp", n.rpcListeners[0].Addr().Network())
+	assert.Equal(t, "unix", n.rpcListeners[1].Addr().Network())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, "unix", sock)
+		},
+	}}
+	resp, err := client.Get("http://unix/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNodeUPNPUnavailable(t *testing.T) {
+	config := cfg.ResetTestRoot("node_upnp_test")
+	defer os.RemoveAll(config.RootDir)
+	config.P2P.UPNP = true
+
+	// without a gateway the node still starts and advertises its listen address
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+	// the port is mapped in the background, the node is listening already
+	assert.True(t, n.IsListening())
+
+	<-n.natDone
+	n.natMtx.Lock()
+	found := n.nat != nil
+	n.natMtx.Unlock()
+	if found {
+		t.Skip("found a UPnP gateway")
+	}
+	assert.Equal(t, config.P2P.ListenAddress, n.NodeInfo().(p2p.DefaultNodeInfo).ListenAddr)
+}
+
+func TestNodeFullMode(t *testing.T) {
+	config := cfg.ResetTestRoot("node_full_mode_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Mode = cfg.ModeFull
+
+	// the private validator is neither loaded nor locked
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Nil(t, n.PrivValidator())
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, pv.Lock())
+	require.NoError(t, pv.Unlock())
+
+	require.NoError(t, n.Start())
+	require.NoError(t, n.Stop())
+}
+
+func TestNodeValidatorModeNeedsPrivValidator(t *testing.T) {
+	config := cfg.ResetTestRoot("node_validator_mode_test")
+	defer os.RemoveAll(config.RootDir)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	_, err = NewNode(config,
+		nil,
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+	)
+	assert.ErrorContains(t, err, "needs a private validator")
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -220,6 +311,85 @@ func testFreeAddr(t *testing.T) string {
 	return fmt.Sprintf("127.0.0.1:%d", ln.Addr().(*net.TCPAddr).Port)
 }
 
+type filterApp struct {
+	abci.BaseApplication
+	rejected map[string]bool
+}
+
+func (app *filterApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if app.rejected[req.Path] {
+		return abci.ResponseQuery{Code: 1}
+	}
+	return abci.ResponseQuery{}
+}
+
+func TestABCIPeerFilters(t *testing.T) {
+	peer := p2pmock.NewPeer(net.IP{127, 0, 0, 1})
+	app := &filterApp{rejected: map[string]bool{
+		"/p2p/filter/id/" + string(peer.ID()): true,
+		"/p2p/filter/addr/127.0.0.1:1234":     true,
+	}}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+
+	idFilter := abciIDFilter(proxyApp.Query())
+	assert.Error(t, idFilter(nil, peer))
+	assert.NoError(t, idFilter(nil, p2pmock.NewPeer(net.IP{127, 0, 0, 1})))
+
+	addrFilter := abciAddrFilter(proxyApp.Query())
+	conn := func(port int) net.Conn {
+		return &filterConn{remote: &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: port}}
+	}
+	assert.Error(t, addrFilter(nil, conn(1234), nil))
+	assert.NoError(t, addrFilter(nil, conn(4321), nil))
+}
+
+type filterConn struct {
+	net.Conn
+	remote net.Addr
+}
+
+func (c *filterConn) RemoteAddr() net.Addr { return c.remote }
+
+type channelsPeer struct {
+	*p2pmock.Peer
+	channels []byte
+}
+
+func (p channelsPeer) NodeInfo() p2p.NodeInfo {
+	ni := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	ni.Channels = p.channels
+	return ni
+}
+
+func TestConsensusChannelFilter(t *testing.T) {
+	config := cfg.ResetTestRoot("node_consensus_channel_filter_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	pubKey, err := n.PrivValidator().GetPubKey()
+	require.NoError(t, err)
+
+	seed := channelsPeer{Peer: p2pmock.NewPeer(nil), channels: []byte{pex.PexChannel}}
+	full := channelsPeer{
+		Peer:     p2pmock.NewPeer(nil),
+		channels: []byte{pex.PexChannel, cs.StateChannel, cs.DataChannel, cs.VoteChannel},
+	}
+
+	// we are the only validator
+	filter := consensusChannelFilter(n.ConsensusState(), pubKey)
+	assert.Error(t, filter(nil, seed))
+	assert.NoError(t, filter(nil, full))
+	seed.Outbound = true
+	assert.NoError(t, filter(nil, seed))
+
+	seed.Outbound = false
+	filter = consensusChannelFilter(n.ConsensusState(), ed25519.GenPrivKey().PubKey())
+	assert.NoError(t, filter(nil, seed))
+}
+
 // create a proposal block using real and full
 // mempool and evidence pool and validate it.
 func TestCreateProposalBlock(t *testing.T) {
@@ -445,6 +615,41 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	channels := n.NodeInfo().(p2p.DefaultNodeInfo).Channels
 	assert.Contains(t, channels, mempl.MempoolChannel)
 	assert.Contains(t, channels, cr.Channels[0].ID)
+	// the replaced reactor's channels are no longer advertised
+	assert.NotContains(t, channels, bcv0.BlockchainChannel)
+	assert.Equal(t, n.NodeInfo(), n.Switch().NodeInfo())
+}
+
+func TestNodeOnSwitchToConsensus(t *testing.T) {
+	config := cfg.ResetTestRoot("node_on_switch_to_consensus_test")
+	defer os.RemoveAll(config.RootDir)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	switched := make(chan int64, 1)
+	n, err := NewNode(config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+		OnSwitchToConsensus(func(height int64) { switched <- height }),
+	)
+	require.NoError(t, err)
+
+	// the only validator doesn't fast sync, so consensus starts right away
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	select {
+	case height := <-switched:
+		assert.EqualValues(t, 0, height)
+	case <-time.After(time.Second):
+		t.Fatal("expected the node to switch to consensus")
+	}
 }
 
 func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
@@ -484,3 +689,49 @@ func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	}
 	return s, stateDB, privVals
 }
+
+func TestSharedDBProvider(t *testing.T) {
+	config := cfg.ResetTestRoot("node_shared_db_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+	provider := SharedDBProvider("tendermint")
+	key := []byte("key")
+
+	stateDB, err := provider(&DBContext{"state", config})
+	require.NoError(t, err)
+	blockStoreDB, err := provider(&DBContext{"blockstore", config})
+	require.NoError(t, err)
+	require.NoError(t, stateDB.Set(key, []byte("state")))
+	require.NoError(t, blockStoreDB.Set(key, []byte("blockstore")))
+
+	// each store sees its own keys only
+	value, err := stateDB.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("state"), value)
+	it, err := blockStoreDB.Iterator(nil, nil)
+	require.NoError(t, err)
+	var keys int
+	for ; it.Valid(); it.Next() {
+		assert.Equal(t, key, it.Key())
+		assert.Equal(t, []byte("blockstore"), it.Value())
+		keys++
+	}
+	require.NoError(t, it.Close())
+	assert.Equal(t, 1, keys)
+
+	// the DB stays open until all of the stores are closed
+	require.NoError(t, stateDB.Close())
+	value, err = blockStoreDB.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("blockstore"), value)
+	require.NoError(t, blockStoreDB.Close())
+	require.NoError(t, blockStoreDB.Close())
+
+	// then it can be opened again
+	stateDB, err = provider(&DBContext{"state", config})
+	require.NoError(t, err)
+	value, err = stateDB.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("state"), value)
+	require.NoError(t, stateDB.Close())
+}
@@ -115,6 +115,8 @@ type MConnection struct {
 
 	created time.Time // time of creation
 
+	lastFlushDuration int64 // duration of the most recent flush, in ns (atomic)
+
 	_maxPacketMsgSize int
 }
 
@@ -123,6 +125,11 @@ type MConnConfig struct {
 	SendRate int64 `mapstructure:"send_rate"`
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Limiters shared by all connections, bounding the aggregate send and
+	// receive rates. Nil means no aggregate limit.
+	SendLimiter *flow.TokenBucket `mapstructure:"-"`
+	RecvLimiter *flow.TokenBucket `mapstructure:"-"`
+
 	// Maximum payload size
 	MaxPacketMsgPayloadSize int `mapstructure:"max_packet_msg_payload_size"`
 
@@ -322,7 +329,9 @@ func (c *MConnection) String() string {
 
 func (c *MConnection) flush() {
 	c.Logger.Debug("Flush", "conn", c)
+	start := time.Now()
 	err := c.bufConnWriter.Flush()
+	atomic.StoreInt64(&c.lastFlushDuration, int64(time.Since(start)))
 	if err != nil {
 		c.Logger.Debug("MConnection flush failed", "err", err)
 	}
@@ -549,6 +558,7 @@ func (c *MConnection) sendPacketMsg() bool {
 		return true
 	}
 	c.sendMonitor.Update(_n)
+	c.config.SendLimiter.Wait(_n)
 	c.flushTimer.Set()
 	return false
 }
@@ -586,6 +596,7 @@ FOR_LOOP:
 
 		_n, err := protoReader.ReadMsg(&packet)
 		c.recvMonitor.Update(_n)
+		c.config.RecvLimiter.Wait(_n)
 		if err != nil {
 			// stopServices was invoked and we are shutting down
 			// receiving is excpected to fail since we will close the connection
@@ -684,10 +695,11 @@ func (c *MConnection) maxPacketMsgSize() int {
 }
 
 type ConnectionStatus struct {
-	Duration    time.Duration
-	SendMonitor flow.Status
-	RecvMonitor flow.Status
-	Channels    []ChannelStatus
+	Duration     time.Duration
+	FlushLatency time.Duration // duration of the most recent flush to the underlying conn
+	SendMonitor  flow.Status
+	RecvMonitor  flow.Status
+	Channels     []ChannelStatus
 }
 
 type ChannelStatus struct {
@@ -701,6 +713,7 @@ type ChannelStatus struct {
 func (c *MConnection) Status() ConnectionStatus {
 	var status ConnectionStatus
 	status.Duration = time.Since(c.created)
+	status.FlushLatency = time.Duration(atomic.LoadInt64(&c.lastFlushDuration))
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
 	status.Channels = make([]ChannelStatus, len(c.channels))
@@ -138,6 +138,15 @@ func (e ErrSwitchAuthenticationFailure) Error() string {
 	)
 }
 
+// ErrSwitchBannedAddress indicates that the address has been banned.
+type ErrSwitchBannedAddress struct {
+	Addr *NetAddress
+}
+
+func (e ErrSwitchBannedAddress) Error() string {
+	return fmt.Sprintf("address %v is banned", e.Addr)
+}
+
 // ErrTransportClosed is raised when the Transport has been closed.
 type ErrTransportClosed struct{}
 
@@ -41,6 +41,8 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter
+	// Number of messages received by each reactor.
+	ReactorReceiveMessagesTotal metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -94,24 +96,32 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		ReactorReceiveMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reactor_receive_messages_total",
+			Help:      "Number of messages received by each reactor.",
+		}, append(labels, "reactor")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                    discard.NewGauge(),
-		PeerReceiveBytesTotal:    discard.NewCounter(),
-		PeerSendBytesTotal:       discard.NewCounter(),
-		PeerPendingSendBytes:     discard.NewGauge(),
-		NumTxs:                   discard.NewGauge(),
-		MessageReceiveBytesTotal: discard.NewCounter(),
-		MessageSendBytesTotal:    discard.NewCounter(),
+		Peers:                       discard.NewGauge(),
+		PeerReceiveBytesTotal:       discard.NewCounter(),
+		PeerSendBytesTotal:          discard.NewCounter(),
+		PeerPendingSendBytes:        discard.NewGauge(),
+		NumTxs:                      discard.NewGauge(),
+		MessageReceiveBytesTotal:    discard.NewCounter(),
+		MessageSendBytesTotal:       discard.NewCounter(),
+		ReactorReceiveMessagesTotal: discard.NewCounter(),
 	}
 }
 
 type metricsLabelCache struct {
 	mtx               *sync.RWMutex
 	messageLabelNames map[reflect.Type]string
+	reactorNames      map[byte]string // by channel ID, as given to Switch.AddReactor
 }
 
 // ValueToMetricLabel is a method that is used to produce a prometheus label value of the golang
@@ -137,9 +147,35 @@ func (m *metricsLabelCache) ValueToMetricLabel(i interface{}) string {
 	return l
 }
 
+// ReactorToMetricLabel returns the name of the reactor of the given channel,
+// to label the metrics of this reactor. If the channel is not registered, the
+// label is made from the type of the reactor.
+func (m *metricsLabelCache) ReactorToMetricLabel(chID byte, reactor Reactor) string {
+	m.mtx.RLock()
+	name, ok := m.reactorNames[chID]
+	m.mtx.RUnlock()
+	if ok {
+		return name
+	}
+	return m.ValueToMetricLabel(reactor)
+}
+
+func (m *metricsLabelCache) setReactorName(chID byte, name string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.reactorNames[chID] = name
+}
+
+func (m *metricsLabelCache) removeReactorName(chID byte) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.reactorNames, chID)
+}
+
 func newMetricsLabelCache() *metricsLabelCache {
 	return &metricsLabelCache{
 		mtx:               &sync.RWMutex{},
 		messageLabelNames: map[reflect.Type]string{},
+		reactorNames:      map[byte]string{},
 	}
 }
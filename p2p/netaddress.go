@@ -68,47 +68,71 @@ func NewNetAddress(id ID, addr net.Addr) *NetAddress {
 // Also resolves the host if host is not an IP.
 // Errors are of type ErrNetAddressXxx where Xxx is in (NoID, Invalid, Lookup)
 func NewNetAddressString(addr string) (*NetAddress, error) {
+	addrs, err := LookupNetAddresses(addr)
+	if err != nil {
+		return nil, err
+	}
+	return addrs[0], nil
+}
+
+// LookupNetAddresses is like NewNetAddressString, but returns one NetAddress
+// for every IP the host resolves to. Dialers can rotate through them to reach
+// peers behind load balancers.
+func LookupNetAddresses(addr string) ([]*NetAddress, error) {
+	id, host, portStr, err := splitNetAddressString(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		ips, err = lookupIP(host)
+		if err != nil {
+			return nil, ErrNetAddressLookup{host, err}
+		}
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, ErrNetAddressInvalid{portStr, err}
+	}
+
+	netAddrs := make([]*NetAddress, len(ips))
+	for i, ip := range ips {
+		netAddrs[i] = NewNetAddressIPPort(ip, uint16(port))
+		netAddrs[i].ID = id
+	}
+	return netAddrs, nil
+}
+
+// lookupIP resolves host names, it is replaced in tests.
+var lookupIP = net.LookupIP
+
+// splitNetAddressString splits an address of the form ID@host:port.
+func splitNetAddressString(addr string) (id ID, host, port string, err error) {
 	addrWithoutProtocol := removeProtocolIfDefined(addr)
 	spl := strings.Split(addrWithoutProtocol, "@")
 	if len(spl) != 2 {
-		return nil, ErrNetAddressNoID{addr}
+		return "", "", "", ErrNetAddressNoID{addr}
 	}
 
 	// get ID
 	if err := validateID(ID(spl[0])); err != nil {
-		return nil, ErrNetAddressInvalid{addrWithoutProtocol, err}
+		return "", "", "", ErrNetAddressInvalid{addrWithoutProtocol, err}
 	}
-	var id ID
 	id, addrWithoutProtocol = ID(spl[0]), spl[1]
 
 	// get host and port
-	host, portStr, err := net.SplitHostPort(addrWithoutProtocol)
+	host, port, err = net.SplitHostPort(addrWithoutProtocol)
 	if err != nil {
-		return nil, ErrNetAddressInvalid{addrWithoutProtocol, err}
+		return "", "", "", ErrNetAddressInvalid{addrWithoutProtocol, err}
 	}
 	if len(host) == 0 {
-		return nil, ErrNetAddressInvalid{
+		return "", "", "", ErrNetAddressInvalid{
 			addrWithoutProtocol,
 			errors.New("host is empty")}
 	}
-
-	ip := net.ParseIP(host)
-	if ip == nil {
-		ips, err := net.LookupIP(host)
-		if err != nil {
-			return nil, ErrNetAddressLookup{host, err}
-		}
-		ip = ips[0]
-	}
-
-	port, err := strconv.ParseUint(portStr, 10, 16)
-	if err != nil {
-		return nil, ErrNetAddressInvalid{portStr, err}
-	}
-
-	na := NewNetAddressIPPort(ip, uint16(port))
-	na.ID = id
-	return na, nil
+	return id, host, port, nil
 }
 
 // NewNetAddressStrings returns an array of NetAddress'es build using
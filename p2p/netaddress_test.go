@@ -124,6 +124,29 @@ func TestNewNetAddressString(t *testing.T) {
 	}
 }
 
+func TestLookupNetAddresses(t *testing.T) {
+	lookupIP = func(host string) ([]net.IP, error) {
+		return []net.IP{net.IPv4(10, 0, 0, 1), net.IPv4(10, 0, 0, 2)}, nil
+	}
+	t.Cleanup(func() { lookupIP = net.LookupIP })
+
+	id := "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	addrs, err := LookupNetAddresses("tcp://" + id + "@seed.example:26656")
+	require.NoError(t, err)
+	require.Len(t, addrs, 2)
+	assert.Equal(t, id+"@10.0.0.1:26656", addrs[0].String())
+	assert.Equal(t, id+"@10.0.0.2:26656", addrs[1].String())
+
+	// IPs are not resolved
+	addrs, err = LookupNetAddresses(id + "@127.0.0.1:26656")
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	assert.Equal(t, id+"@127.0.0.1:26656", addrs[0].String())
+
+	_, err = LookupNetAddresses(id + "@seed.example:badport")
+	assert.Error(t, err)
+}
+
 func TestNewNetAddressStrings(t *testing.T) {
 	addrs, errs := NewNetAddressStrings([]string{
 		"127.0.0.1:8080",
@@ -69,6 +69,12 @@ type AddrBook interface {
 
 	IsGood(*p2p.NetAddress) bool
 	IsBanned(*p2p.NetAddress) bool
+	// List banned addresses
+	BannedAddresses() []BannedAddress
+
+	// Add to the misbehavior score of a peer, banning it once the score
+	// reaches misbehaviorBanScore
+	ReportMisbehavior(addr *p2p.NetAddress, score int)
 
 	// Send a selection of addresses to peers
 	GetSelection() []*p2p.NetAddress
@@ -95,6 +101,7 @@ type addrBook struct {
 	privateIDs map[p2p.ID]struct{}
 	addrLookup map[p2p.ID]*knownAddress // new & old
 	badPeers   map[p2p.ID]*knownAddress // blacklisted peers
+	scores     map[p2p.ID]*misbehavior  // misbehavior scores of peers
 	bucketsOld []map[string]*knownAddress
 	bucketsNew []map[string]*knownAddress
 	nOld       int
@@ -124,6 +131,7 @@ func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
 		privateIDs:        make(map[p2p.ID]struct{}),
 		addrLookup:        make(map[p2p.ID]*knownAddress),
 		badPeers:          make(map[p2p.ID]*knownAddress),
+		scores:            make(map[p2p.ID]*misbehavior),
 		filePath:          filePath,
 		routabilityStrict: routabilityStrict,
 		hashKey:           newHashKey(),
@@ -154,7 +162,9 @@ func (a *addrBook) OnStart() error {
 	if err := a.BaseService.OnStart(); err != nil {
 		return err
 	}
-	a.loadFromFile(a.filePath)
+	if _, err := a.loadFromFile(a.filePath); err != nil {
+		return err
+	}
 
 	// wg.Add to ensure that any invocation of .Wait()
 	// later on will wait for saveRoutine to terminate.
@@ -237,10 +247,50 @@ func (a *addrBook) IsGood(addr *p2p.NetAddress) bool {
 // IsBanned returns true if the peer is currently banned
 func (a *addrBook) IsBanned(addr *p2p.NetAddress) bool {
 	a.mtx.Lock()
-	_, ok := a.badPeers[addr.ID]
-	a.mtx.Unlock()
+	defer a.mtx.Unlock()
 
-	return ok
+	return a.isBannedPeer(addr.ID)
+}
+
+// BannedAddresses implements AddrBook - returns the banned addresses and
+// when their ban expires.
+func (a *addrBook) BannedAddresses() []BannedAddress {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	banned := make([]BannedAddress, 0, len(a.badPeers))
+	for id, ka := range a.badPeers {
+		if a.isBannedPeer(id) {
+			banned = append(banned, BannedAddress{Addr: ka.Addr, Until: ka.LastBanTime})
+		}
+	}
+	return banned
+}
+
+// ReportMisbehavior implements AddrBook. It adds score to the misbehavior
+// score of the peer, which is forgotten if the peer does not misbehave for
+// misbehaviorScoreTTL. Once the score reaches misbehaviorBanScore, the peer
+// is banned for defaultBanTime.
+func (a *addrBook) ReportMisbehavior(addr *p2p.NetAddress, score int) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	now := time.Now()
+	m, ok := a.scores[addr.ID]
+	if !ok || now.Sub(m.lastReport) > misbehaviorScoreTTL {
+		m = &misbehavior{}
+		a.scores[addr.ID] = m
+	}
+	m.score += score
+	m.lastReport = now
+
+	if m.score < misbehaviorBanScore {
+		return
+	}
+	delete(a.scores, addr.ID)
+	a.Logger.Info("Banning misbehaving peer", "addr", addr, "score", m.score)
+	a.addBadPeer(addr, defaultBanTime)
+	a.removeAddress(addr)
 }
 
 // HasAddress returns true if the address is in the book.
@@ -364,24 +414,8 @@ func (a *addrBook) ReinstateBadPeers() {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
-	for _, ka := range a.badPeers {
-		if ka.isBanned() {
-			continue
-		}
-
-		bucket, err := a.calcNewBucket(ka.Addr, ka.Src)
-		if err != nil {
-			a.Logger.Error("Failed to calculate new bucket (bad peer won't be reinstantiated)",
-				"addr", ka.Addr, "err", err)
-			continue
-		}
-
-		if err := a.addToNewBucket(ka, bucket); err != nil {
-			a.Logger.Error("Error adding peer to new bucket", "err", err)
-		}
-		delete(a.badPeers, ka.ID())
-
-		a.Logger.Info("Reinstated address", "addr", ka.Addr)
+	for id := range a.badPeers {
+		a.isBannedPeer(id)
 	}
 }
 
@@ -647,7 +681,7 @@ func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
 		return ErrAddrBookInvalidAddr{Addr: addr, AddrErr: err}
 	}
 
-	if _, ok := a.badPeers[addr.ID]; ok {
+	if a.isBannedPeer(addr.ID) {
 		return ErrAddressBanned{addr}
 	}
 
@@ -806,21 +840,49 @@ func (a *addrBook) removeAddress(addr *p2p.NetAddress) {
 	a.removeFromAllBuckets(ka)
 }
 
-func (a *addrBook) addBadPeer(addr *p2p.NetAddress, banTime time.Duration) bool {
-	// check it exists in addrbook
-	ka := a.addrLookup[addr.ID]
-	// check address is not already there
-	if ka == nil {
+// isBannedPeer returns true if the peer is banned. A ban which has expired
+// is lifted, and the address placed into a new bucket, so that the ban ends
+// even if ReinstateBadPeers is not called (e.g. PEX is disabled).
+func (a *addrBook) isBannedPeer(id p2p.ID) bool {
+	ka, ok := a.badPeers[id]
+	if !ok {
 		return false
 	}
+	if ka.isBanned() {
+		return true
+	}
 
-	if _, alreadyBadPeer := a.badPeers[addr.ID]; !alreadyBadPeer {
+	delete(a.badPeers, id)
+	bucket, err := a.calcNewBucket(ka.Addr, ka.Src)
+	if err != nil {
+		a.Logger.Error("Failed to calculate new bucket (bad peer won't be reinstantiated)",
+			"addr", ka.Addr, "err", err)
+		return false
+	}
+	if err := a.addToNewBucket(ka, bucket); err != nil {
+		a.Logger.Error("Error adding peer to new bucket", "err", err)
+	}
+	a.Logger.Info("Reinstated address", "addr", ka.Addr)
+	return false
+}
+
+// addBadPeer bans the address, which does not have to be in the book (e.g.
+// an inbound peer). It returns true if the address is in the book.
+func (a *addrBook) addBadPeer(addr *p2p.NetAddress, banTime time.Duration) bool {
+	ka, inBook := a.addrLookup[addr.ID]
+
+	if !a.isBannedPeer(addr.ID) {
+		// the address may have been reinstated if its previous ban expired
+		ka, inBook = a.addrLookup[addr.ID]
+		if !inBook {
+			ka = newKnownAddress(addr, addr)
+		}
 		// add to bad peer list
 		ka.ban(banTime)
 		a.badPeers[addr.ID] = ka
 		a.Logger.Info("Add address to blacklist", "addr", addr)
 	}
-	return true
+	return inBook
 }
 
 //---------------------------------------------------------------------
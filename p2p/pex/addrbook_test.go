@@ -2,6 +2,8 @@ package pex
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -92,6 +94,86 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookLoadCorruptedFile(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+	defer os.Remove(fname + ".corrupted")
+
+	require.NoError(t, os.WriteFile(fname, []byte(`{"key": "abc", "addrs": [`), 0644))
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	defer book.Stop() //nolint:errcheck // ignore for tests
+
+	assert.True(t, book.Empty())
+	assert.FileExists(t, fname+".corrupted")
+
+	// the book keeps working and is saved to the original file
+	addrSrc := randNetAddressPairs(t, 1)[0]
+	require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	book.Save()
+	assert.FileExists(t, fname)
+}
+
+func TestAddrBookLoadNewerVersion(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	bz := []byte(fmt.Sprintf(`{"version": %d, "key": "abc", "addrs": []}`, addrBookVersion+1))
+	require.NoError(t, os.WriteFile(fname, bz, 0644))
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	err := book.Start()
+	assert.True(t, errors.As(err, &ErrAddrBookVersion{}), err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("addrbook version %d is newer than supported", addrBookVersion+1))
+
+	// the file is neither moved aside nor overwritten
+	assert.NoFileExists(t, fname+".corrupted")
+	got, err := os.ReadFile(fname)
+	require.NoError(t, err)
+	assert.Equal(t, bz, got)
+}
+
+func TestAddrBookMigrateV1File(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	addrs := randNetAddressPairs(t, 3)
+	ka := newKnownAddress(addrs[0].addr, addrs[0].src)
+	ka.Buckets = []int{1}
+	invalid := newKnownAddress(addrs[1].addr, addrs[1].src)
+	invalid.Buckets = []int{newBucketCount}
+	unassigned := newKnownAddress(addrs[2].addr, addrs[2].src)
+
+	// version 1 files have no version field
+	bz, err := json.Marshal(map[string]interface{}{
+		"key":   "0123456789abcdef",
+		"addrs": []*knownAddress{ka, invalid, unassigned},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fname, bz, 0644))
+
+	book := NewAddrBook(fname, true).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	defer book.Stop() //nolint:errcheck // ignore for tests
+
+	assert.Equal(t, 1, book.Size())
+	assert.True(t, book.HasAddress(addrs[0].addr))
+	assert.Equal(t, "0123456789abcdef", book.key)
+
+	book.Save()
+	bz, err = os.ReadFile(fname)
+	require.NoError(t, err)
+	aJSON := &addrBookJSON{}
+	require.NoError(t, json.Unmarshal(bz, aJSON))
+	assert.Equal(t, addrBookVersion, aJSON.Version)
+	require.Len(t, aJSON.Addrs, 1)
+	assert.Equal(t, ka.LastAttempt.UTC(), aJSON.Addrs[0].LastAttempt.UTC())
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -439,6 +521,91 @@ func TestBanBadPeers(t *testing.T) {
 	assert.False(t, book.IsGood(addr))
 }
 
+func TestBanExpiresWithoutReinstate(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	// with PEX disabled, ReinstateBadPeers is never called
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addr := randIPv4Address(t)
+	_ = book.AddAddress(addr, addr)
+	inbound := randIPv4Address(t)
+	book.MarkBad(addr, time.Hour)
+	book.MarkBad(inbound, time.Hour)
+	require.True(t, book.IsBanned(addr))
+	require.Len(t, book.BannedAddresses(), 2)
+
+	// the bans expire
+	for _, ka := range book.(*addrBook).badPeers {
+		ka.LastBanTime = time.Now().Add(-time.Second)
+	}
+	assert.False(t, book.IsBanned(addr))
+	assert.True(t, book.HasAddress(addr))
+	assert.Empty(t, book.BannedAddresses())
+	assert.False(t, book.IsBanned(inbound))
+	assert.Empty(t, book.(*addrBook).badPeers)
+
+	// a new ban applies again
+	book.MarkBad(addr, time.Hour)
+	assert.True(t, book.IsBanned(addr))
+	assert.False(t, book.HasAddress(addr))
+}
+
+func TestBanMisbehavingPeers(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addr := randIPv4Address(t)
+	_ = book.AddAddress(addr, addr)
+
+	book.ReportMisbehavior(addr, misbehaviorBanScore-1)
+	assert.True(t, book.HasAddress(addr))
+	assert.False(t, book.IsBanned(addr))
+
+	book.ReportMisbehavior(addr, 1)
+	assert.False(t, book.HasAddress(addr))
+	assert.True(t, book.IsBanned(addr))
+
+	// peers which are not in the book (e.g. inbound peers) are banned too
+	inbound := randIPv4Address(t)
+	book.ReportMisbehavior(inbound, misbehaviorBanScore)
+	assert.True(t, book.IsBanned(inbound))
+	assert.False(t, book.HasAddress(inbound))
+
+	assert.Len(t, book.BannedAddresses(), 2)
+}
+
+func TestBannedPeersSaveLoad(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addr := randIPv4Address(t)
+	_ = book.AddAddress(addr, addr)
+	book.MarkBad(addr, time.Hour)
+	book.Save()
+
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	err := book.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = book.Stop() })
+
+	assert.True(t, book.IsBanned(addr))
+	assert.False(t, book.HasAddress(addr))
+	banned := book.BannedAddresses()
+	require.Len(t, banned, 1)
+	assert.Equal(t, addr.String(), banned[0].Addr.String())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), banned[0].Until, time.Minute)
+}
+
 func TestAddrBookEmpty(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
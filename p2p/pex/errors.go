@@ -76,6 +76,16 @@ func (err ErrAddrBookInvalidAddr) Error() string {
 	return fmt.Sprintf("Cannot add invalid address %v: %v", err.Addr, err.AddrErr)
 }
 
+// ErrAddrBookVersion is returned when the address book file was written by a
+// newer version of the file format. The file is left untouched.
+type ErrAddrBookVersion struct {
+	Version int
+}
+
+func (err ErrAddrBookVersion) Error() string {
+	return fmt.Sprintf("addrbook version %d is newer than supported (%d)", err.Version, addrBookVersion)
+}
+
 // ErrAddressBanned is thrown when the address has been banned and therefore cannot be used
 type ErrAddressBanned struct {
 	Addr *p2p.NetAddress
@@ -2,6 +2,7 @@ package pex
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,9 +11,15 @@ import (
 
 /* Loading & Saving */
 
+// addrBookVersion is the version of the address book file format. Version 1
+// files were written without a version field and are migrated on load.
+const addrBookVersion = 2
+
 type addrBookJSON struct {
-	Key   string          `json:"key"`
-	Addrs []*knownAddress `json:"addrs"`
+	Version int             `json:"version"`
+	Key     string          `json:"key"`
+	Addrs   []*knownAddress `json:"addrs"`
+	Banned  []*knownAddress `json:"banned,omitempty"`
 }
 
 func (a *addrBook) saveToFile(filePath string) {
@@ -25,9 +32,15 @@ func (a *addrBook) saveToFile(filePath string) {
 	for _, ka := range a.addrLookup {
 		addrs = append(addrs, ka)
 	}
+	banned := make([]*knownAddress, 0, len(a.badPeers))
+	for _, ka := range a.badPeers {
+		banned = append(banned, ka)
+	}
 	aJSON := &addrBookJSON{
-		Key:   a.key,
-		Addrs: addrs,
+		Version: addrBookVersion,
+		Key:     a.key,
+		Addrs:   addrs,
+		Banned:  banned,
 	}
 
 	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
@@ -41,33 +54,47 @@ func (a *addrBook) saveToFile(filePath string) {
 	}
 }
 
-// Returns false if file does not exist.
-// cmn.Panics if file is corrupt.
-func (a *addrBook) loadFromFile(filePath string) bool {
-	// If doesn't exist, do nothing.
-	_, err := os.Stat(filePath)
-	if os.IsNotExist(err) {
-		return false
+// Returns false if file does not exist or could not be loaded. A corrupted
+// file is moved aside, so that the address book starts empty and peer
+// discovery can refill it from the seeds and persistent peers. A file written
+// by a newer version is not corrupted, and ErrAddrBookVersion is returned
+// instead.
+func (a *addrBook) loadFromFile(filePath string) (bool, error) {
+	// If doesn't exist or is empty, do nothing.
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) || (err == nil && info.Size() == 0) {
+		return false, nil
 	}
 
-	// Load addrBookJSON{}
-	r, err := os.Open(filePath)
-	if err != nil {
-		panic(fmt.Sprintf("Error opening file %s: %v", filePath, err))
+	aJSON, err := readAddrBookFile(filePath)
+	if errors.As(err, &ErrAddrBookVersion{}) {
+		return false, fmt.Errorf("failed to load AddrBook from %s: %w", filePath, err)
 	}
-	defer r.Close()
-	aJSON := &addrBookJSON{}
-	dec := json.NewDecoder(r)
-	err = dec.Decode(aJSON)
 	if err != nil {
-		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
+		corruptPath := filePath + ".corrupted"
+		a.Logger.Error("Failed to load AddrBook from file, starting with an empty one",
+			"file", filePath, "err", err, "backup", corruptPath)
+		if err := os.Rename(filePath, corruptPath); err != nil {
+			a.Logger.Error("Failed to move corrupted AddrBook file", "file", filePath, "err", err)
+		}
+		return false, nil
+	}
+	if aJSON.Version < addrBookVersion {
+		a.Logger.Info("Migrating AddrBook file", "file", filePath,
+			"from", aJSON.Version, "to", addrBookVersion)
 	}
 
 	// Restore all the fields...
 	// Restore the key
-	a.key = aJSON.Key
+	if aJSON.Key != "" {
+		a.key = aJSON.Key
+	}
 	// Restore .bucketsNew & .bucketsOld
 	for _, ka := range aJSON.Addrs {
+		if err := a.validateLoadedAddress(ka); err != nil {
+			a.Logger.Error("Dropping invalid address from AddrBook file", "err", err)
+			continue
+		}
 		for _, bucketIndex := range ka.Buckets {
 			bucket := a.getBucket(ka.BucketType, bucketIndex)
 			bucket[ka.Addr.String()] = ka
@@ -79,5 +106,64 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 			a.nOld++
 		}
 	}
-	return true
+	// Restore the banned addresses; expired bans are lifted by
+	// ReinstateBadPeers
+	for _, ka := range aJSON.Banned {
+		if ka == nil || ka.Addr == nil {
+			continue
+		}
+		a.badPeers[ka.ID()] = ka
+	}
+	return true, nil
+}
+
+func readAddrBookFile(filePath string) (*addrBookJSON, error) {
+	r, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	aJSON := &addrBookJSON{}
+	if err := json.NewDecoder(r).Decode(aJSON); err != nil {
+		return nil, err
+	}
+	// Files without a version were written before versioning was introduced.
+	if aJSON.Version == 0 {
+		aJSON.Version = 1
+	}
+	if aJSON.Version > addrBookVersion {
+		return nil, ErrAddrBookVersion{Version: aJSON.Version}
+	}
+	return aJSON, nil
+}
+
+// validateLoadedAddress checks that a known address read from the file can be
+// put back into the buckets it claims to be in.
+func (a *addrBook) validateLoadedAddress(ka *knownAddress) error {
+	if ka == nil || ka.Addr == nil {
+		return errors.New("missing address")
+	}
+	if a.addrLookup[ka.ID()] != nil {
+		return fmt.Errorf("duplicate address %v", ka.Addr)
+	}
+
+	var bucketCount int
+	switch ka.BucketType {
+	case bucketTypeNew:
+		bucketCount = newBucketCount
+	case bucketTypeOld:
+		bucketCount = oldBucketCount
+	default:
+		return fmt.Errorf("address %v has unknown bucket type %d", ka.Addr, ka.BucketType)
+	}
+	if len(ka.Buckets) == 0 {
+		return fmt.Errorf("address %v is in no bucket", ka.Addr)
+	}
+	for _, idx := range ka.Buckets {
+		if idx < 0 || idx >= bucketCount {
+			return fmt.Errorf("address %v has invalid bucket index %d", ka.Addr, idx)
+		}
+	}
+	return nil
 }
@@ -19,6 +19,18 @@ type knownAddress struct {
 	LastBanTime time.Time       `json:"last_ban_time"`
 }
 
+// BannedAddress is an address which is banned until the given time.
+type BannedAddress struct {
+	Addr  *p2p.NetAddress
+	Until time.Time
+}
+
+// misbehavior tracks the misbehavior score of a peer.
+type misbehavior struct {
+	score      int
+	lastReport time.Time
+}
+
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
 	return &knownAddress{
 		Addr:        addr,
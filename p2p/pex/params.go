@@ -52,4 +52,11 @@ const (
 	// max addresses returned by GetSelection
 	// NOTE: this must match "maxMsgSize"
 	maxGetSelection = 250
+
+	// misbehavior score at which a peer is banned.
+	misbehaviorBanScore = 100
+
+	// time after which the misbehavior score of a peer is forgotten, if it
+	// did not misbehave again.
+	misbehaviorScoreTTL = time.Hour
 )
@@ -635,23 +635,32 @@ func (r *Reactor) checkSeeds() (numOnline int, netAddrs []*p2p.NetAddress, err e
 	return numOnline, netAddrs, nil
 }
 
-// randomly dial seeds until we connect to one or exhaust them
+// randomly dial seeds until we connect to one or exhaust them. Seeds are
+// resolved again every time, so that seeds given by host name follow DNS
+// changes, and all records of a host are tried in random order.
 func (r *Reactor) dialSeeds() {
-	perm := tmrand.Perm(len(r.seedAddrs))
+	perm := tmrand.Perm(len(r.config.Seeds))
 	// perm := r.Switch.rng.Perm(lSeeds)
 	for _, i := range perm {
 		// dial a random seed
-		seedAddr := r.seedAddrs[i]
-		err := r.Switch.DialPeerWithAddress(seedAddr)
+		seedAddrs, err := p2p.LookupNetAddresses(r.config.Seeds[i])
+		if err != nil {
+			r.Switch.Logger.Error("Error resolving seed", "err", err, "seed", r.config.Seeds[i])
+			continue
+		}
+		for _, j := range tmrand.Perm(len(seedAddrs)) {
+			seedAddr := seedAddrs[j]
+			err := r.Switch.DialPeerWithAddress(seedAddr)
 
-		switch err.(type) {
-		case nil, p2p.ErrCurrentlyDialingOrExistingAddress:
-			return
+			switch err.(type) {
+			case nil, p2p.ErrCurrentlyDialingOrExistingAddress:
+				return
+			}
+			r.Switch.Logger.Error("Error dialing seed", "err", err, "seed", seedAddr)
 		}
-		r.Switch.Logger.Error("Error dialing seed", "err", err, "seed", seedAddr)
 	}
 	// do not write error message if there were no seeds specified in config
-	if len(r.seedAddrs) > 0 {
+	if len(r.config.Seeds) > 0 {
 		r.Switch.Logger.Error("Couldn't connect to any seeds")
 	}
 }
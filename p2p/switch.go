@@ -3,14 +3,17 @@ package p2p
 import (
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/cmap"
+	flow "github.com/tendermint/tendermint/libs/flowrate"
 	"github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
 )
 
@@ -30,6 +33,20 @@ const (
 	reconnectBackOffBaseSeconds = 3
 )
 
+// Misbehavior scores reported to the address book, which bans peers once
+// their total score gets too high.
+const (
+	// MisbehaviorScoreError is reported for peers stopped for misbehavior
+	// that does not warrant an immediate ban, such as sending an invalid
+	// message. Plain errors, like a dropped connection, are not scored.
+	MisbehaviorScoreError = 10
+	// MisbehaviorScoreHandshake is reported for peers failing the handshake.
+	MisbehaviorScoreHandshake = 20
+	// MisbehaviorScoreBan is reported for peers that provably misbehaved, such
+	// as sending an invalid block. It is high enough to get them banned.
+	MisbehaviorScoreBan = 100
+)
+
 // MConnConfig returns an MConnConfig with fields updated
 // from the P2PConfig.
 func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
@@ -37,6 +54,8 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.FlushThrottle = cfg.FlushThrottleTimeout
 	mConfig.SendRate = cfg.SendRate
 	mConfig.RecvRate = cfg.RecvRate
+	mConfig.SendLimiter = flow.NewTokenBucket(cfg.TotalSendRate)
+	mConfig.RecvLimiter = flow.NewTokenBucket(cfg.TotalRecvRate)
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
 	return mConfig
 }
@@ -53,6 +72,8 @@ type AddrBook interface {
 	MarkGood(ID)
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
+	IsBanned(*NetAddress) bool
+	ReportMisbehavior(addr *NetAddress, score int)
 	Save()
 }
 
@@ -77,11 +98,14 @@ type Switch struct {
 	peers         *PeerSet
 	dialing       *cmap.CMap
 	reconnecting  *cmap.CMap
-	nodeInfo      NodeInfo // our node info
-	nodeKey       *NodeKey // our node privkey
+	nodeInfoMtx   tmsync.RWMutex // guards nodeInfo
+	nodeInfo      NodeInfo       // our node info
+	nodeKey       *NodeKey       // our node privkey
 	addrBook      AddrBook
 	// peers addresses with whom we'll maintain constant connection
+	persistentPeersMtx   tmsync.RWMutex // guards persistentPeersAddrs and persistentPeersHosts
 	persistentPeersAddrs []*NetAddress
+	persistentPeersHosts map[ID]string // persistent peers given by host name, re-resolved on reconnect
 	unconditionalPeerIDs map[ID]struct{}
 
 	transport Transport
@@ -124,6 +148,7 @@ func NewSwitch(
 		transport:            transport,
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
+		persistentPeersHosts: make(map[ID]string),
 		unconditionalPeerIDs: make(map[ID]struct{}),
 		mlc:                  newMetricsLabelCache(),
 	}
@@ -158,18 +183,32 @@ func WithMetrics(metrics *Metrics) SwitchOption {
 //---------------------------------------------------------------------
 // Switch setup
 
-// AddReactor adds the given reactor to the switch.
+// AddReactor adds the given reactor to the switch. It panics if the name is
+// already taken or one of the reactor's channels is already used by another
+// reactor, in which case the switch is left unchanged.
 // NOTE: Not goroutine safe.
 func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
+	if _, ok := sw.reactors[name]; ok {
+		panic(fmt.Sprintf("Reactor %v already exists", name))
+	}
+	chIDs := make(map[byte]struct{})
 	for _, chDesc := range reactor.GetChannels() {
 		chID := chDesc.ID
 		// No two reactors can share the same channel.
 		if sw.reactorsByCh[chID] != nil {
 			panic(fmt.Sprintf("Channel %X has multiple reactors %v & %v", chID, sw.reactorsByCh[chID], reactor))
 		}
+		if _, ok := chIDs[chID]; ok {
+			panic(fmt.Sprintf("Channel %X is used more than once by reactor %v", chID, reactor))
+		}
+		chIDs[chID] = struct{}{}
+	}
+	for _, chDesc := range reactor.GetChannels() {
+		chID := chDesc.ID
 		sw.chDescs = append(sw.chDescs, chDesc)
 		sw.reactorsByCh[chID] = reactor
 		sw.msgTypeByChID[chID] = chDesc.MessageType
+		sw.mlc.setReactorName(chID, name)
 	}
 	sw.reactors[name] = reactor
 	reactor.SetSwitch(sw)
@@ -189,6 +228,7 @@ func (sw *Switch) RemoveReactor(name string, reactor Reactor) {
 		}
 		delete(sw.reactorsByCh, chDesc.ID)
 		delete(sw.msgTypeByChID, chDesc.ID)
+		sw.mlc.removeReactorName(chDesc.ID)
 	}
 	delete(sw.reactors, name)
 	reactor.SetSwitch(nil)
@@ -207,14 +247,16 @@ func (sw *Switch) Reactor(name string) Reactor {
 }
 
 // SetNodeInfo sets the switch's NodeInfo for checking compatibility and handshaking with other nodes.
-// NOTE: Not goroutine safe.
 func (sw *Switch) SetNodeInfo(nodeInfo NodeInfo) {
+	sw.nodeInfoMtx.Lock()
+	defer sw.nodeInfoMtx.Unlock()
 	sw.nodeInfo = nodeInfo
 }
 
 // NodeInfo returns the switch's NodeInfo.
-// NOTE: Not goroutine safe.
 func (sw *Switch) NodeInfo() NodeInfo {
+	sw.nodeInfoMtx.RLock()
+	defer sw.nodeInfoMtx.RUnlock()
 	return sw.nodeInfo
 }
 
@@ -361,31 +403,69 @@ func (sw *Switch) Peers() IPeerSet {
 	return sw.peers
 }
 
+// ConnectionStatuses returns the status of the connection to each peer,
+// including per-channel send queue sizes and recently sent bytes. It is
+// meant for debugging which reactor saturates a peer link.
+func (sw *Switch) ConnectionStatuses() map[ID]ConnectionStatus {
+	peers := sw.peers.List()
+	statuses := make(map[ID]ConnectionStatus, len(peers))
+	for _, p := range peers {
+		statuses[p.ID()] = p.Status()
+	}
+	return statuses
+}
+
 // StopPeerForError disconnects from a peer due to external error.
 // If the peer is persistent, it will attempt to reconnect.
-// TODO: make record depending on reason.
+// The error is not held against the peer, since it is most often an ordinary
+// disconnect or timeout; use StopPeerForMisbehavior or BanPeerForError for
+// peers at fault.
 func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
+	sw.stopPeerForError(peer, reason, 0)
+}
+
+// StopPeerForMisbehavior disconnects from a peer that misbehaved, e.g. sent an
+// invalid message, and adds to its misbehavior score, so that a peer doing it
+// repeatedly gets banned. Persistent peers are reconnected to, as with
+// StopPeerForError.
+func (sw *Switch) StopPeerForMisbehavior(peer Peer, reason interface{}) {
+	sw.stopPeerForError(peer, reason, MisbehaviorScoreError)
+}
+
+// BanPeerForError disconnects from a peer that provably misbehaved and, unless
+// it is persistent or unconditional, bans its address. Persistent peers are
+// reconnected to, as with StopPeerForError.
+func (sw *Switch) BanPeerForError(peer Peer, reason interface{}) {
+	sw.stopPeerForError(peer, reason, MisbehaviorScoreBan)
+}
+
+func (sw *Switch) stopPeerForError(peer Peer, reason interface{}, score int) {
 	if !peer.IsRunning() {
 		return
 	}
 
-	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
+	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason, "score", score)
 	sw.stopAndRemovePeer(peer, reason)
 
-	if peer.IsPersistent() {
-		var addr *NetAddress
-		if peer.IsOutbound() { // socket address for outbound peers
-			addr = peer.SocketAddr()
-		} else { // self-reported address for inbound peers
-			var err error
-			addr, err = peer.NodeInfo().NetAddress()
-			if err != nil {
+	var addr *NetAddress
+	if peer.IsOutbound() { // socket address for outbound peers
+		addr = peer.SocketAddr()
+	} else { // self-reported address for inbound peers
+		var err error
+		addr, err = peer.NodeInfo().NetAddress()
+		if err != nil {
+			if peer.IsPersistent() {
 				sw.Logger.Error("Wanted to reconnect to inbound peer, but self-reported address is wrong",
 					"peer", peer, "err", err)
-				return
 			}
+			return
 		}
+	}
+
+	if peer.IsPersistent() {
 		go sw.reconnectToPeer(addr)
+	} else if score > 0 && sw.addrBook != nil && !sw.IsPeerUnconditional(peer.ID()) {
+		sw.addrBook.ReportMisbehavior(addr, score)
 	}
 }
 
@@ -441,6 +521,7 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 			return
 		}
 
+		addr = sw.resolvePersistentPeer(addr, i)
 		err := sw.DialPeerWithAddress(addr)
 		if err == nil {
 			return // success
@@ -465,6 +546,7 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 		sleepIntervalSeconds := math.Pow(reconnectBackOffBaseSeconds, float64(i))
 		sw.randomSleep(time.Duration(sleepIntervalSeconds) * time.Second)
 
+		addr = sw.resolvePersistentPeer(addr, reconnectAttempts+i)
 		err := sw.DialPeerWithAddress(addr)
 		if err == nil {
 			return // success
@@ -476,6 +558,25 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 	sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "elapsed", time.Since(start))
 }
 
+// resolvePersistentPeer resolves the host of a persistent peer again, so that
+// reconnects follow DNS changes. If the host has several records, the attempt
+// selects which one is dialed. addr is returned unchanged if the peer was not
+// given by host name or the lookup fails.
+func (sw *Switch) resolvePersistentPeer(addr *NetAddress, attempt int) *NetAddress {
+	sw.persistentPeersMtx.RLock()
+	hostAddr, ok := sw.persistentPeersHosts[addr.ID]
+	sw.persistentPeersMtx.RUnlock()
+	if !ok {
+		return addr
+	}
+	addrs, err := LookupNetAddresses(hostAddr)
+	if err != nil {
+		sw.Logger.Info("Failed to resolve persistent peer", "addr", hostAddr, "err", err)
+		return addr
+	}
+	return addrs[attempt%len(addrs)]
+}
+
 // SetAddrBook allows to set address book on Switch.
 func (sw *Switch) SetAddrBook(addrBook AddrBook) {
 	sw.addrBook = addrBook
@@ -584,6 +685,9 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	if sw.IsDialingOrExistingAddress(addr) {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
+	if sw.addrBook != nil && sw.addrBook.IsBanned(addr) {
+		return ErrSwitchBannedAddress{Addr: addr}
+	}
 
 	sw.dialing.Set(string(addr.ID), addr)
 	defer sw.dialing.Delete(string(addr.ID))
@@ -622,7 +726,20 @@ func (sw *Switch) AddPersistentPeers(addrs []string) error {
 		}
 		return err
 	}
+	hosts := make(map[ID]string)
+	for _, addr := range addrs {
+		id, host, _, err := splitNetAddressString(addr)
+		if err == nil && net.ParseIP(host) == nil {
+			hosts[id] = addr
+		}
+	}
+
+	sw.persistentPeersMtx.Lock()
+	defer sw.persistentPeersMtx.Unlock()
 	sw.persistentPeersAddrs = netAddrs
+	for id, addr := range hosts {
+		sw.persistentPeersHosts[id] = addr
+	}
 	return nil
 }
 
@@ -654,12 +771,16 @@ func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 }
 
 func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
+	sw.persistentPeersMtx.RLock()
+	defer sw.persistentPeersMtx.RUnlock()
 	for _, pa := range sw.persistentPeersAddrs {
 		if pa.Equals(na) {
 			return true
 		}
 	}
-	return false
+	// the host of a persistent peer may resolve to another IP by now
+	_, ok := sw.persistentPeersHosts[na.ID]
+	return ok
 }
 
 func (sw *Switch) acceptRoutine() {
@@ -720,6 +841,12 @@ func (sw *Switch) acceptRoutine() {
 			break
 		}
 
+		if sw.addrBook != nil && sw.addrBook.IsBanned(p.SocketAddr()) {
+			sw.Logger.Info("Ignoring inbound connection: peer is banned", "address", p.SocketAddr())
+			sw.transport.Cleanup(p)
+			continue
+		}
+
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
 			// Ignore connection if we already have enough peers.
 			_, in, _ := sw.NumPeers()
@@ -788,6 +915,9 @@ func (sw *Switch) addOutboundPeerWithConfig(
 
 				return err
 			}
+			if (e.IsAuthFailure() || e.IsNodeInfoInvalid()) && sw.addrBook != nil && !sw.IsPeerPersistent(addr) {
+				sw.addrBook.ReportMisbehavior(addr, MisbehaviorScoreHandshake)
+			}
 		}
 
 		// retry persistent peers after
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -221,6 +222,74 @@ func assertMsgReceivedWithTimeout(
 	}
 }
 
+func TestSwitchAddReactorRejectsDuplicates(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	chDescs := len(sw.chDescs)
+
+	// the name is already taken
+	assert.Panics(t, func() {
+		sw.AddReactor("foo", NewTestReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x10), Priority: 10, MessageType: &p2pproto.Message{}},
+		}, true))
+	})
+	// the second channel belongs to "bar"
+	assert.Panics(t, func() {
+		sw.AddReactor("baz", NewTestReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x10), Priority: 10, MessageType: &p2pproto.Message{}},
+			{ID: byte(0x02), Priority: 10, MessageType: &p2pproto.Message{}},
+		}, true))
+	})
+	// the reactor uses a channel twice
+	assert.Panics(t, func() {
+		sw.AddReactor("baz", NewTestReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x10), Priority: 10, MessageType: &p2pproto.Message{}},
+			{ID: byte(0x10), Priority: 10, MessageType: &p2pproto.Message{}},
+		}, true))
+	})
+
+	// the switch is unchanged
+	assert.Len(t, sw.Reactors(), 2)
+	assert.Len(t, sw.chDescs, chDescs)
+	assert.Nil(t, sw.reactorsByCh[byte(0x10)])
+
+	sw.AddReactor("baz", NewTestReactor([]*conn.ChannelDescriptor{
+		{ID: byte(0x10), Priority: 10, MessageType: &p2pproto.Message{}},
+	}, true))
+	assert.Len(t, sw.Reactors(), 3)
+}
+
+func TestSwitchReactorMetricLabel(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	bar := sw.Reactor("bar")
+
+	// the messages are counted under the name given to AddReactor
+	assert.Equal(t, "bar", sw.mlc.ReactorToMetricLabel(byte(0x02), bar))
+
+	sw.RemoveReactor("bar", bar)
+	assert.Equal(t, "p2p_TestReactor", sw.mlc.ReactorToMetricLabel(byte(0x02), bar))
+}
+
+func TestSwitchConnectionStatuses(t *testing.T) {
+	s1, s2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := s1.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := s2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	statuses := s1.ConnectionStatuses()
+	require.Len(t, statuses, 1)
+	status, ok := statuses[s2.NodeInfo().ID()]
+	require.True(t, ok, "expected a status for s2")
+	// one status per channel registered by the reactors
+	assert.Len(t, status.Channels, 4)
+}
+
 func TestSwitchFiltersOutItself(t *testing.T) {
 	s1 := MakeSwitch(cfg, 1, "127.0.0.1", "123.123.123", initSwitchFunc)
 
@@ -487,6 +556,88 @@ func TestSwitchStopPeerForError(t *testing.T) {
 	assert.EqualValues(t, 0, peersMetricValue())
 }
 
+// misbehaviorAddrBook records the misbehavior scores reported for each peer.
+type misbehaviorAddrBook struct {
+	AddrBookMock
+	mtx    tmsync.Mutex
+	scores map[ID]int
+}
+
+func (book *misbehaviorAddrBook) ReportMisbehavior(addr *NetAddress, score int) {
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	book.scores[addr.ID] += score
+}
+
+func TestSwitchBanPeerForError(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := sw1.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := sw2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	book := &misbehaviorAddrBook{scores: make(map[ID]int)}
+	sw1.SetAddrBook(book)
+
+	require.Len(t, sw1.Peers().List(), 1)
+	p := sw1.Peers().List()[0]
+	sw1.BanPeerForError(p, fmt.Errorf("bad block"))
+
+	assert.Empty(t, sw1.Peers().List())
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	assert.Equal(t, MisbehaviorScoreBan, book.scores[p.ID()])
+}
+
+func TestSwitchStopPeerForErrorDoesNotScore(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := sw1.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := sw2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	book := &misbehaviorAddrBook{scores: make(map[ID]int)}
+	sw1.SetAddrBook(book)
+
+	require.Len(t, sw1.Peers().List(), 1)
+	p := sw1.Peers().List()[0]
+	sw1.StopPeerForError(p, io.EOF)
+
+	assert.Empty(t, sw1.Peers().List())
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	assert.Zero(t, book.scores[p.ID()])
+}
+
+func TestSwitchStopPeerForMisbehavior(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := sw1.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := sw2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	book := &misbehaviorAddrBook{scores: make(map[ID]int)}
+	sw1.SetAddrBook(book)
+
+	require.Len(t, sw1.Peers().List(), 1)
+	p := sw1.Peers().List()[0]
+	sw1.StopPeerForMisbehavior(p, fmt.Errorf("invalid message"))
+
+	assert.Empty(t, sw1.Peers().List())
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	assert.Equal(t, MisbehaviorScoreError, book.scores[p.ID()])
+}
+
 func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
 	err := sw.Start()
@@ -537,6 +688,74 @@ func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	assert.Equal(t, 2, sw.Peers().Size())
 }
 
+func TestSwitchResolvesPersistentPeerHostName(t *testing.T) {
+	ips := []net.IP{net.IPv4(10, 0, 0, 1)}
+	lookupIP = func(host string) ([]net.IP, error) {
+		if ips == nil {
+			return nil, errors.New("no such host")
+		}
+		return ips, nil
+	}
+	t.Cleanup(func() { lookupIP = net.LookupIP })
+
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	require.NoError(t, sw.AddPersistentPeers([]string{IDAddressString(id, "peer.example:26656")}))
+	require.Len(t, sw.persistentPeersAddrs, 1)
+	addr := sw.persistentPeersAddrs[0]
+	assert.Equal(t, "10.0.0.1", addr.IP.String())
+
+	// the host moved to a load balancer with two records
+	ips = []net.IP{net.IPv4(10, 0, 0, 2), net.IPv4(10, 0, 0, 3)}
+	for attempt, want := range []string{"10.0.0.2", "10.0.0.3", "10.0.0.2"} {
+		resolved := sw.resolvePersistentPeer(addr, attempt)
+		assert.Equal(t, want, resolved.IP.String())
+		assert.Equal(t, id, resolved.ID)
+		assert.True(t, sw.IsPeerPersistent(resolved))
+	}
+
+	// a failed lookup keeps the last address
+	ips = nil
+	assert.Equal(t, addr, sw.resolvePersistentPeer(addr, 0))
+
+	// peers given by IP are not resolved
+	ipAddr, err := NewNetAddressString(IDAddressString(id, "10.0.0.9:26656"))
+	require.NoError(t, err)
+	sw = MakeSwitch(cfg, 2, "testing", "123.123.123", initSwitchFunc)
+	require.NoError(t, sw.AddPersistentPeers([]string{ipAddr.String()}))
+	assert.Equal(t, ipAddr, sw.resolvePersistentPeer(ipAddr, 1))
+}
+
+func TestSwitchAddPersistentPeersConcurrently(t *testing.T) {
+	lookupIP = func(host string) ([]net.IP, error) { return []net.IP{net.IPv4(10, 0, 0, 1)}, nil }
+	t.Cleanup(func() { lookupIP = net.LookupIP })
+
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	addr := IDAddressString(id, "peer.example:26656")
+	require.NoError(t, sw.AddPersistentPeers([]string{addr}))
+	na := sw.persistentPeersAddrs[0]
+
+	// peers are added at runtime (/dial_peers) while reconnect routines read them
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			other := PubKeyToID(ed25519.GenPrivKey().PubKey())
+			assert.NoError(t, sw.AddPersistentPeers([]string{addr, IDAddressString(other, "other.example:26656")}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			assert.True(t, sw.IsPeerPersistent(na))
+			sw.resolvePersistentPeer(na, i)
+		}
+	}()
+	wg.Wait()
+}
+
 func TestSwitchReconnectsToInboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
 	err := sw.Start()
@@ -136,7 +136,7 @@ func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
 		return err
 	}
 
-	ni, err := handshake(conn, time.Second, sw.nodeInfo)
+	ni, err := handshake(conn, time.Second, sw.NodeInfo())
 	if err != nil {
 		if err := conn.Close(); err != nil {
 			sw.Logger.Error("Error closing connection", "err", err)
@@ -182,6 +182,28 @@ func MakeSwitch(
 	initSwitch func(int, *Switch) *Switch,
 	opts ...SwitchOption,
 ) *Switch {
+	return makeSwitch(cfg, i, nil, initSwitch, opts...)
+}
+
+// MakeMemorySwitch is like MakeSwitch, but the switch listens on and dials
+// through the given in-memory network instead of tcp.
+func MakeMemorySwitch(
+	memNet *MemoryNetwork,
+	cfg *config.P2PConfig,
+	i int,
+	initSwitch func(int, *Switch) *Switch,
+	opts ...SwitchOption,
+) *Switch {
+	return makeSwitch(cfg, i, memNet, initSwitch, opts...)
+}
+
+func makeSwitch(
+	cfg *config.P2PConfig,
+	i int,
+	memNet *MemoryNetwork,
+	initSwitch func(int, *Switch) *Switch,
+	opts ...SwitchOption,
+) *Switch {
 
 	nodeKey := NodeKey{
 		PrivKey: ed25519.GenPrivKey(),
@@ -194,7 +216,12 @@ func MakeSwitch(
 		panic(err)
 	}
 
-	t := NewMultiplexTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+	var t *MultiplexTransport
+	if memNet != nil {
+		t = NewMemoryTransport(memNet, nodeInfo, nodeKey, MConnConfig(cfg))
+	} else {
+		t = NewMultiplexTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+	}
 
 	if err := t.Listen(*addr); err != nil {
 		panic(err)
@@ -308,7 +335,9 @@ func (book *AddrBookMock) HasAddress(addr *NetAddress) bool {
 func (book *AddrBookMock) RemoveAddress(addr *NetAddress) {
 	delete(book.Addrs, addr.String())
 }
-func (book *AddrBookMock) Save() {}
+func (book *AddrBookMock) IsBanned(addr *NetAddress) bool                { return false }
+func (book *AddrBookMock) ReportMisbehavior(addr *NetAddress, score int) {}
+func (book *AddrBookMock) Save()                                         {}
 func (book *AddrBookMock) AddPrivateIDs(addrs []string) {
 	for _, addr := range addrs {
 		book.PrivateAddrs[addr] = struct{}{}
@@ -11,6 +11,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/protoio"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 )
@@ -140,6 +141,11 @@ type MultiplexTransport struct {
 	listener               net.Listener
 	maxIncomingConnections int // see MaxIncomingConnections
 
+	// listen and dial open the underlying connections, defaulting to tcp. See
+	// NewMemoryTransport for an in-process alternative.
+	listen func(NetAddress) (net.Listener, error)
+	dial   func(NetAddress, time.Duration) (net.Conn, error)
+
 	acceptc chan accept
 	closec  chan struct{}
 
@@ -150,6 +156,7 @@ type MultiplexTransport struct {
 	dialTimeout      time.Duration
 	filterTimeout    time.Duration
 	handshakeTimeout time.Duration
+	nodeInfoMtx      tmsync.RWMutex // guards nodeInfo
 	nodeInfo         NodeInfo
 	nodeKey          NodeKey
 	resolver         IPResolver
@@ -181,6 +188,8 @@ func NewMultiplexTransport(
 		nodeKey:          nodeKey,
 		conns:            NewConnSet(),
 		resolver:         net.DefaultResolver,
+		listen:           listenTCP,
+		dial:             dialTCP,
 	}
 }
 
@@ -212,7 +221,7 @@ func (mt *MultiplexTransport) Dial(
 	addr NetAddress,
 	cfg peerConfig,
 ) (Peer, error) {
-	c, err := addr.DialTimeout(mt.dialTimeout)
+	c, err := mt.dial(addr, mt.dialTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -247,7 +256,7 @@ func (mt *MultiplexTransport) Close() error {
 
 // Listen implements transportLifecycle.
 func (mt *MultiplexTransport) Listen(addr NetAddress) error {
-	ln, err := net.Listen("tcp", addr.DialString())
+	ln, err := mt.listen(addr)
 	if err != nil {
 		return err
 	}
@@ -264,11 +273,35 @@ func (mt *MultiplexTransport) Listen(addr NetAddress) error {
 	return nil
 }
 
+func listenTCP(addr NetAddress) (net.Listener, error) {
+	return net.Listen("tcp", addr.DialString())
+}
+
+func dialTCP(addr NetAddress, timeout time.Duration) (net.Conn, error) {
+	return addr.DialTimeout(timeout)
+}
+
+// SetNodeInfo sets the NodeInfo sent to peers during the handshake. Peers
+// which already completed it keep the previous one.
+func (mt *MultiplexTransport) SetNodeInfo(nodeInfo NodeInfo) {
+	mt.nodeInfoMtx.Lock()
+	defer mt.nodeInfoMtx.Unlock()
+	mt.nodeInfo = nodeInfo
+}
+
+func (mt *MultiplexTransport) ourNodeInfo() NodeInfo {
+	mt.nodeInfoMtx.RLock()
+	defer mt.nodeInfoMtx.RUnlock()
+	return mt.nodeInfo
+}
+
 // AddChannel registers a channel to nodeInfo.
 // NOTE: NodeInfo must be of type DefaultNodeInfo else channels won't be updated
 // This is a bit messy at the moment but is cleaned up in the following version
 // when NodeInfo changes from an interface to a concrete type
 func (mt *MultiplexTransport) AddChannel(chID byte) {
+	mt.nodeInfoMtx.Lock()
+	defer mt.nodeInfoMtx.Unlock()
 	if ni, ok := mt.nodeInfo.(DefaultNodeInfo); ok {
 		if !ni.HasChannel(chID) {
 			ni.Channels = append(ni.Channels, chID)
@@ -438,7 +471,8 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+	ourNodeInfo := mt.ourNodeInfo()
+	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, ourNodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -470,7 +504,7 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
+	if ourNodeInfo.ID() == nodeInfo.ID() {
 		return nil, nil, ErrRejected{
 			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
@@ -479,7 +513,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
+	if err := ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
 		return nil, nil, ErrRejected{
 			conn:           c,
 			err:            err,
@@ -0,0 +1,156 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p/conn"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// MemoryNetwork is an in-process network connecting MultiplexTransports
+// through in-memory pipes instead of tcp. It allows running several nodes in
+// a single process, e.g. in tests, without allocating ports.
+type MemoryNetwork struct {
+	mtx       tmsync.Mutex
+	listeners map[string]*memoryListener
+	nextPort  int
+}
+
+// NewMemoryNetwork returns an empty MemoryNetwork.
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{
+		listeners: make(map[string]*memoryListener),
+		nextPort:  1 << 15,
+	}
+}
+
+// NewMemoryTransport returns a MultiplexTransport which listens on and dials
+// addresses of the given network. Connections are still authenticated and
+// multiplexed like tcp ones.
+func NewMemoryTransport(
+	network *MemoryNetwork,
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+) *MultiplexTransport {
+	mt := NewMultiplexTransport(nodeInfo, nodeKey, mConfig)
+	mt.listen = network.listen
+	mt.dial = func(addr NetAddress, timeout time.Duration) (net.Conn, error) {
+		return network.dial(mt.netAddr.IP, addr, timeout)
+	}
+	return mt
+}
+
+func (n *MemoryNetwork) listen(addr NetAddress) (net.Listener, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	key := addr.DialString()
+	if _, ok := n.listeners[key]; ok {
+		return nil, fmt.Errorf("memory address %v already in use", key)
+	}
+
+	l := &memoryListener{
+		network: n,
+		key:     key,
+		addr:    &net.TCPAddr{IP: addr.IP, Port: int(addr.Port)},
+		connc:   make(chan net.Conn),
+		closec:  make(chan struct{}),
+	}
+	n.listeners[key] = l
+
+	return l, nil
+}
+
+// dial connects to the listener at addr. As with tcp, the dialing side is
+// given a fresh port so that every connection has a distinct remote address.
+func (n *MemoryNetwork) dial(ip net.IP, addr NetAddress, timeout time.Duration) (net.Conn, error) {
+	n.mtx.Lock()
+	l, ok := n.listeners[addr.DialString()]
+	n.nextPort++
+	port := n.nextPort
+	n.mtx.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("dial memory %v: connection refused", addr.DialString())
+	}
+
+	if ip == nil {
+		ip = net.IPv4(127, 0, 0, 1)
+	}
+	local := &net.TCPAddr{IP: ip, Port: port}
+
+	c1, c2 := net.Pipe()
+	select {
+	case l.connc <- &memoryConn{Conn: c2, local: l.addr, remote: local}:
+		return &memoryConn{Conn: c1, local: local, remote: l.addr}, nil
+	case <-l.closec:
+		return nil, fmt.Errorf("dial memory %v: connection refused", addr.DialString())
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("dial memory %v: timed out", addr.DialString())
+	}
+}
+
+func (n *MemoryNetwork) remove(l *memoryListener) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	delete(n.listeners, l.key)
+}
+
+// memoryListener implements net.Listener for a MemoryNetwork.
+type memoryListener struct {
+	network *MemoryNetwork
+	key     string
+	addr    *net.TCPAddr
+
+	connc     chan net.Conn
+	closec    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*memoryListener)(nil)
+
+func (l *memoryListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.connc:
+		return c, nil
+	case <-l.closec:
+		return nil, errors.New("memory listener closed")
+	}
+}
+
+func (l *memoryListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closec)
+		l.network.remove(l)
+	})
+	return nil
+}
+
+func (l *memoryListener) Addr() net.Addr {
+	return l.addr
+}
+
+// memoryConn is one end of an in-memory pipe. It reports tcp addresses so
+// that the rest of the transport (ip filters, NetAddress) treats it like a
+// regular connection.
+type memoryConn struct {
+	net.Conn
+
+	local  *net.TCPAddr
+	remote *net.TCPAddr
+}
+
+func (c *memoryConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *memoryConn) RemoteAddr() net.Addr {
+	return c.remote
+}
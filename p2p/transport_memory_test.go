@@ -0,0 +1,84 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/p2p/conn"
+	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+func TestMemoryTransportSwitches(t *testing.T) {
+	memNet := NewMemoryNetwork()
+
+	// testNodeInfo already announces testCh, so it must not be added again
+	initSwitch := func(i int, sw *Switch) *Switch {
+		sw.AddReactor("foo", NewTestReactor([]*conn.ChannelDescriptor{
+			{ID: byte(0x00), Priority: 10, MessageType: &p2pproto.Message{}},
+		}, true))
+		return sw
+	}
+	switches := make([]*Switch, 3)
+	for i := range switches {
+		switches[i] = MakeMemorySwitch(memNet, cfg, i, initSwitch)
+	}
+	require.NoError(t, StartSwitches(switches))
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	// connect every switch to every other one
+	for i := range switches {
+		for j := i + 1; j < len(switches); j++ {
+			addr := switches[j].NetAddress()
+			require.NoError(t, switches[i].DialPeerWithAddress(addr))
+		}
+	}
+	for i, sw := range switches {
+		assert.Eventually(t, func() bool {
+			return sw.Peers().Size() == len(switches)-1
+		}, 5*time.Second, 10*time.Millisecond, "switch %d", i)
+	}
+
+	msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "1"}}}
+	switches[0].BroadcastEnvelope(Envelope{ChannelID: byte(0x00), Message: msg})
+	for i, sw := range switches[1:] {
+		reactor := sw.Reactor("foo").(*TestReactor)
+		assert.Eventually(t, func() bool {
+			return len(reactor.getMsgs(byte(0x00))) == 1
+		}, 5*time.Second, 10*time.Millisecond, "switch %d", i+1)
+	}
+}
+
+func TestMemoryTransportDialUnknownAddress(t *testing.T) {
+	var (
+		memNet = NewMemoryNetwork()
+		pv     = ed25519.GenPrivKey()
+		id     = PubKeyToID(pv.PubKey())
+		mt     = NewMemoryTransport(memNet, testNodeInfo(id, defaultNodeName), NodeKey{PrivKey: pv}, MConnConfig(cfg))
+	)
+
+	addr, err := NewNetAddressString(IDAddressString(id, "127.0.0.1:26656"))
+	require.NoError(t, err)
+
+	_, err = mt.Dial(*addr, peerConfig{})
+	assert.Error(t, err)
+
+	// once a transport listens on the address, it is taken
+	require.NoError(t, mt.Listen(*addr))
+	other := NewMemoryTransport(memNet, testNodeInfo(id, defaultNodeName), NodeKey{PrivKey: pv}, MConnConfig(cfg))
+	assert.Error(t, other.Listen(*addr))
+
+	// and it is released on close
+	require.NoError(t, mt.Close())
+	assert.NoError(t, other.Listen(*addr))
+	assert.NoError(t, other.Close())
+}
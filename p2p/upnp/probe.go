@@ -13,6 +13,27 @@ type Capabilities struct {
 	Hairpin     bool
 }
 
+// MapPort discovers the internet gateway device and forwards tcp connections
+// on its extPort to intPort on this host. It returns the gateway, which is
+// needed to delete the mapping again, and the gateway's external address.
+func MapPort(intPort, extPort int, description string) (NAT, net.IP, error) {
+	nat, err := Discover()
+	if err != nil {
+		return nil, nil, fmt.Errorf("nat upnp could not be discovered: %v", err)
+	}
+
+	ext, err := nat.GetExternalAddress()
+	if err != nil {
+		return nil, nil, fmt.Errorf("external address error: %v", err)
+	}
+
+	if _, err := nat.AddPortMapping("tcp", extPort, intPort, description, 0); err != nil {
+		return nil, nil, fmt.Errorf("port mapping error: %v", err)
+	}
+
+	return nat, ext, nil
+}
+
 func makeUPNPListener(intPort int, extPort int, logger log.Logger) (NAT, net.Listener, net.IP, error) {
 	nat, err := Discover()
 	if err != nil {
@@ -148,6 +148,8 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	lock *tmos.FileLock
 }
 
 // NewFilePV generates a new validator from the given key and paths.
@@ -236,6 +238,33 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return pv
 }
 
+// Lock acquires an exclusive lock on the key file, so that no other process
+// can sign with the same key while it is held. It should be called before the
+// FilePV is used for signing. An error is returned if another process already
+// holds the lock (e.g. a misconfigured failover node).
+func (pv *FilePV) Lock() error {
+	if pv.lock != nil {
+		return nil
+	}
+	lock, err := tmos.LockFile(pv.Key.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to lock private validator key file %s: %w", pv.Key.filePath, err)
+	}
+	pv.lock = lock
+	return nil
+}
+
+// Unlock releases the lock acquired by Lock. It is a no-op if the lock is not
+// held.
+func (pv *FilePV) Unlock() error {
+	if pv.lock == nil {
+		return nil
+	}
+	err := pv.lock.Unlock()
+	pv.lock = nil
+	return err
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FilePV) GetAddress() types.Address {
@@ -13,6 +13,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -91,6 +92,28 @@ func TestLoadOrGenValidator(t *testing.T) {
 	assert.Equal(addr, privVal.GetAddress(), "expected privval addr to be the same")
 }
 
+func TestLockValidator(t *testing.T) {
+	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := os.CreateTemp("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.Save()
+	require.NoError(t, privVal.Lock())
+
+	// a second instance using the same key file must not be able to lock it
+	other := LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	err = other.Lock()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, tmos.ErrFileLocked)
+
+	// once released, the key file can be locked again
+	require.NoError(t, privVal.Unlock())
+	require.NoError(t, other.Lock())
+	require.NoError(t, other.Unlock())
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
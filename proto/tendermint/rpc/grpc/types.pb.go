@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	types "github.com/tendermint/tendermint/abci/types"
+	state "github.com/tendermint/tendermint/proto/tendermint/state"
+	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -107,6 +109,50 @@ func (m *RequestBroadcastTx) GetTx() []byte {
 	return nil
 }
 
+type RequestStreamBlocks struct {
+	FromHeight int64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+}
+
+func (m *RequestStreamBlocks) Reset()         { *m = RequestStreamBlocks{} }
+func (m *RequestStreamBlocks) String() string { return proto.CompactTextString(m) }
+func (*RequestStreamBlocks) ProtoMessage()    {}
+func (*RequestStreamBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{2}
+}
+func (m *RequestStreamBlocks) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestStreamBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestStreamBlocks.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestStreamBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestStreamBlocks.Merge(m, src)
+}
+func (m *RequestStreamBlocks) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestStreamBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestStreamBlocks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestStreamBlocks proto.InternalMessageInfo
+
+func (m *RequestStreamBlocks) GetFromHeight() int64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
 type ResponsePing struct {
 }
 
@@ -114,7 +160,7 @@ func (m *ResponsePing) Reset()         { *m = ResponsePing{} }
 func (m *ResponsePing) String() string { return proto.CompactTextString(m) }
 func (*ResponsePing) ProtoMessage()    {}
 func (*ResponsePing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0ffff5682c662b95, []int{2}
+	return fileDescriptor_0ffff5682c662b95, []int{3}
 }
 func (m *ResponsePing) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -152,7 +198,7 @@ func (m *ResponseBroadcastTx) Reset()         { *m = ResponseBroadcastTx{} }
 func (m *ResponseBroadcastTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseBroadcastTx) ProtoMessage()    {}
 func (*ResponseBroadcastTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0ffff5682c662b95, []int{3}
+	return fileDescriptor_0ffff5682c662b95, []int{4}
 }
 func (m *ResponseBroadcastTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -195,37 +241,99 @@ func (m *ResponseBroadcastTx) GetDeliverTx() *types.ResponseDeliverTx {
 	return nil
 }
 
+type ResponseStreamBlocks struct {
+	Block   *types1.Block        `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Results *state.ABCIResponses `protobuf:"bytes,2,opt,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *ResponseStreamBlocks) Reset()         { *m = ResponseStreamBlocks{} }
+func (m *ResponseStreamBlocks) String() string { return proto.CompactTextString(m) }
+func (*ResponseStreamBlocks) ProtoMessage()    {}
+func (*ResponseStreamBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{5}
+}
+func (m *ResponseStreamBlocks) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseStreamBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseStreamBlocks.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseStreamBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseStreamBlocks.Merge(m, src)
+}
+func (m *ResponseStreamBlocks) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseStreamBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseStreamBlocks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseStreamBlocks proto.InternalMessageInfo
+
+func (m *ResponseStreamBlocks) GetBlock() *types1.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *ResponseStreamBlocks) GetResults() *state.ABCIResponses {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RequestPing)(nil), "tendermint.rpc.grpc.RequestPing")
 	proto.RegisterType((*RequestBroadcastTx)(nil), "tendermint.rpc.grpc.RequestBroadcastTx")
+	proto.RegisterType((*RequestStreamBlocks)(nil), "tendermint.rpc.grpc.RequestStreamBlocks")
 	proto.RegisterType((*ResponsePing)(nil), "tendermint.rpc.grpc.ResponsePing")
 	proto.RegisterType((*ResponseBroadcastTx)(nil), "tendermint.rpc.grpc.ResponseBroadcastTx")
+	proto.RegisterType((*ResponseStreamBlocks)(nil), "tendermint.rpc.grpc.ResponseStreamBlocks")
 }
 
 func init() { proto.RegisterFile("tendermint/rpc/grpc/types.proto", fileDescriptor_0ffff5682c662b95) }
 
 var fileDescriptor_0ffff5682c662b95 = []byte{
-	// 316 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2f, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x2a, 0x48, 0xd6, 0x4f, 0x07, 0x11, 0x25, 0x95,
-	0x05, 0xa9, 0xc5, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xc2, 0x08, 0x05, 0x7a, 0x45, 0x05,
-	0xc9, 0x7a, 0x20, 0x05, 0x52, 0xd2, 0x48, 0xba, 0x12, 0x93, 0x92, 0x33, 0x91, 0x75, 0x28, 0xf1,
-	0x72, 0x71, 0x07, 0xa5, 0x16, 0x96, 0xa6, 0x16, 0x97, 0x04, 0x64, 0xe6, 0xa5, 0x2b, 0xa9, 0x70,
-	0x09, 0x41, 0xb9, 0x4e, 0x45, 0xf9, 0x89, 0x29, 0xc9, 0x89, 0xc5, 0x25, 0x21, 0x15, 0x42, 0x7c,
-	0x5c, 0x4c, 0x25, 0x15, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x4c, 0x25, 0x15, 0x4a, 0x7c,
-	0x5c, 0x3c, 0x41, 0xa9, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x60, 0x5d, 0x53, 0x19, 0xb9, 0x84,
-	0x61, 0x02, 0xc8, 0xfa, 0xac, 0xb9, 0x38, 0x92, 0x33, 0x52, 0x93, 0xb3, 0xe3, 0xa1, 0xba, 0xb9,
-	0x8d, 0x14, 0xf4, 0x90, 0x5c, 0x08, 0x72, 0x8c, 0x1e, 0x4c, 0x9f, 0x33, 0x48, 0x61, 0x48, 0x45,
-	0x10, 0x7b, 0x32, 0x84, 0x21, 0xe4, 0xc8, 0xc5, 0x95, 0x92, 0x9a, 0x93, 0x59, 0x96, 0x5a, 0x04,
-	0xd2, 0xce, 0x04, 0xd6, 0xae, 0x84, 0x53, 0xbb, 0x0b, 0x44, 0x69, 0x48, 0x45, 0x10, 0x67, 0x0a,
-	0x8c, 0x69, 0xb4, 0x97, 0x91, 0x8b, 0x07, 0xee, 0x1e, 0xc7, 0x00, 0x4f, 0x21, 0x6f, 0x2e, 0x16,
-	0x90, 0x83, 0x85, 0x50, 0x9c, 0x01, 0x0b, 0x28, 0x3d, 0xa4, 0x80, 0x90, 0x52, 0xc4, 0xa1, 0x02,
-	0xe1, 0x6b, 0xa1, 0x04, 0x2e, 0x6e, 0x64, 0xcf, 0xaa, 0xe3, 0x33, 0x13, 0x49, 0xa1, 0x94, 0x06,
-	0x5e, 0xa3, 0x91, 0x54, 0x3a, 0xf9, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83,
-	0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43,
-	0x94, 0x51, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0x52, 0xf4, 0x62,
-	0x49, 0x1f, 0xd6, 0xc9, 0xf9, 0x45, 0xa9, 0x20, 0x46, 0x12, 0x1b, 0x38, 0xc6, 0x8d, 0x01, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xf6, 0x4b, 0x02, 0xd8, 0x46, 0x02, 0x00, 0x00,
+	// 447 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0x3f, 0x8f, 0xd3, 0x30,
+	0x18, 0xc6, 0xeb, 0xf2, 0xe7, 0x8e, 0xb7, 0xe5, 0x06, 0x17, 0x09, 0x14, 0x50, 0x5a, 0x22, 0x24,
+	0xca, 0x80, 0x8b, 0x8a, 0x84, 0x84, 0x6e, 0x6a, 0x8f, 0x81, 0x13, 0x0c, 0x27, 0xd3, 0x89, 0xe5,
+	0x48, 0xdd, 0x97, 0x36, 0xba, 0x36, 0xce, 0xd9, 0x2e, 0x0a, 0x1b, 0x1f, 0x81, 0x85, 0x8f, 0xc3,
+	0xce, 0x78, 0x23, 0x23, 0x6a, 0xbf, 0x08, 0xb2, 0x93, 0x70, 0x3e, 0x71, 0xcd, 0x12, 0x39, 0xaf,
+	0x7f, 0x8f, 0x9f, 0x37, 0x8f, 0xf3, 0x42, 0xd7, 0x60, 0x3a, 0x43, 0xb5, 0x4a, 0x52, 0x33, 0x50,
+	0x99, 0x18, 0xcc, 0xed, 0xc3, 0x7c, 0xcd, 0x50, 0xb3, 0x4c, 0x49, 0x23, 0x69, 0xe7, 0x12, 0x60,
+	0x2a, 0x13, 0xcc, 0x02, 0xc1, 0x43, 0x4f, 0x15, 0x4f, 0x45, 0xe2, 0x2b, 0x82, 0x47, 0xde, 0xa6,
+	0xab, 0x0f, 0xa6, 0x4b, 0x29, 0xce, 0xae, 0xd9, 0xd5, 0x26, 0x36, 0xe8, 0x6b, 0xa3, 0xbb, 0xd0,
+	0xe2, 0x78, 0xbe, 0x46, 0x6d, 0x4e, 0x92, 0x74, 0x1e, 0x3d, 0x01, 0x5a, 0xbe, 0x8e, 0x95, 0x8c,
+	0x67, 0x22, 0xd6, 0x66, 0x92, 0xd3, 0x03, 0x68, 0x9a, 0xfc, 0x01, 0xe9, 0x91, 0x7e, 0x9b, 0x37,
+	0x4d, 0x1e, 0xbd, 0x82, 0x4e, 0x49, 0x7d, 0x30, 0x0a, 0xe3, 0xd5, 0xd8, 0xda, 0x69, 0xda, 0x85,
+	0xd6, 0x67, 0x25, 0x57, 0xa7, 0x0b, 0x4c, 0xe6, 0x0b, 0xe3, 0xf8, 0x1b, 0x1c, 0x6c, 0xe9, 0xad,
+	0xab, 0x44, 0x07, 0xd0, 0xe6, 0xa8, 0x33, 0x99, 0x6a, 0x74, 0x6e, 0x3f, 0x08, 0x74, 0xaa, 0x82,
+	0xef, 0x77, 0x08, 0xfb, 0x62, 0x81, 0xe2, 0xec, 0xb4, 0x74, 0x6d, 0x0d, 0x7b, 0xcc, 0x4b, 0xc5,
+	0x06, 0xc0, 0x2a, 0xdd, 0x91, 0x05, 0x27, 0x39, 0xdf, 0x13, 0xc5, 0x82, 0x8e, 0x00, 0x66, 0xb8,
+	0x4c, 0xbe, 0xa0, 0xb2, 0xf2, 0xa6, 0x93, 0x47, 0x3b, 0xe5, 0x6f, 0x0a, 0x74, 0x92, 0xf3, 0x3b,
+	0xb3, 0x6a, 0x19, 0x7d, 0x23, 0x70, 0xaf, 0x02, 0xae, 0x7c, 0xe1, 0x73, 0xb8, 0xe5, 0xa2, 0x2d,
+	0xbb, 0xba, 0xef, 0x1f, 0x5b, 0xa4, 0xea, 0x40, 0x5e, 0x50, 0xf4, 0x35, 0xec, 0x29, 0xd4, 0xeb,
+	0xa5, 0xd1, 0x65, 0x1f, 0x5d, 0x5f, 0xe0, 0x2e, 0x83, 0x8d, 0xc6, 0x47, 0xc7, 0x95, 0x97, 0xe6,
+	0x15, 0x3f, 0xfc, 0x49, 0xa0, 0xfd, 0x2f, 0x92, 0xd1, 0xc9, 0x31, 0x7d, 0x07, 0x37, 0x6d, 0x66,
+	0xb4, 0xc7, 0xae, 0xf9, 0x3f, 0x98, 0x77, 0x87, 0xc1, 0xe3, 0x1d, 0xc4, 0x65, 0xf0, 0xf4, 0x13,
+	0xb4, 0xfc, 0xbc, 0x9f, 0xd6, 0x9d, 0xe9, 0x81, 0x41, 0xbf, 0xf6, 0x68, 0x8f, 0x1c, 0x9e, 0xc3,
+	0xbe, 0x8b, 0xc2, 0xb6, 0x8e, 0xd0, 0xbe, 0x92, 0x62, 0xbf, 0xce, 0xce, 0x27, 0x83, 0x67, 0xb5,
+	0x7e, 0x3e, 0xfa, 0x82, 0x8c, 0xdf, 0xff, 0xda, 0x84, 0xe4, 0x62, 0x13, 0x92, 0x3f, 0x9b, 0x90,
+	0x7c, 0xdf, 0x86, 0x8d, 0x8b, 0x6d, 0xd8, 0xf8, 0xbd, 0x0d, 0x1b, 0x1f, 0x87, 0xf3, 0xc4, 0x2c,
+	0xd6, 0x53, 0x26, 0xe4, 0x6a, 0xe0, 0xcf, 0xca, 0xff, 0x93, 0x78, 0x28, 0xa4, 0x42, 0xbb, 0x98,
+	0xde, 0x76, 0xf3, 0xf1, 0xf2, 0xef, 0x00, 0x80, 0x2f, 0x7a, 0xfb, 0xb0, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -344,6 +452,105 @@ var _BroadcastAPI_serviceDesc = grpc.ServiceDesc{
 	Metadata: "tendermint/rpc/grpc/types.proto",
 }
 
+// BlockAPIClient is the client API for BlockAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockAPIClient interface {
+	StreamBlocks(ctx context.Context, in *RequestStreamBlocks, opts ...grpc.CallOption) (BlockAPI_StreamBlocksClient, error)
+}
+
+type blockAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlockAPIClient(cc *grpc.ClientConn) BlockAPIClient {
+	return &blockAPIClient{cc}
+}
+
+func (c *blockAPIClient) StreamBlocks(ctx context.Context, in *RequestStreamBlocks, opts ...grpc.CallOption) (BlockAPI_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockAPI_serviceDesc.Streams[0], "/tendermint.rpc.grpc.BlockAPI/StreamBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockAPIStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockAPI_StreamBlocksClient interface {
+	Recv() (*ResponseStreamBlocks, error)
+	grpc.ClientStream
+}
+
+type blockAPIStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockAPIStreamBlocksClient) Recv() (*ResponseStreamBlocks, error) {
+	m := new(ResponseStreamBlocks)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockAPIServer is the server API for BlockAPI service.
+type BlockAPIServer interface {
+	StreamBlocks(*RequestStreamBlocks, BlockAPI_StreamBlocksServer) error
+}
+
+// UnimplementedBlockAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockAPIServer struct {
+}
+
+func (*UnimplementedBlockAPIServer) StreamBlocks(req *RequestStreamBlocks, srv BlockAPI_StreamBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlocks not implemented")
+}
+
+func RegisterBlockAPIServer(s *grpc.Server, srv BlockAPIServer) {
+	s.RegisterService(&_BlockAPI_serviceDesc, srv)
+}
+
+func _BlockAPI_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestStreamBlocks)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockAPIServer).StreamBlocks(m, &blockAPIStreamBlocksServer{stream})
+}
+
+type BlockAPI_StreamBlocksServer interface {
+	Send(*ResponseStreamBlocks) error
+	grpc.ServerStream
+}
+
+type blockAPIStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockAPIStreamBlocksServer) Send(m *ResponseStreamBlocks) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _BlockAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.rpc.grpc.BlockAPI",
+	HandlerType: (*BlockAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _BlockAPI_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tendermint/rpc/grpc/types.proto",
+}
+
 func (m *RequestPing) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -397,6 +604,34 @@ func (m *RequestBroadcastTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestStreamBlocks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestStreamBlocks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestStreamBlocks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ResponsePing) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -467,6 +702,53 @@ func (m *ResponseBroadcastTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ResponseStreamBlocks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseStreamBlocks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseStreamBlocks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Results != nil {
+		{
+			size, err := m.Results.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -500,6 +782,18 @@ func (m *RequestBroadcastTx) Size() (n int) {
 	return n
 }
 
+func (m *RequestStreamBlocks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovTypes(uint64(m.FromHeight))
+	}
+	return n
+}
+
 func (m *ResponsePing) Size() (n int) {
 	if m == nil {
 		return 0
@@ -526,6 +820,23 @@ func (m *ResponseBroadcastTx) Size() (n int) {
 	return n
 }
 
+func (m *ResponseStreamBlocks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Results != nil {
+		l = m.Results.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -666,6 +977,75 @@ func (m *RequestBroadcastTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RequestStreamBlocks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestStreamBlocks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestStreamBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
+			}
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ResponsePing) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -838,6 +1218,128 @@ func (m *ResponseBroadcastTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseStreamBlocks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseStreamBlocks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseStreamBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &types1.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Results == nil {
+				m.Results = &state.ABCIResponses{}
+			}
+			if err := m.Results.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5,16 +5,23 @@ package store
 
 import (
 	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	bits "github.com/tendermint/tendermint/proto/tendermint/libs/bits"
+	types "github.com/tendermint/tendermint/proto/tendermint/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -74,25 +81,148 @@ func (m *BlockStoreState) GetHeight() int64 {
 	return 0
 }
 
+// CompactCommit is the encoding of a Commit in the block store. Instead of a
+// CommitSig per validator, it records which validators signed in bitmaps keyed
+// by validator index, and the fields of the signatures of those that did.
+type CompactCommit struct {
+	Height  int64         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round   int32         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockID types.BlockID `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
+	// validators who signed, for the block or for nil
+	Signed bits.BitArray `protobuf:"bytes,4,opt,name=signed,proto3" json:"signed"`
+	// validators who signed for nil
+	SignedNil bits.BitArray `protobuf:"bytes,5,opt,name=signed_nil,json=signedNil,proto3" json:"signed_nil"`
+	// the fields of the signatures of the validators in signed, in order
+	ValidatorAddresses [][]byte    `protobuf:"bytes,6,rep,name=validator_addresses,json=validatorAddresses,proto3" json:"validator_addresses,omitempty"`
+	Timestamps         []time.Time `protobuf:"bytes,7,rep,name=timestamps,proto3,stdtime" json:"timestamps"`
+	Signatures         [][]byte    `protobuf:"bytes,8,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (m *CompactCommit) Reset()         { *m = CompactCommit{} }
+func (m *CompactCommit) String() string { return proto.CompactTextString(m) }
+func (*CompactCommit) ProtoMessage()    {}
+func (*CompactCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9e53a0a74267f7, []int{1}
+}
+func (m *CompactCommit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactCommit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactCommit.Merge(m, src)
+}
+func (m *CompactCommit) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactCommit proto.InternalMessageInfo
+
+func (m *CompactCommit) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactCommit) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactCommit) GetBlockID() types.BlockID {
+	if m != nil {
+		return m.BlockID
+	}
+	return types.BlockID{}
+}
+
+func (m *CompactCommit) GetSigned() bits.BitArray {
+	if m != nil {
+		return m.Signed
+	}
+	return bits.BitArray{}
+}
+
+func (m *CompactCommit) GetSignedNil() bits.BitArray {
+	if m != nil {
+		return m.SignedNil
+	}
+	return bits.BitArray{}
+}
+
+func (m *CompactCommit) GetValidatorAddresses() [][]byte {
+	if m != nil {
+		return m.ValidatorAddresses
+	}
+	return nil
+}
+
+func (m *CompactCommit) GetTimestamps() []time.Time {
+	if m != nil {
+		return m.Timestamps
+	}
+	return nil
+}
+
+func (m *CompactCommit) GetSignatures() [][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockStoreState)(nil), "tendermint.store.BlockStoreState")
+	proto.RegisterType((*CompactCommit)(nil), "tendermint.store.CompactCommit")
 }
 
 func init() { proto.RegisterFile("tendermint/store/types.proto", fileDescriptor_ff9e53a0a74267f7) }
 
 var fileDescriptor_ff9e53a0a74267f7 = []byte{
-	// 165 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x29, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x2e, 0xc9, 0x2f, 0x4a, 0xd5, 0x2f, 0xa9, 0x2c,
-	0x48, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x40, 0xc8, 0xea, 0x81, 0x65, 0x95,
-	0x6c, 0xb9, 0xf8, 0x9d, 0x72, 0xf2, 0x93, 0xb3, 0x83, 0x41, 0xbc, 0xe0, 0x92, 0xc4, 0x92, 0x54,
-	0x21, 0x21, 0x2e, 0x96, 0xa4, 0xc4, 0xe2, 0x54, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xe6, 0x20, 0x30,
-	0x5b, 0x48, 0x8c, 0x8b, 0x2d, 0x23, 0x35, 0x33, 0x3d, 0xa3, 0x44, 0x82, 0x09, 0x2c, 0x0a, 0xe5,
-	0x39, 0x05, 0x9e, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13,
-	0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x79, 0x7a, 0x66,
-	0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0x92, 0x9b, 0x90, 0x98, 0x60, 0x27, 0xe9,
-	0xa3, 0xbb, 0x37, 0x89, 0x0d, 0x2c, 0x6e, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xef, 0xa6, 0x30,
-	0x63, 0xca, 0x00, 0x00, 0x00,
+	// 430 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xcd, 0x6e, 0x13, 0x31,
+	0x10, 0xce, 0xb2, 0xf9, 0xc3, 0x05, 0x15, 0x99, 0x0a, 0x99, 0x08, 0x39, 0xab, 0x9e, 0x72, 0xb2,
+	0xa5, 0x72, 0xe0, 0x02, 0x87, 0x6e, 0xca, 0xa1, 0x17, 0x24, 0xb6, 0x9c, 0xb8, 0x44, 0xde, 0xd8,
+	0x6c, 0x2c, 0x76, 0xd7, 0x91, 0x3d, 0x41, 0xea, 0x5b, 0xf4, 0x09, 0x78, 0x9e, 0x1e, 0x7b, 0xe4,
+	0x54, 0x50, 0xf2, 0x22, 0x68, 0xed, 0xa4, 0xb5, 0xb8, 0x71, 0x9b, 0x99, 0x6f, 0xe6, 0xfb, 0x3e,
+	0xcd, 0x0c, 0x7a, 0x03, 0xaa, 0x95, 0xca, 0x36, 0xba, 0x05, 0xee, 0xc0, 0x58, 0xc5, 0xe1, 0x7a,
+	0xad, 0x1c, 0x5b, 0x5b, 0x03, 0x06, 0xbf, 0x78, 0x44, 0x99, 0x47, 0x27, 0x27, 0x95, 0xa9, 0x8c,
+	0x07, 0x79, 0x17, 0x85, 0xbe, 0xc9, 0xb4, 0x32, 0xa6, 0xaa, 0x15, 0xf7, 0x59, 0xb9, 0xf9, 0xc6,
+	0x41, 0x37, 0xca, 0x81, 0x68, 0xd6, 0xfb, 0x86, 0x2c, 0x92, 0xa9, 0x75, 0xe9, 0x78, 0xa9, 0xc1,
+	0xc5, 0x52, 0x93, 0xd8, 0x88, 0xaf, 0xc7, 0xe8, 0xe9, 0x07, 0x74, 0x9c, 0xd7, 0x66, 0xf9, 0xfd,
+	0xaa, 0x33, 0x71, 0x05, 0x02, 0x14, 0xc6, 0xa8, 0x5f, 0x0a, 0xa7, 0x48, 0x92, 0x25, 0xb3, 0xb4,
+	0xf0, 0x31, 0x7e, 0x85, 0x86, 0x2b, 0xa5, 0xab, 0x15, 0x90, 0x27, 0xbe, 0xba, 0xcf, 0x4e, 0x7f,
+	0xa6, 0xe8, 0xf9, 0xdc, 0x34, 0x6b, 0xb1, 0x84, 0xb9, 0x69, 0x1a, 0x0d, 0x51, 0x67, 0x12, 0x77,
+	0xe2, 0x13, 0x34, 0xb0, 0x66, 0xd3, 0x4a, 0x4f, 0x30, 0x28, 0x42, 0x82, 0x3f, 0xa2, 0x71, 0xd9,
+	0xc9, 0x2f, 0xb4, 0x24, 0x69, 0x96, 0xcc, 0x8e, 0xce, 0x5e, 0xb3, 0x68, 0x35, 0xc1, 0xa9, 0x37,
+	0x78, 0x79, 0x91, 0x1f, 0xdf, 0xde, 0x4f, 0x7b, 0xdb, 0xfb, 0xe9, 0x68, 0x5f, 0x28, 0x46, 0x7e,
+	0xf6, 0x52, 0xe2, 0xf7, 0x68, 0xe8, 0x74, 0xd5, 0x2a, 0x49, 0xfa, 0x9e, 0x84, 0xc6, 0x24, 0xdd,
+	0x5a, 0x58, 0xb7, 0x16, 0x96, 0x6b, 0x38, 0xb7, 0x56, 0x5c, 0xe7, 0xfd, 0x8e, 0xa9, 0xd8, 0xcf,
+	0xe0, 0x39, 0x42, 0x21, 0x5a, 0xb4, 0xba, 0x26, 0x83, 0xff, 0x60, 0x78, 0x1a, 0xe6, 0x3e, 0xe9,
+	0x1a, 0x73, 0xf4, 0xf2, 0x87, 0xa8, 0xb5, 0x14, 0x60, 0xec, 0x42, 0x48, 0x69, 0x95, 0x73, 0xca,
+	0x91, 0x61, 0x96, 0xce, 0x9e, 0x15, 0xf8, 0x01, 0x3a, 0x3f, 0x20, 0xf8, 0x02, 0xa1, 0x87, 0x63,
+	0x3a, 0x32, 0xca, 0xd2, 0xd9, 0xd1, 0xd9, 0x84, 0x85, 0x7b, 0xb3, 0xc3, 0xbd, 0xd9, 0x97, 0x43,
+	0x4b, 0x3e, 0xee, 0x14, 0x6f, 0x7e, 0x4f, 0x93, 0x22, 0x9a, 0xc3, 0x34, 0x78, 0x17, 0xb0, 0xb1,
+	0xca, 0x91, 0xb1, 0x57, 0x8b, 0x2a, 0xf9, 0xe7, 0xdb, 0x2d, 0x4d, 0xee, 0xb6, 0x34, 0xf9, 0xb3,
+	0xa5, 0xc9, 0xcd, 0x8e, 0xf6, 0xee, 0x76, 0xb4, 0xf7, 0x6b, 0x47, 0x7b, 0x5f, 0xdf, 0x55, 0x1a,
+	0x56, 0x9b, 0x92, 0x2d, 0x4d, 0xc3, 0xe3, 0x17, 0x79, 0x0c, 0xc3, 0x37, 0xfe, 0xfb, 0xc7, 0xe5,
+	0xd0, 0xd7, 0xdf, 0xfe, 0x1d, 0x00, 0xca, 0xde, 0xef, 0x94, 0xe2, 0x02, 0x00, 0x00,
 }
 
 func (m *BlockStoreState) Marshal() (dAtA []byte, err error) {
@@ -128,6 +258,99 @@ func (m *BlockStoreState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CompactCommit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactCommit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signatures) > 0 {
+		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Signatures[iNdEx])
+			copy(dAtA[i:], m.Signatures[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Signatures[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.Timestamps) > 0 {
+		for iNdEx := len(m.Timestamps) - 1; iNdEx >= 0; iNdEx-- {
+			n, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamps[iNdEx], dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamps[iNdEx]):])
+			if err != nil {
+				return 0, err
+			}
+			i -= n
+			i = encodeVarintTypes(dAtA, i, uint64(n))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.ValidatorAddresses) > 0 {
+		for iNdEx := len(m.ValidatorAddresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ValidatorAddresses[iNdEx])
+			copy(dAtA[i:], m.ValidatorAddresses[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorAddresses[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	{
+		size, err := m.SignedNil.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	{
+		size, err := m.Signed.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.BlockID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -154,6 +377,45 @@ func (m *BlockStoreState) Size() (n int) {
 	return n
 }
 
+func (m *CompactCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.BlockID.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.Signed.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.SignedNil.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ValidatorAddresses) > 0 {
+		for _, b := range m.ValidatorAddresses {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.Timestamps) > 0 {
+		for _, e := range m.Timestamps {
+			l = github_com_gogo_protobuf_types.SizeOfStdTime(e)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.Signatures) > 0 {
+		for _, b := range m.Signatures {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -248,6 +510,291 @@ func (m *BlockStoreState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CompactCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Signed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedNil", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SignedNil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddresses", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddresses = append(m.ValidatorAddresses, make([]byte, postIndex-iNdEx))
+			copy(m.ValidatorAddresses[len(m.ValidatorAddresses)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timestamps = append(m.Timestamps, time.Time{})
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&(m.Timestamps[len(m.Timestamps)-1]), dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -20,6 +20,8 @@ type AppConnConsensus interface {
 	DeliverTxAsync(types.RequestDeliverTx) *abcicli.ReqRes
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
 	CommitSync() (*types.ResponseCommit, error)
+
+	FlushSync() error
 }
 
 type AppConnMempool interface {
@@ -93,6 +95,10 @@ func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
 	return app.appConn.CommitSync()
 }
 
+func (app *appConnConsensus) FlushSync() error {
+	return app.appConn.FlushSync()
+}
+
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
@@ -100,7 +100,7 @@ func (c *Local) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resul
 }
 
 func (c *Local) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	return core.UnconfirmedTxs(c.ctx, limit)
+	return core.UnconfirmedTxs(c.ctx, limit, nil, "")
 }
 
 func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
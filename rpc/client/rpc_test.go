@@ -185,6 +185,10 @@ func TestGenesisAndValidators(t *testing.T) {
 		// make sure the current set is also the genesis set
 		assert.Equal(t, gval.Power, val.VotingPower)
 		assert.Equal(t, gval.PubKey, val.PubKey)
+
+		// the only validator is the proposer
+		require.NotNil(t, vals.Proposer)
+		assert.Equal(t, val.Address, vals.Proposer.Address)
 	}
 }
 
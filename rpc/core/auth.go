@@ -0,0 +1,49 @@
+package core
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// ErrUnauthorized is returned by unsafe RPC commands and debug dumps when
+// authentication is enabled and the request is not authenticated.
+var ErrUnauthorized = errors.New("unauthorized: a valid auth token or client certificate is required")
+
+// authorizeUnsafe checks that the caller is allowed to use unsafe RPC
+// commands and debug dumps. If neither unsafe_auth_token nor
+// tls_client_ca_file is configured, everybody is.
+//
+// Requests which did not come over HTTP or websocket (e.g. through the local
+// client) come from within the process and are always allowed. Websocket
+// requests are not, since the credentials of the connection are not kept.
+func authorizeUnsafe(ctx *rpctypes.Context) error {
+	if !env.Config.IsUnsafeAuthEnabled() {
+		return nil
+	}
+	if ctx.HTTPReq == nil {
+		if ctx.WSConn == nil {
+			return nil
+		}
+		return ErrUnauthorized
+	}
+
+	// a client certificate verified against tls_client_ca_file
+	if env.Config.TLSClientCAFile != "" && ctx.HTTPReq.TLS != nil &&
+		len(ctx.HTTPReq.TLS.VerifiedChains) > 0 {
+		return nil
+	}
+
+	if env.Config.UnsafeAuthToken != "" {
+		const prefix = "Bearer "
+		auth := ctx.HTTPReq.Header.Get("Authorization")
+		if strings.HasPrefix(auth, prefix) &&
+			subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), []byte(env.Config.UnsafeAuthToken)) == 1 {
+			return nil
+		}
+	}
+
+	return ErrUnauthorized
+}
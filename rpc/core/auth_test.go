@@ -0,0 +1,68 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cfg "github.com/tendermint/tendermint/config"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestAuthorizeUnsafe(t *testing.T) {
+	prevEnv := env
+	t.Cleanup(func() { env = prevEnv })
+
+	request := func(token string, verifiedCert bool) *rpctypes.Context {
+		r := httptest.NewRequest(http.MethodGet, "/dial_peers", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if verifiedCert {
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		}
+		return &rpctypes.Context{HTTPReq: r}
+	}
+
+	testCases := map[string]struct {
+		token    string
+		clientCA string
+		ctx      *rpctypes.Context
+		allowed  bool
+	}{
+		"auth disabled":            {"", "", request("", false), true},
+		"local client":             {"secret", "", &rpctypes.Context{}, true},
+		"websocket":                {"secret", "", &rpctypes.Context{WSConn: &wsConnStub{}}, false},
+		"missing token":            {"secret", "", request("", false), false},
+		"wrong token":              {"secret", "", request("guess", false), false},
+		"valid token":              {"secret", "", request("secret", false), true},
+		"missing client cert":      {"", "ca.pem", request("", false), false},
+		"valid client cert":        {"", "ca.pem", request("", true), true},
+		"token without cert":       {"secret", "ca.pem", request("secret", false), true},
+		"cert not used without CA": {"secret", "", request("", true), false},
+	}
+
+	for desc, tc := range testCases {
+		tc := tc
+		t.Run(desc, func(t *testing.T) {
+			env = &Environment{Config: *cfg.DefaultRPCConfig()}
+			env.Config.UnsafeAuthToken = tc.token
+			env.Config.TLSClientCAFile = tc.clientCA
+
+			err := authorizeUnsafe(tc.ctx)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, ErrUnauthorized, err)
+			}
+		})
+	}
+}
+
+type wsConnStub struct {
+	rpctypes.WSRPCConnection
+}
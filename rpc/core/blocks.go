@@ -150,8 +150,15 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 		return nil, err
 	}
 
+	// the block may have been pruned while its results were kept
+	var proposerAddress types.Address
+	if blockMeta := env.BlockStore.LoadBlockMeta(height); blockMeta != nil {
+		proposerAddress = blockMeta.Header.ProposerAddress
+	}
+
 	return &ctypes.ResultBlockResults{
 		Height:                height,
+		ProposerAddress:       proposerAddress,
 		TxsResults:            results.DeliverTxs,
 		BeginBlockEvents:      results.BeginBlock.Events,
 		EndBlockEvents:        results.EndBlock.Events,
@@ -160,6 +167,45 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 	}, nil
 }
 
+// ProveTxResult returns a merkle proof of the result of the transaction at the
+// given index in the block at the given height. The results of a block are
+// committed by the LastResultsHash of the next block, which is returned as
+// ResultsHash, so the proof can be verified against a trusted header.
+// If no height is provided, it will use the height before the latest block.
+// More: https://docs.tendermint.com/v0.34/rpc/#/Info/prove_tx_result
+func ProveTxResult(ctx *rpctypes.Context, heightPtr *int64, index uint32) (*ctypes.ResultProveTxResult, error) {
+	height, err := getHeight(env.BlockStore.Height()-1, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	if height <= 0 {
+		return nil, errors.New("no block results have been committed yet")
+	}
+
+	results, err := env.StateStore.LoadABCIResponses(height)
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(results.DeliverTxs) {
+		return nil, fmt.Errorf("index %d out of range, block %d has %d txs",
+			index, height, len(results.DeliverTxs))
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height + 1)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block %d, which commits the results of block %d, is not available", height+1, height)
+	}
+
+	abciResults := types.NewResults(results.DeliverTxs)
+	return &ctypes.ResultProveTxResult{
+		Height:      height,
+		Index:       index,
+		TxResult:    *abciResults[index],
+		Proof:       abciResults.ProveResult(int(index)),
+		ResultsHash: blockMeta.Header.LastResultsHash,
+	}, nil
+}
+
 // BlockSearch searches for a paginated set of blocks matching BeginBlock and
 // EndBlock event search criteria.
 func BlockSearch(
@@ -10,6 +10,8 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -86,7 +88,8 @@ func TestBlockResults(t *testing.T) {
 	})
 	err := env.StateStore.SaveABCIResponses(100, results)
 	require.NoError(t, err)
-	env.BlockStore = mockBlockStore{height: 100}
+	proposer := tmrand.Bytes(crypto.AddressSize)
+	env.BlockStore = mockBlockStore{height: 100, proposer: proposer}
 
 	testCases := []struct {
 		height  int64
@@ -98,6 +101,7 @@ func TestBlockResults(t *testing.T) {
 		{101, true, nil},
 		{100, false, &ctypes.ResultBlockResults{
 			Height:                100,
+			ProposerAddress:       proposer,
 			TxsResults:            results.DeliverTxs,
 			BeginBlockEvents:      results.BeginBlock.Events,
 			EndBlockEvents:        results.EndBlock.Events,
@@ -117,15 +121,78 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestProveTxResult(t *testing.T) {
+	results := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{
+			{Code: 0, Data: []byte{0x01}, Log: "ok", GasUsed: 10},
+			{Code: 1, Log: "not ok", GasWanted: 20},
+		},
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}
+	resultsHash := types.NewResults(results.DeliverTxs).Hash()
+
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	err := env.StateStore.SaveABCIResponses(100, results)
+	require.NoError(t, err)
+	env.BlockStore = mockBlockStore{height: 101, resultsHash: resultsHash}
+
+	testCases := []struct {
+		height  int64
+		index   uint32
+		wantErr bool
+	}{
+		{0, 0, true},
+		{99, 0, true},
+		{101, 0, true},
+		{100, 2, true},
+		{100, 0, false},
+		{100, 1, false},
+	}
+
+	for _, tc := range testCases {
+		res, err := ProveTxResult(&rpctypes.Context{}, &tc.height, tc.index)
+		if tc.wantErr {
+			assert.Error(t, err, "height %d index %d", tc.height, tc.index)
+			continue
+		}
+		require.NoError(t, err)
+		assert.EqualValues(t, resultsHash, res.ResultsHash)
+		assert.Equal(t, *types.NewResults(results.DeliverTxs)[tc.index], res.TxResult)
+		bz, err := res.TxResult.Marshal()
+		require.NoError(t, err)
+		assert.NoError(t, res.Proof.Verify(res.ResultsHash, bz))
+	}
+
+	// the latest results are not committed yet, so the height before is used
+	res, err := ProveTxResult(&rpctypes.Context{}, nil, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, res.Height)
+}
+
 type mockBlockStore struct {
-	height int64
+	height      int64
+	proposer    types.Address
+	resultsHash []byte
 }
 
-func (mockBlockStore) Base() int64                                       { return 1 }
-func (store mockBlockStore) Height() int64                               { return store.height }
-func (store mockBlockStore) Size() int64                                 { return store.height }
-func (mockBlockStore) LoadBaseMeta() *types.BlockMeta                    { return nil }
-func (mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta       { return nil }
+func (mockBlockStore) Base() int64                    { return 1 }
+func (store mockBlockStore) Height() int64            { return store.height }
+func (store mockBlockStore) Size() int64              { return store.height }
+func (mockBlockStore) LoadBaseMeta() *types.BlockMeta { return nil }
+func (store mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	if store.proposer == nil && store.resultsHash == nil {
+		return nil
+	}
+	return &types.BlockMeta{Header: types.Header{
+		Height:          height,
+		ProposerAddress: store.proposer,
+		LastResultsHash: store.resultsHash,
+	}}
+}
 func (mockBlockStore) LoadBlock(height int64) *types.Block               { return nil }
 func (mockBlockStore) LoadBlockByHash(hash []byte) *types.Block          { return nil }
 func (mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
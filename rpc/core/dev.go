@@ -1,12 +1,42 @@
 package core
 
 import (
+	"errors"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmflags "github.com/tendermint/tendermint/libs/cli/flags"
+	"github.com/tendermint/tendermint/libs/log"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
 // UnsafeFlushMempool removes all transactions from the mempool.
 func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool, error) {
+	if err := authorizeUnsafe(ctx); err != nil {
+		return nil, err
+	}
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeSetLogLevel changes the log level of the node until it restarts. The
+// level has the format of log_level in the config, e.g.
+// "consensus:debug,p2p:error,*:info".
+func UnsafeSetLogLevel(ctx *rpctypes.Context, level string) (*ctypes.ResultUnsafeSetLogLevel, error) {
+	if err := authorizeUnsafe(ctx); err != nil {
+		return nil, err
+	}
+	ls, ok := env.Logger.(log.LevelSetter)
+	if !ok {
+		return nil, errors.New("the logger of the node doesn't support setting the log level")
+	}
+	options, err := tmflags.ParseLogLevelOptions(level, cfg.DefaultLogLevel)
+	if err != nil {
+		return nil, err
+	}
+	if err := ls.SetLevels(options...); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetLogLevel", "level", level)
+	return &ctypes.ResultUnsafeSetLogLevel{}, nil
+}
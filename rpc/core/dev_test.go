@@ -0,0 +1,36 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestUnsafeSetLogLevel(t *testing.T) {
+	prevEnv := env
+	t.Cleanup(func() { env = prevEnv })
+
+	var buf bytes.Buffer
+	logger := log.NewFilter(log.NewTMJSONLoggerNoTS(&buf), log.AllowError())
+	consensusLogger := logger.With("module", "consensus")
+	env = &Environment{Config: *cfg.DefaultRPCConfig(), Logger: logger.With("module", "rpc")}
+
+	_, err := UnsafeSetLogLevel(&rpctypes.Context{}, "consensus:debug,*:error")
+	require.NoError(t, err)
+	consensusLogger.Debug("foo")
+	assert.Equal(t, `{"_msg":"foo","level":"debug","module":"consensus"}`, strings.TrimSpace(buf.String()))
+
+	_, err = UnsafeSetLogLevel(&rpctypes.Context{}, "consensus:verbose")
+	assert.Error(t, err)
+
+	env.Logger = log.NewNopLogger()
+	_, err = UnsafeSetLogLevel(&rpctypes.Context{}, "info")
+	assert.Error(t, err)
+}
@@ -40,6 +40,7 @@ Endpoints that require arguments:
 /subscribe?event=_
 /tx?hash=_&prove=_
 /unsubscribe?event=_
+/unsafe_set_log_level?level=_
 ```
 */
 package core
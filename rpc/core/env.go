@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	bc "github.com/tendermint/tendermint/blockchain"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
@@ -12,6 +13,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
@@ -31,6 +33,9 @@ const (
 	// genesisChunkSize is the maximum size, in bytes, of each
 	// chunk in the genesis structure for the chunked API
 	genesisChunkSize = 16 * 1024 * 1024 // 16
+
+	// defaultBanTime is how long /unsafe_ban_peer bans a peer by default
+	defaultBanTime = 24 * time.Hour
 )
 
 var (
@@ -67,6 +72,16 @@ type peers interface {
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	StopPeerGracefully(p2p.Peer)
+}
+
+type fastSync interface {
+	SyncStatus() bc.SyncStatus
+}
+
+type addrBook interface {
+	MarkBad(*p2p.NetAddress, time.Duration)
+	BannedAddresses() []pex.BannedAddress
 }
 
 // ----------------------------------------------
@@ -84,6 +99,8 @@ type Environment struct {
 	ConsensusState Consensus
 	P2PPeers       peers
 	P2PTransport   transport
+	AddrBook       addrBook
+	FastSync       fastSync // nil if the fast sync version doesn't report its status
 
 	// objects
 	PubKey           crypto.PubKey
@@ -20,7 +20,7 @@ import (
 // CheckTx nor DeliverTx results.
 // More: https://docs.tendermint.com/v0.34/rpc/#/Tx/broadcast_tx_async
 func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	err := env.Mempool.CheckTx(tx, nil, mempl.TxInfo{})
+	err := env.Mempool.CheckTx(tx, nil, mempl.TxInfo{TraceID: ctx.TraceID()})
 
 	if err != nil {
 		return nil, err
@@ -39,7 +39,7 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 		case resCh <- res:
 		}
 
-	}, mempl.TxInfo{})
+	}, mempl.TxInfo{TraceID: ctx.TraceID()})
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +77,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	deliverTxSub, err := env.EventBus.Subscribe(subCtx, subscriber, q)
 	if err != nil {
 		err = fmt.Errorf("failed to subscribe to tx: %w", err)
-		env.Logger.Error("Error on broadcast_tx_commit", "err", err)
+		env.Logger.Error("Error on broadcast_tx_commit", "err", err, "trace_id", ctx.TraceID())
 		return nil, err
 	}
 	defer func() {
@@ -93,9 +93,9 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		case <-ctx.Context().Done():
 		case checkTxResCh <- res:
 		}
-	}, mempl.TxInfo{})
+	}, mempl.TxInfo{TraceID: ctx.TraceID()})
 	if err != nil {
-		env.Logger.Error("Error on broadcastTxCommit", "err", err)
+		env.Logger.Error("Error on broadcastTxCommit", "err", err, "trace_id", ctx.TraceID())
 		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
 	}
 	select {
@@ -129,15 +129,16 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 				reason = deliverTxSub.Err().Error()
 			}
 			err = fmt.Errorf("deliverTxSub was cancelled (reason: %s)", reason)
-			env.Logger.Error("Error on broadcastTxCommit", "err", err)
+			env.Logger.Error("Error on broadcastTxCommit", "err", err, "trace_id", ctx.TraceID())
 			return &ctypes.ResultBroadcastTxCommit{
 				CheckTx:   *checkTxRes,
 				DeliverTx: abci.ResponseDeliverTx{},
 				Hash:      tx.Hash(),
 			}, err
 		case <-time.After(env.Config.TimeoutBroadcastTxCommit):
-			err = errors.New("timed out waiting for tx to be included in a block")
-			env.Logger.Error("Error on broadcastTxCommit", "err", err)
+			err = fmt.Errorf("tx was accepted to the mempool but not committed within %v (timeout_broadcast_tx_commit)",
+				env.Config.TimeoutBroadcastTxCommit)
+			env.Logger.Error("Error on broadcastTxCommit", "err", err, "trace_id", ctx.TraceID())
 			return &ctypes.ResultBroadcastTxCommit{
 				CheckTx:   *checkTxRes,
 				DeliverTx: abci.ResponseDeliverTx{},
@@ -147,14 +148,41 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	}
 }
 
-// UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
-// including their number.
+// UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries,
+// skipping the first ?offset) including their number. Transactions are
+// returned in the requested order ("arrival" or "priority"), or in the order
+// they would be reaped for a block if order_by is empty.
 // More: https://docs.tendermint.com/v0.34/rpc/#/Info/unconfirmed_txs
-func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
+func UnconfirmedTxs(
+	ctx *rpctypes.Context,
+	limitPtr *int,
+	offsetPtr *int,
+	orderBy string,
+) (*ctypes.ResultUnconfirmedTxs, error) {
 	// reuse per_page validator
 	limit := validatePerPage(limitPtr)
 
-	txs := env.Mempool.ReapMaxTxs(limit)
+	offset := 0
+	if offsetPtr != nil {
+		if *offsetPtr < 0 {
+			return nil, fmt.Errorf("offset must be non-negative, got %d", *offsetPtr)
+		}
+		offset = *offsetPtr
+	}
+
+	var order mempl.TxOrder
+	switch orderBy {
+	case "":
+		order = mempl.TxOrderDefault
+	case "arrival":
+		order = mempl.TxOrderArrival
+	case "priority":
+		order = mempl.TxOrderPriority
+	default:
+		return nil, errors.New("expected order_by to be either `arrival` or `priority` or empty")
+	}
+
+	txs := env.Mempool.ListTxs(offset, limit, order)
 	return &ctypes.ResultUnconfirmedTxs{
 		Count:      len(txs),
 		Total:      env.Mempool.Size(),
@@ -175,7 +203,7 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 // be added to the mempool either.
 // More: https://docs.tendermint.com/v0.34/rpc/#/Tx/check_tx
 func CheckTx(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
-	res, err := env.ProxyAppMempool.CheckTxSync(abci.RequestCheckTx{Tx: tx})
+	res, err := env.ProxyAppMempool.CheckTxSync(abci.RequestCheckTx{Tx: tx, TraceId: ctx.TraceID(), Local: true})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/mempool/mock"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// acceptingMempool accepts every transaction without ever committing it.
+type acceptingMempool struct {
+	mock.Mempool
+}
+
+func (acceptingMempool) CheckTx(_ types.Tx, cb func(*abci.Response), _ mempl.TxInfo) error {
+	cb(abci.ToResponseCheckTx(abci.ResponseCheckTx{Code: abci.CodeTypeOK}))
+	return nil
+}
+
+func TestBroadcastTxCommitTimeout(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	env = &Environment{}
+	env.Config = *cfg.TestRPCConfig()
+	env.Config.TimeoutBroadcastTxCommit = 10 * time.Millisecond
+	env.EventBus = eventBus
+	env.Mempool = acceptingMempool{}
+	env.Logger = log.TestingLogger()
+
+	tx := types.Tx("hello")
+	res, err := BroadcastTxCommit(&rpctypes.Context{}, tx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "accepted to the mempool but not committed")
+	assert.EqualValues(t, abci.CodeTypeOK, res.CheckTx.Code)
+	assert.EqualValues(t, tx.Hash(), res.Hash)
+	assert.Zero(t, res.Height)
+}
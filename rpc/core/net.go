@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/p2p"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -40,6 +41,9 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 
 // UnsafeDialSeeds dials the given seeds (comma-separated id@IP:PORT).
 func UnsafeDialSeeds(ctx *rpctypes.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
+	if err := authorizeUnsafe(ctx); err != nil {
+		return &ctypes.ResultDialSeeds{}, err
+	}
 	if len(seeds) == 0 {
 		return &ctypes.ResultDialSeeds{}, errors.New("no seeds provided")
 	}
@@ -54,6 +58,9 @@ func UnsafeDialSeeds(ctx *rpctypes.Context, seeds []string) (*ctypes.ResultDialS
 // optionally making them persistent.
 func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, unconditional, private bool) (
 	*ctypes.ResultDialPeers, error) {
+	if err := authorizeUnsafe(ctx); err != nil {
+		return &ctypes.ResultDialPeers{}, err
+	}
 	if len(peers) == 0 {
 		return &ctypes.ResultDialPeers{}, errors.New("no peers provided")
 	}
@@ -91,6 +98,66 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, uncondit
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeBanPeer bans the given peer, which is either the ID of a connected
+// peer or an address (id@IP:PORT), for the given number of seconds (24 hours
+// if not positive). The peer is disconnected if connected.
+func UnsafeBanPeer(ctx *rpctypes.Context, peer string, duration int64) (*ctypes.ResultBanPeer, error) {
+	if err := authorizeUnsafe(ctx); err != nil {
+		return nil, err
+	}
+	if env.AddrBook == nil {
+		return nil, errors.New("address book is not available")
+	}
+
+	var addr *p2p.NetAddress
+	connected := env.P2PPeers.Peers().Get(p2p.ID(peer))
+	switch {
+	case connected != nil && connected.IsOutbound():
+		addr = connected.SocketAddr()
+	case connected != nil:
+		var err error
+		if addr, err = connected.NodeInfo().NetAddress(); err != nil {
+			addr = connected.SocketAddr()
+		}
+	case strings.Contains(peer, "@"):
+		var err error
+		if addr, err = p2p.NewNetAddressString(peer); err != nil {
+			return nil, err
+		}
+		connected = env.P2PPeers.Peers().Get(addr.ID)
+	default:
+		return nil, fmt.Errorf("peer %s is not connected, provide its address (id@IP:PORT) instead", peer)
+	}
+
+	banTime := defaultBanTime
+	if duration > 0 {
+		banTime = time.Duration(duration) * time.Second
+	}
+	env.Logger.Info("BanPeer", "addr", addr, "duration", banTime)
+	env.AddrBook.MarkBad(addr, banTime)
+	if connected != nil {
+		env.P2PPeers.StopPeerGracefully(connected)
+	}
+
+	return &ctypes.ResultBanPeer{BannedUntil: time.Now().Add(banTime)}, nil
+}
+
+// BannedPeers returns the banned peers.
+func BannedPeers(ctx *rpctypes.Context) (*ctypes.ResultBannedPeers, error) {
+	if env.AddrBook == nil {
+		return nil, errors.New("address book is not available")
+	}
+	banned := env.AddrBook.BannedAddresses()
+	peers := make([]ctypes.BannedPeer, 0, len(banned))
+	for _, b := range banned {
+		peers = append(peers, ctypes.BannedPeer{
+			Address:     b.Addr.String(),
+			BannedUntil: b.Until,
+		})
+	}
+	return &ctypes.ResultBannedPeers{Peers: peers}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/v0.34/rpc/#/Info/genesis
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
@@ -16,13 +16,16 @@ var Routes = map[string]*rpc.RPCFunc{
 	// info API
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
+	"sync_status":          rpc.NewRPCFunc(SyncStatus, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"banned_peers":         rpc.NewRPCFunc(BannedPeers, ""),
 	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
 	"genesis":              rpc.NewRPCFunc(Genesis, "", rpc.Cacheable()),
 	"genesis_chunked":      rpc.NewRPCFunc(GenesisChunked, "chunk", rpc.Cacheable()),
 	"block":                rpc.NewRPCFunc(Block, "height", rpc.Cacheable("height")),
 	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash", rpc.Cacheable()),
 	"block_results":        rpc.NewRPCFunc(BlockResults, "height", rpc.Cacheable("height")),
+	"prove_tx_result":      rpc.NewRPCFunc(ProveTxResult, "height,index", rpc.Cacheable("height")),
 	"commit":               rpc.NewRPCFunc(Commit, "height", rpc.Cacheable("height")),
 	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove", rpc.Cacheable()),
@@ -32,7 +35,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height", rpc.Cacheable("height")),
-	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
+	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit,offset,order_by"),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
 	// tx broadcast API
@@ -54,4 +57,6 @@ func AddUnsafeRoutes() {
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["unsafe_ban_peer"] = rpc.NewRPCFunc(UnsafeBanPeer, "peer,duration")
+	Routes["unsafe_set_log_level"] = rpc.NewRPCFunc(UnsafeSetLogLevel, "level")
 }
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"time"
 
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -64,17 +65,23 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
 		},
-		ValidatorInfo: ctypes.ValidatorInfo{
+	}
+	// full and seed nodes have no private validator
+	if env.PubKey != nil {
+		result.ValidatorInfo = ctypes.ValidatorInfo{
 			Address:     env.PubKey.Address(),
 			PubKey:      env.PubKey,
 			VotingPower: votingPower,
-		},
+		}
 	}
 
 	return result, nil
 }
 
 func validatorAtHeight(h int64) *types.Validator {
+	if env.PubKey == nil {
+		return nil
+	}
 	vals, err := env.StateStore.LoadValidators(h)
 	if err != nil {
 		return nil
@@ -83,3 +90,20 @@ func validatorAtHeight(h int64) *types.Validator {
 	_, val := vals.GetByAddress(privValAddress)
 	return val
 }
+
+// SyncStatus returns the progress of fast sync: the latest height in the block
+// store, the highest height reported by peers and the peers blocks are
+// downloaded from.
+// More: https://docs.tendermint.com/v0.34/rpc/#/Info/sync_status
+func SyncStatus(ctx *rpctypes.Context) (*ctypes.ResultSyncStatus, error) {
+	if env.FastSync == nil {
+		return nil, errors.New("sync status is not supported by this fast sync version")
+	}
+	status := env.FastSync.SyncStatus()
+	return &ctypes.ResultSyncStatus{
+		Syncing:      status.Syncing,
+		Height:       status.Height,
+		TargetHeight: status.TargetHeight,
+		Peers:        status.Peers,
+	}, nil
+}
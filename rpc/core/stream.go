@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// streamCounter is used to give each block stream a unique subscriber ID.
+var streamCounter uint64
+
+// StreamBlocks calls send for every committed block, in order, starting at
+// fromHeight (or the lowest available height if fromHeight is 0), together
+// with the ABCI responses of the block. Results are nil if the node does not
+// keep them. Once the stream has caught up with the chain, it waits for new
+// blocks to be committed.
+//
+// The next block is not loaded until send returns, so a slow consumer only
+// slows down its own stream. StreamBlocks returns when ctx is done, or send
+// or loading a block fails.
+//
+// It is not exposed over JSON-RPC; see the gRPC BlockAPI.
+func StreamBlocks(
+	ctx context.Context,
+	fromHeight int64,
+	send func(*types.Block, *tmstate.ABCIResponses) error,
+) error {
+	base := env.BlockStore.Base()
+	if fromHeight == 0 {
+		fromHeight = base
+	}
+	if fromHeight < base {
+		return fmt.Errorf("height %d is not available, lowest height is %d", fromHeight, base)
+	}
+
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	}
+
+	subscriber := fmt.Sprintf("stream-blocks-%d", atomic.AddUint64(&streamCounter, 1))
+	sub, err := subscribeNewBlocks(ctx, subscriber)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := env.EventBus.UnsubscribeAll(context.Background(), subscriber); err != nil &&
+			err != tmpubsub.ErrSubscriptionNotFound {
+			env.Logger.Error("Failed to unsubscribe block stream", "subscriber", subscriber, "err", err)
+		}
+	}()
+
+	height := fromHeight
+	for {
+		// blocks are saved before they are executed, so only stream up to the
+		// last height with results
+		state, err := env.StateStore.Load()
+		if err != nil {
+			return err
+		}
+
+		for ; height <= state.LastBlockHeight; height++ {
+			block := env.BlockStore.LoadBlock(height)
+			if block == nil {
+				return fmt.Errorf("block at height %d not found", height)
+			}
+
+			results, err := env.StateStore.LoadABCIResponses(height)
+			if err != nil {
+				// results may not be kept, or not exist for a block restored by state sync
+				if _, ok := err.(sm.ErrNoABCIResponsesForHeight); !ok && err != sm.ErrABCIResponsesNotPersisted {
+					return err
+				}
+				results = nil
+			}
+
+			if err := send(block, results); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-sub.Out():
+		case <-sub.Cancelled():
+			if sub.Err() != tmpubsub.ErrOutOfCapacity {
+				return sub.Err()
+			}
+			// more blocks were committed than we could keep track of while
+			// sending, so subscribe again and catch up from the stores. The
+			// server cancelled the subscription, but still counts it until
+			// it is unsubscribed.
+			if err := env.EventBus.Unsubscribe(ctx, subscriber, types.EventQueryNewBlockHeader); err != nil &&
+				err != tmpubsub.ErrSubscriptionNotFound {
+				return err
+			}
+			if sub, err = subscribeNewBlocks(ctx, subscriber); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func subscribeNewBlocks(ctx context.Context, subscriber string) (types.Subscription, error) {
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
+	defer cancel()
+	return env.EventBus.Subscribe(subCtx, subscriber, types.EventQueryNewBlockHeader,
+		env.Config.SubscriptionBufferSize)
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// streamBlockStore has blocks, with only a header, at every height.
+type streamBlockStore struct {
+	mockBlockStore
+}
+
+func (streamBlockStore) LoadBlock(height int64) *types.Block {
+	return &types.Block{Header: types.Header{Height: height}}
+}
+
+func TestStreamBlocksOutOfCapacity(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	genDoc := &types.GenesisDoc{
+		ChainID:     "stream_test",
+		GenesisTime: tmtime.Now(),
+		Validators:  []types.GenesisValidator{{PubKey: ed25519.GenPrivKey().PubKey(), Power: 10}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	// as after the first block
+	state.LastValidators = state.Validators.Copy()
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: true})
+
+	env = &Environment{}
+	env.Config = *cfg.TestRPCConfig()
+	env.Config.SubscriptionBufferSize = 1
+	env.EventBus = eventBus
+	env.BlockStore = streamBlockStore{}
+	env.StateStore = stateStore
+	env.Logger = log.TestingLogger()
+
+	commit := func(height int64) {
+		state.LastBlockHeight = height
+		require.NoError(t, stateStore.Save(state))
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+		}))
+	}
+	commit(1)
+
+	// another subscriber, to know when the events have been delivered
+	observer, err := eventBus.Subscribe(context.Background(), "observer", types.EventQueryNewBlockHeader, 10)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heights := make(chan int64)
+	release := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- StreamBlocks(ctx, 1, func(block *types.Block, _ *tmstate.ABCIResponses) error {
+			heights <- block.Height
+			<-release
+			return nil
+		})
+	}()
+	select {
+	case h := <-heights:
+		require.EqualValues(t, 1, h)
+	case err := <-errCh:
+		t.Fatalf("stream ended: %v", err)
+	}
+
+	// while the first block is being sent, more blocks are committed than
+	// the subscription can hold
+	for height := int64(2); height <= 4; height++ {
+		commit(height)
+	}
+	// once the observer got the last event, the one before has overflowed
+	// the subscription of the stream
+	for height := int64(2); height <= 4; height++ {
+		msg := <-observer.Out()
+		require.EqualValues(t, height, msg.Data().(types.EventDataNewBlockHeader).Header.Height)
+	}
+	close(release)
+
+	// the stream catches up from the stores...
+	for height := int64(2); height <= 4; height++ {
+		select {
+		case h := <-heights:
+			require.EqualValues(t, height, h)
+		case err := <-errCh:
+			t.Fatalf("stream ended: %v", err)
+		}
+	}
+	// ...and keeps waiting for new blocks
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case h := <-heights:
+			require.EqualValues(t, 5, h)
+			return
+		case err := <-errCh:
+			t.Fatalf("stream ended: %v", err)
+		case <-ticker.C:
+			// the stream may not have subscribed again yet
+			commit(5)
+		case <-timeout:
+			t.Fatal("block 5 was not streamed")
+		}
+	}
+}
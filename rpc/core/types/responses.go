@@ -6,6 +6,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/p2p"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -48,6 +49,7 @@ type ResultCommit struct {
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
+	ProposerAddress       types.Address             `json:"proposer_address,omitempty"`
 	TxsResults            []*abci.ResponseDeliverTx `json:"txs_results"`
 	BeginBlockEvents      []abci.Event              `json:"begin_block_events"`
 	EndBlockEvents        []abci.Event              `json:"end_block_events"`
@@ -55,6 +57,17 @@ type ResultBlockResults struct {
 	ConsensusParamUpdates *abci.ConsensusParams     `json:"consensus_param_updates"`
 }
 
+// Proof of a transaction result. Only the deterministic fields of the result
+// (code, data, gas wanted and gas used) are part of the proof. ResultsHash is
+// the LastResultsHash of the block at Height+1.
+type ResultProveTxResult struct {
+	Height      int64                  `json:"height"`
+	Index       uint32                 `json:"index"`
+	TxResult    abci.ResponseDeliverTx `json:"tx_result"`
+	Proof       merkle.Proof           `json:"proof"`
+	ResultsHash bytes.HexBytes         `json:"results_hash"`
+}
+
 // NewResultCommit is a helper to initialize the ResultCommit with
 // the embedded struct
 func NewResultCommit(header *types.Header, commit *types.Commit,
@@ -106,6 +119,14 @@ func (s *ResultStatus) TxIndexEnabled() bool {
 	return s.NodeInfo.Other.TxIndex == "on"
 }
 
+// Fast sync progress
+type ResultSyncStatus struct {
+	Syncing      bool     `json:"syncing"`
+	Height       int64    `json:"height"`
+	TargetHeight int64    `json:"target_height"`
+	Peers        []p2p.ID `json:"peers"`
+}
+
 // Info about peer connections
 type ResultNetInfo struct {
 	Listening bool     `json:"listening"`
@@ -132,6 +153,22 @@ type Peer struct {
 	RemoteIP         string               `json:"remote_ip"`
 }
 
+// Banned peers
+type ResultBannedPeers struct {
+	Peers []BannedPeer `json:"peers"`
+}
+
+// A banned peer
+type BannedPeer struct {
+	Address     string    `json:"address"`
+	BannedUntil time.Time `json:"banned_until"`
+}
+
+// Result of banning a peer
+type ResultBanPeer struct {
+	BannedUntil time.Time `json:"banned_until"`
+}
+
 // Validators for a height.
 type ResultValidators struct {
 	BlockHeight int64              `json:"block_height"`
@@ -140,6 +177,9 @@ type ResultValidators struct {
 	Count int `json:"count"`
 	// Total number of validators
 	Total int `json:"total"`
+	// Proposer of the first round at this height. It is not verified by
+	// light clients, which leave it empty.
+	Proposer *types.Validator `json:"proposer,omitempty"`
 }
 
 // ConsensusParams for given height
@@ -237,6 +277,7 @@ type ResultBroadcastEvidence struct {
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
+	ResultUnsafeSetLogLevel  struct{}
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	core "github.com/tendermint/tendermint/rpc/core"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 type broadcastAPI struct {
@@ -37,3 +39,19 @@ func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcast
 		},
 	}, nil
 }
+
+type blockAPI struct {
+}
+
+func (bapi *blockAPI) StreamBlocks(req *RequestStreamBlocks, stream BlockAPI_StreamBlocksServer) error {
+	// stream.Send blocks on gRPC flow control, so no further blocks are
+	// loaded for a slow client until it has received the previous ones.
+	return core.StreamBlocks(stream.Context(), req.FromHeight,
+		func(block *types.Block, results *tmstate.ABCIResponses) error {
+			pbb, err := block.ToProto()
+			if err != nil {
+				return err
+			}
+			return stream.Send(&ResponseStreamBlocks{Block: pbb, Results: results})
+		})
+}
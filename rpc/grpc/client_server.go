@@ -14,24 +14,35 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// StartGRPCServer starts a new gRPC server, serving the BroadcastAPI and
+// BlockAPI, using the given net.Listener.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func StartGRPCServer(ln net.Listener) error {
 	grpcServer := grpc.NewServer()
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{})
+	RegisterBlockAPIServer(grpcServer, &blockAPI{})
 	return grpcServer.Serve(ln)
 }
 
 // StartGRPCClient dials the gRPC server using protoAddr and returns a new
 // BroadcastAPIClient.
 func StartGRPCClient(protoAddr string) BroadcastAPIClient {
+	return NewBroadcastAPIClient(dial(protoAddr))
+}
+
+// StartGRPCBlockAPIClient dials the gRPC server using protoAddr and returns a
+// new BlockAPIClient.
+func StartGRPCBlockAPIClient(protoAddr string) BlockAPIClient {
+	return NewBlockAPIClient(dial(protoAddr))
+}
+
+func dial(protoAddr string) *grpc.ClientConn {
 	//nolint:staticcheck // SA1019 Existing use of deprecated but supported dial option.
 	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
 	if err != nil {
 		panic(err)
 	}
-	return NewBroadcastAPIClient(conn)
+	return conn
 }
 
 func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
@@ -33,3 +33,24 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestStreamBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := rpctest.GetGRPCBlockAPIClient().StreamBlocks(
+		ctx,
+		&core_grpc.RequestStreamBlocks{FromHeight: 1},
+	)
+	require.NoError(t, err)
+
+	// blocks are sent in order, including ones committed after the call
+	for height := int64(1); height <= 5; height++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, res.Block)
+		require.Equal(t, height, res.Block.Header.Height)
+		require.NotNil(t, res.Results)
+		require.Len(t, res.Results.DeliverTxs, len(res.Block.Data.Txs))
+	}
+}
@@ -106,6 +106,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			}
 
 			returns := rpcFunc.f.Call(args)
+			logger.Debug("HTTPJSONRPC", "method", request.Method, "trace_id", ctx.TraceID())
 			result, err := unreflectResult(returns)
 			if err != nil {
 				responses = append(responses, types.RPCInternalError(request.ID, err))
@@ -3,6 +3,7 @@ package server
 
 import (
 	"bufio"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +33,8 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// mirrors http.Server#TLSConfig, only used by ServeTLS
+	TLSConfig *tls.Config
 }
 
 // DefaultConfig returns a default configuration.
@@ -84,6 +87,7 @@ func ServeTLS(
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
 		MaxHeaderBytes:    config.MaxHeaderBytes,
+		TLSConfig:         config.TLSConfig,
 	}
 	err := s.ServeTLS(listener, certFile, keyFile)
 
@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 )
 
 // a wrapper to emulate a sum type: jsonrpcid = string | int
@@ -137,6 +139,17 @@ func ArrayToRequest(id jsonrpcid, method string, params []interface{}) (RPCReque
 //----------------------------------------
 // RESPONSE
 
+// Error codes defined by the JSON-RPC 2.0 spec. CodeServerError is the first
+// of the codes reserved for implementation-defined server errors.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeServerError    = -32000
+)
+
 type RPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -219,7 +232,7 @@ func (resp RPCResponse) String() string {
 //	If there was an error in detecting the id in the Request object (e.g. Parse
 //	error/Invalid Request), it MUST be Null.
 func RPCParseError(err error) RPCResponse {
-	return NewRPCErrorResponse(nil, -32700, "Parse error. Invalid JSON", err.Error())
+	return NewRPCErrorResponse(nil, CodeParseError, "Parse error. Invalid JSON", err.Error())
 }
 
 // From the JSON-RPC 2.0 spec:
@@ -227,23 +240,23 @@ func RPCParseError(err error) RPCResponse {
 //	If there was an error in detecting the id in the Request object (e.g. Parse
 //	error/Invalid Request), it MUST be Null.
 func RPCInvalidRequestError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32600, "Invalid Request", err.Error())
+	return NewRPCErrorResponse(id, CodeInvalidRequest, "Invalid Request", err.Error())
 }
 
 func RPCMethodNotFoundError(id jsonrpcid) RPCResponse {
-	return NewRPCErrorResponse(id, -32601, "Method not found", "")
+	return NewRPCErrorResponse(id, CodeMethodNotFound, "Method not found", "")
 }
 
 func RPCInvalidParamsError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32602, "Invalid params", err.Error())
+	return NewRPCErrorResponse(id, CodeInvalidParams, "Invalid params", err.Error())
 }
 
 func RPCInternalError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32603, "Internal error", err.Error())
+	return NewRPCErrorResponse(id, CodeInternalError, "Internal error", err.Error())
 }
 
 func RPCServerError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
+	return NewRPCErrorResponse(id, CodeServerError, "Server error", err.Error())
 }
 
 //----------------------------------------
@@ -273,6 +286,20 @@ type Context struct {
 	WSConn WSRPCConnection
 	// http request
 	HTTPReq *http.Request
+
+	// trace ID, see TraceID
+	traceID string
+}
+
+// TraceID returns an identifier unique to this request. It is passed down to
+// the mempool and the ABCI application, and included in log lines, so a single
+// call can be followed across subsystem boundaries. The ID is generated on
+// first use.
+func (ctx *Context) TraceID() string {
+	if ctx.traceID == "" {
+		ctx.traceID = hex.EncodeToString(tmrand.Bytes(8))
+	}
+	return ctx.traceID
 }
 
 // RemoteAddr returns the remote address (usually a string "IP:port").
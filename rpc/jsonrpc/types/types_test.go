@@ -81,3 +81,22 @@ func TestRPCError(t *testing.T) {
 			Message: "Badness",
 		}))
 }
+
+func TestRPCErrorCodes(t *testing.T) {
+	id := JSONRPCIntID(1)
+	err := errors.New("foo")
+	assert.Equal(t, CodeParseError, RPCParseError(err).Error.Code)
+	assert.Equal(t, CodeInvalidRequest, RPCInvalidRequestError(id, err).Error.Code)
+	assert.Equal(t, CodeMethodNotFound, RPCMethodNotFoundError(id).Error.Code)
+	assert.Equal(t, CodeInvalidParams, RPCInvalidParamsError(id, err).Error.Code)
+	assert.Equal(t, CodeInternalError, RPCInternalError(id, err).Error.Code)
+	assert.Equal(t, CodeServerError, RPCServerError(id, err).Error.Code)
+}
+
+func TestContextTraceID(t *testing.T) {
+	ctx := &Context{}
+	id := ctx.TraceID()
+	assert.Len(t, id, 16)
+	assert.Equal(t, id, ctx.TraceID(), "trace ID must be stable for a request")
+	assert.NotEqual(t, id, (&Context{}).TraceID(), "trace IDs must differ across requests")
+}
@@ -3,20 +3,17 @@
 
 	Usage:
 			json2wal <path-to-JSON>  <path-to-wal>
+
+	See also the json2wal subcommand of tendermint.
 */
 
 package main
 
 import (
-	"bufio"
 	"fmt"
-	"io"
 	"os"
-	"strings"
 
 	cs "github.com/tendermint/tendermint/consensus"
-	tmjson "github.com/tendermint/tendermint/libs/json"
-	"github.com/tendermint/tendermint/types"
 )
 
 func main() {
@@ -37,33 +34,7 @@ func main() {
 	}
 	defer walFile.Close()
 
-	// the length of tendermint/wal/MsgInfo in the wal.json may exceed the defaultBufSize(4096) of bufio
-	// because of the byte array in BlockPart
-	// leading to unmarshal error: unexpected end of JSON input
-	br := bufio.NewReaderSize(f, int(2*types.BlockPartSizeBytes))
-	dec := cs.NewWALEncoder(walFile)
-
-	for {
-		msgJSON, _, err := br.ReadLine()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			panic(fmt.Errorf("failed to read file: %v", err))
-		}
-		// ignore the ENDHEIGHT in json.File
-		if strings.HasPrefix(string(msgJSON), "ENDHEIGHT") {
-			continue
-		}
-
-		var msg cs.TimedWALMessage
-		err = tmjson.Unmarshal(msgJSON, &msg)
-		if err != nil {
-			panic(fmt.Errorf("failed to unmarshal json: %v", err))
-		}
-
-		err = dec.Encode(&msg)
-		if err != nil {
-			panic(fmt.Errorf("failed to encode msg: %v", err))
-		}
+	if err := cs.JSONToWAL(f, walFile); err != nil {
+		panic(err)
 	}
 }
@@ -3,17 +3,17 @@
 
 	Usage:
 			wal2json <path-to-wal>
+
+	See also the wal2json subcommand of tendermint.
 */
 
 package main
 
 import (
 	"fmt"
-	"io"
 	"os"
 
 	cs "github.com/tendermint/tendermint/consensus"
-	tmjson "github.com/tendermint/tendermint/libs/json"
 )
 
 func main() {
@@ -28,35 +28,8 @@ func main() {
 	}
 	defer f.Close()
 
-	dec := cs.NewWALDecoder(f)
-	for {
-		msg, err := dec.Decode()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			panic(fmt.Errorf("failed to decode msg: %v", err))
-		}
-
-		json, err := tmjson.Marshal(msg)
-		if err != nil {
-			panic(fmt.Errorf("failed to marshal msg: %v", err))
-		}
-
-		_, err = os.Stdout.Write(json)
-		if err == nil {
-			_, err = os.Stdout.Write([]byte("\n"))
-		}
-
-		if err == nil {
-			if endMsg, ok := msg.Msg.(cs.EndHeightMessage); ok {
-				_, err = os.Stdout.Write([]byte(fmt.Sprintf("ENDHEIGHT %d\n", endMsg.Height)))
-			}
-		}
-
-		if err != nil {
-			fmt.Println("Failed to write message", err)
-			os.Exit(1) //nolint:gocritic
-		}
-
+	if err := cs.WALToJSON(f, os.Stdout); err != nil {
+		fmt.Println(err)
+		os.Exit(1) //nolint:gocritic
 	}
 }
@@ -40,8 +40,20 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// maximum number of DeliverTx requests sent to the app without waiting for
+	// their responses; 0 means no limit
+	maxInFlightDeliverTxs int
+
+	// verified signatures, skipped when verifying the LastCommit of a block;
+	// may be nil
+	sigCache *types.SignatureCache
 }
 
+// DefaultMaxInFlightDeliverTxs is the default maximum number of DeliverTx
+// requests sent to the app without waiting for their responses.
+const DefaultMaxInFlightDeliverTxs = 256
+
 type BlockExecutorOption func(executor *BlockExecutor)
 
 func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
@@ -50,6 +62,25 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithMaxInFlightDeliverTxs sets the maximum number of DeliverTx
+// requests sent to the app without waiting for their responses. When it is
+// reached, the connection is flushed. 0 means no limit.
+func BlockExecutorWithMaxInFlightDeliverTxs(n int) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.maxInFlightDeliverTxs = n
+	}
+}
+
+// BlockExecutorWithSignatureCache sets a cache of verified signatures. The
+// signatures of the LastCommit of a block found in it are not verified again.
+// Consensus adds the precommits it receives to the cache of its BlockExecutor,
+// so that the LastCommit of the next block is mostly found there.
+func BlockExecutorWithSignatureCache(cache *types.SignatureCache) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.sigCache = cache
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -68,6 +99,8 @@ func NewBlockExecutor(
 		evpool:   evpool,
 		logger:   logger,
 		metrics:  NopMetrics(),
+
+		maxInFlightDeliverTxs: DefaultMaxInFlightDeliverTxs,
 	}
 
 	for _, option := range options {
@@ -81,6 +114,11 @@ func (blockExec *BlockExecutor) Store() Store {
 	return blockExec.store
 }
 
+// SignatureCache returns the cache of verified signatures, which may be nil.
+func (blockExec *BlockExecutor) SignatureCache() *types.SignatureCache {
+	return blockExec.sigCache
+}
+
 // SetEventBus - sets the event bus for publishing block related events.
 // If not called, it defaults to types.NopEventBus.
 func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher) {
@@ -115,7 +153,7 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 // Validation does not mutate state, but does require historical information from the stateDB,
 // ie. to verify evidence from a validator at an old height.
 func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) error {
-	err := validateBlock(state, block)
+	err := validateBlock(state, block, blockExec.sigCache)
 	if err != nil {
 		return err
 	}
@@ -132,13 +170,14 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	state State, blockID types.BlockID, block *types.Block,
 ) (State, int64, error) {
 
-	if err := validateBlock(state, block); err != nil {
+	if err := validateBlock(state, block, blockExec.sigCache); err != nil {
 		return state, 0, ErrInvalidBlock(err)
 	}
 
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
 		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		blockExec.maxInFlightDeliverTxs,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -254,7 +293,8 @@ func (blockExec *BlockExecutor) Commit(
 //---------------------------------------------------------
 // Helper functions for executing blocks and updating state
 
-// Executes block's transactions on proxyAppConn.
+// Executes block's transactions on proxyAppConn, flushing the connection
+// every maxInFlight transactions (if positive).
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
 	logger log.Logger,
@@ -262,6 +302,7 @@ func execBlockOnProxyApp(
 	block *types.Block,
 	store Store,
 	initialHeight int64,
+	maxInFlight int,
 ) (*tmstate.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
@@ -316,11 +357,19 @@ func execBlockOnProxyApp(
 	}
 
 	// run txs of block
-	for _, tx := range block.Txs {
+	for i, tx := range block.Txs {
 		proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx})
 		if err := proxyAppConn.Error(); err != nil {
 			return nil, err
 		}
+		// wait for the responses before sending more, so that huge blocks
+		// don't pile up requests in the client and the app
+		if maxInFlight > 0 && (i+1)%maxInFlight == 0 && i+1 < len(block.Txs) {
+			if err := proxyAppConn.FlushSync(); err != nil {
+				logger.Error("error in proxyAppConn.FlushSync", "err", err)
+				return nil, err
+			}
+		}
 	}
 
 	// End block.
@@ -526,6 +575,7 @@ func fireEvents(
 // Execute block without state. TODO: eliminate
 
 // ExecCommitBlock executes and commits a block on the proxyApp without validating or mutating the state.
+// At most DefaultMaxInFlightDeliverTxs DeliverTx requests are in flight.
 // It returns the application root hash (result of abci.Commit).
 func ExecCommitBlock(
 	appConnConsensus proxy.AppConnConsensus,
@@ -534,7 +584,8 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight,
+		DefaultMaxInFlightDeliverTxs)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
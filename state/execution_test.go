@@ -59,6 +59,52 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// flushCountingAppConn counts the flushes of the connection.
+type flushCountingAppConn struct {
+	proxy.AppConnConsensus
+	flushes int
+}
+
+func (app *flushCountingAppConn) FlushSync() error {
+	app.flushes++
+	return app.AppConnConsensus.FlushSync()
+}
+
+func TestApplyBlockMaxInFlightDeliverTxs(t *testing.T) {
+	testCases := []struct {
+		maxInFlight int
+		flushes     int
+	}{
+		{0, 0},
+		{3, 3},  // after txs 3, 6 and 9
+		{5, 1},  // EndBlock flushes the last ones
+		{10, 0}, // idem
+	}
+	for _, tc := range testCases {
+		app := &testApp{}
+		proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+		require.NoError(t, proxyApp.Start())
+		defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+		state, stateDB, _ := makeState(1, 1)
+		stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+		appConn := &flushCountingAppConn{AppConnConsensus: proxyApp.Consensus()}
+		blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), appConn,
+			mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithMaxInFlightDeliverTxs(tc.maxInFlight))
+
+		block := makeBlock(state, 1)
+		require.Len(t, block.Txs, nTxsPerBlock)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+		_, _, err := blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+		assert.Equal(t, tc.flushes, appConn.flushes, "max in flight %d", tc.maxInFlight)
+
+		abciResponses, err := stateStore.LoadABCIResponses(1)
+		require.NoError(t, err)
+		assert.Len(t, abciResponses.DeliverTxs, nTxsPerBlock)
+	}
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
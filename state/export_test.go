@@ -40,9 +40,21 @@ func ValidateValidatorUpdates(abciUpdates []abci.ValidatorUpdate, params tmproto
 	return validateValidatorUpdates(abciUpdates, params)
 }
 
-// SaveValidatorsInfo is an alias for the private saveValidatorsInfo method in
+// SaveValidatorsInfo is an alias for the private saveValidatorsInfo function in
 // store.go, exported exclusively and explicitly for testing.
 func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
-	stateStore := dbStore{db, StoreOptions{DiscardABCIResponses: false}}
-	return stateStore.saveValidatorsInfo(height, lastHeightChanged, valSet)
+	batch := db.NewBatch()
+	defer batch.Close()
+	if err := saveValidatorsInfo(batch, height, lastHeightChanged, valSet); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+// SetMigrations replaces the registry of migrations, exclusively and
+// explicitly for testing. It returns a function restoring it.
+func SetMigrations(ms []Migration) func() {
+	prev := migrations
+	migrations = ms
+	return func() { migrations = prev }
 }
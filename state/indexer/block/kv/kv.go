@@ -23,15 +23,23 @@ var _ indexer.BlockIndexer = (*BlockerIndexer)(nil)
 // events with an underlying KV store. Block events are indexed by their height,
 // such that matching search criteria returns the respective block height(s).
 type BlockerIndexer struct {
-	store dbm.DB
+	store      dbm.DB
+	syncWrites bool
 }
 
 func New(store dbm.DB) *BlockerIndexer {
 	return &BlockerIndexer{
-		store: store,
+		store:      store,
+		syncWrites: true,
 	}
 }
 
+// SetSyncWrites sets whether the writes of the indexer are flushed to disk
+// before they return (the default).
+func (idx *BlockerIndexer) SetSyncWrites(sync bool) {
+	idx.syncWrites = sync
+}
+
 // Has returns true if the given height has been indexed. An error is returned
 // upon database query failure.
 func (idx *BlockerIndexer) Has(height int64) (bool, error) {
@@ -74,6 +82,9 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 		return fmt.Errorf("failed to index EndBlock events: %w", err)
 	}
 
+	if !idx.syncWrites {
+		return batch.Write()
+	}
 	return batch.WriteSync()
 }
 
@@ -0,0 +1,105 @@
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+var schemaVersionKey = []byte("schemaVersionKey")
+
+// Migration changes the encoding of the data of the state DB from the schema
+// version Version-1 to Version.
+type Migration struct {
+	Version     uint64
+	Description string
+	// Migrate rewrites the data of the DB. It must be idempotent: it is run
+	// again if the node stops before the new schema version is saved.
+	Migrate func(db dbm.DB) error
+}
+
+// migrations is the registry of the migrations of the state DB, in the order
+// of their versions, starting from 1. Changes to the encoding of the data of
+// the state DB (ValidatorsInfo, ConsensusParamsInfo, ABCIResponses, ...) must
+// come with a migration appended here.
+var migrations = []Migration{}
+
+// SchemaVersion returns the schema version of the state DB. DBs created before
+// the schema was versioned are at version 0.
+func SchemaVersion(db dbm.DB) (uint64, error) {
+	bz, err := db.Get(schemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid schema version %X", bz)
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+// LatestSchemaVersion returns the schema version of the state DB this version
+// of Tendermint reads and writes.
+func LatestSchemaVersion() uint64 {
+	return uint64(len(migrations))
+}
+
+// PendingMigrations returns the migrations to run on the state DB to bring it
+// to the latest schema version.
+func PendingMigrations(db dbm.DB) ([]Migration, error) {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	latest := LatestSchemaVersion()
+	if version > latest {
+		return nil, fmt.Errorf("state DB has schema version %d, but this version of Tendermint only supports up to %d",
+			version, latest)
+	}
+	return migrations[version:], nil
+}
+
+// Migrate runs the pending migrations on the state DB, saving the schema
+// version after each of them. It is a no-op if the DB is at the latest schema
+// version already.
+func Migrate(db dbm.DB, logger log.Logger) error {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		logger.Info("Migrating state DB", "version", m.Version, "description", m.Description)
+		if err := m.Migrate(db); err != nil {
+			return fmt.Errorf("failed to migrate state DB to schema version %d: %w", m.Version, err)
+		}
+		if err := saveSchemaVersion(db, m.Version); err != nil {
+			return err
+		}
+	}
+
+	if len(pending) > 0 {
+		return nil
+	}
+	// DBs created before the schema was versioned, and new ones, get the
+	// version saved too
+	has, err := db.Has(schemaVersionKey)
+	if err != nil || has {
+		return err
+	}
+	return saveSchemaVersion(db, LatestSchemaVersion())
+}
+
+func saveSchemaVersion(db dbm.DB, version uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+	if err := db.SetSync(schemaVersionKey, bz); err != nil {
+		return fmt.Errorf("failed to save state DB schema version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+func TestMigrationsAreOrdered(t *testing.T) {
+	pending, err := sm.PendingMigrations(dbm.NewMemDB())
+	require.NoError(t, err)
+	for i, m := range pending {
+		assert.EqualValues(t, i+1, m.Version, m.Description)
+		assert.NotNil(t, m.Migrate, m.Description)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	var ran []uint64
+	migration := func(version uint64) sm.Migration {
+		return sm.Migration{
+			Version:     version,
+			Description: "test",
+			Migrate: func(db dbm.DB) error {
+				ran = append(ran, version)
+				return db.Set([]byte("key"), []byte{byte(version)})
+			},
+		}
+	}
+	defer sm.SetMigrations([]sm.Migration{migration(1)})()
+
+	// a DB created before the schema was versioned is migrated
+	db := dbm.NewMemDB()
+	version, err := sm.SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, version)
+	require.NoError(t, sm.Migrate(db, log.TestingLogger()))
+	version, err = sm.SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, version)
+	assert.Equal(t, []uint64{1}, ran)
+
+	// migrating again is a no-op
+	require.NoError(t, sm.Migrate(db, log.TestingLogger()))
+	assert.Equal(t, []uint64{1}, ran)
+
+	// only the new migrations run
+	sm.SetMigrations([]sm.Migration{migration(1), migration(2), migration(3)})
+	pending, err := sm.PendingMigrations(db)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+	require.NoError(t, sm.Migrate(db, log.TestingLogger()))
+	assert.Equal(t, []uint64{1, 2, 3}, ran)
+	bz, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, bz)
+
+	// DBs of newer versions are rejected
+	sm.SetMigrations([]sm.Migration{migration(1)})
+	assert.Error(t, sm.Migrate(db, log.TestingLogger()))
+}
+
+func TestMigrateFailure(t *testing.T) {
+	defer sm.SetMigrations([]sm.Migration{
+		{Version: 1, Migrate: func(db dbm.DB) error { return nil }},
+		{Version: 2, Migrate: func(db dbm.DB) error { return errors.New("failure") }},
+	})()
+
+	db := dbm.NewMemDB()
+	require.Error(t, sm.Migrate(db, log.TestingLogger()))
+	// the DB stays at the version of the last successful migration
+	version, err := sm.SchemaVersion(db)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, version)
+}
+
+func TestMigrateNewDB(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, sm.Migrate(db, log.TestingLogger()))
+	version, err := sm.SchemaVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, sm.LatestSchemaVersion(), version)
+	has, err := db.Has([]byte("schemaVersionKey"))
+	require.NoError(t, err)
+	assert.True(t, has)
+}
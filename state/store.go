@@ -170,66 +170,75 @@ func (store dbStore) loadState(key []byte) (state State, err error) {
 }
 
 // Save persists the State, the ValidatorsInfo, and the ConsensusParamsInfo to the database.
-// This flushes the writes (e.g. calls SetSync).
+// They are written atomically, in a single batch, which is flushed (e.g. WriteSync).
 func (store dbStore) Save(state State) error {
 	return store.save(state, stateKey)
 }
 
 func (store dbStore) save(state State, key []byte) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
 	nextHeight := state.LastBlockHeight + 1
 	// If first block, save validators for the block.
 	if nextHeight == 1 {
 		nextHeight = state.InitialHeight
 		// This extra logic due to Tendermint validator set changes being delayed 1 block.
 		// It may get overwritten due to InitChain validator updates.
-		if err := store.saveValidatorsInfo(nextHeight, nextHeight, state.Validators); err != nil {
+		if err := saveValidatorsInfo(batch, nextHeight, nextHeight, state.Validators); err != nil {
 			return err
 		}
 	}
 	// Save next validators.
-	if err := store.saveValidatorsInfo(nextHeight+1, state.LastHeightValidatorsChanged, state.NextValidators); err != nil {
+	if err := saveValidatorsInfo(batch, nextHeight+1, state.LastHeightValidatorsChanged, state.NextValidators); err != nil {
 		return err
 	}
 
 	// Save next consensus params.
-	if err := store.saveConsensusParamsInfo(nextHeight,
+	if err := saveConsensusParamsInfo(batch, nextHeight,
 		state.LastHeightConsensusParamsChanged, state.ConsensusParams); err != nil {
 		return err
 	}
-	err := store.db.SetSync(key, state.Bytes())
-	if err != nil {
+	if err := batch.Set(key, state.Bytes()); err != nil {
 		return err
 	}
-	return nil
+	return batch.WriteSync()
 }
 
 // BootstrapState saves a new state, used e.g. by state sync when starting from non-zero height.
+// Like Save, it writes atomically.
 func (store dbStore) Bootstrap(state State) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
 	height := state.LastBlockHeight + 1
 	if height == 1 {
 		height = state.InitialHeight
 	}
 
 	if height > 1 && !state.LastValidators.IsNilOrEmpty() {
-		if err := store.saveValidatorsInfo(height-1, height-1, state.LastValidators); err != nil {
+		if err := saveValidatorsInfo(batch, height-1, height-1, state.LastValidators); err != nil {
 			return err
 		}
 	}
 
-	if err := store.saveValidatorsInfo(height, height, state.Validators); err != nil {
+	if err := saveValidatorsInfo(batch, height, height, state.Validators); err != nil {
 		return err
 	}
 
-	if err := store.saveValidatorsInfo(height+1, height+1, state.NextValidators); err != nil {
+	if err := saveValidatorsInfo(batch, height+1, height+1, state.NextValidators); err != nil {
 		return err
 	}
 
-	if err := store.saveConsensusParamsInfo(height,
+	if err := saveConsensusParamsInfo(batch, height,
 		state.LastHeightConsensusParamsChanged, state.ConsensusParams); err != nil {
 		return err
 	}
 
-	return store.db.SetSync(stateKey, state.Bytes())
+	if err := batch.Set(stateKey, state.Bytes()); err != nil {
+		return err
+	}
+	return batch.WriteSync()
 }
 
 // PruneStates deletes states between the given heights (including from, excluding to). It is not
@@ -450,6 +459,9 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCI
 	}
 	abciResponses.DeliverTxs = dtxs
 
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
 	// If the flag is false then we save the ABCIResponse. This can be used for the /BlockResults
 	// query or to reindex an event using the command line.
 	if !store.DiscardABCIResponses {
@@ -457,7 +469,7 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCI
 		if err != nil {
 			return err
 		}
-		if err := store.db.Set(calcABCIResponsesKey(height), bz); err != nil {
+		if err := batch.Set(calcABCIResponsesKey(height), bz); err != nil {
 			return err
 		}
 	}
@@ -472,8 +484,11 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCI
 	if err != nil {
 		return err
 	}
+	if err := batch.Set(lastABCIResponseKey, bz); err != nil {
+		return err
+	}
 
-	return store.db.SetSync(lastABCIResponseKey, bz)
+	return batch.WriteSync()
 }
 
 //-----------------------------------------------------------------------------
@@ -548,12 +563,12 @@ func loadValidatorsInfo(db dbm.DB, height int64) (*tmstate.ValidatorsInfo, error
 	return v, nil
 }
 
-// saveValidatorsInfo persists the validator set.
+// saveValidatorsInfo adds the validator set to the batch.
 //
 // `height` is the effective height for which the validator is responsible for
 // signing. It should be called from s.Save(), right before the state itself is
-// persisted.
-func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
+// added to the batch.
+func saveValidatorsInfo(batch dbm.Batch, height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
 	if lastHeightChanged > height {
 		return errors.New("lastHeightChanged cannot be greater than ValidatorsInfo height")
 	}
@@ -575,12 +590,7 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 		return err
 	}
 
-	err = store.db.Set(calcValidatorsKey(height), bz)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return batch.Set(calcValidatorsKey(height), bz)
 }
 
 //-----------------------------------------------------------------------------
@@ -633,11 +643,11 @@ func (store dbStore) loadConsensusParamsInfo(height int64) (*tmstate.ConsensusPa
 	return paramsInfo, nil
 }
 
-// saveConsensusParamsInfo persists the consensus params for the next block to disk.
-// It should be called from s.Save(), right before the state itself is persisted.
+// saveConsensusParamsInfo adds the consensus params for the next block to the batch.
+// It should be called from s.Save(), right before the state itself is added to the batch.
 // If the consensus params did not change after processing the latest block,
 // only the last height for which they changed is persisted.
-func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, params tmproto.ConsensusParams) error {
+func saveConsensusParamsInfo(batch dbm.Batch, nextHeight, changeHeight int64, params tmproto.ConsensusParams) error {
 	paramsInfo := &tmstate.ConsensusParamsInfo{
 		LastHeightChanged: changeHeight,
 	}
@@ -650,12 +660,7 @@ func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, par
 		return err
 	}
 
-	err = store.db.Set(calcConsensusParamsKey(nextHeight), bz)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return batch.Set(calcConsensusParamsKey(nextHeight), bz)
 }
 
 func (store dbStore) Close() error {
@@ -1,6 +1,7 @@
 package state_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -87,6 +88,39 @@ func BenchmarkLoadValidators(b *testing.B) {
 	}
 }
 
+// failingBatchDB is a DB whose batches fail to be written.
+type failingBatchDB struct {
+	dbm.DB
+}
+
+func (db failingBatchDB) NewBatch() dbm.Batch { return failingBatch{db.DB.NewBatch()} }
+
+type failingBatch struct {
+	dbm.Batch
+}
+
+func (failingBatch) Write() error     { return errors.New("write failure") }
+func (failingBatch) WriteSync() error { return errors.New("write failure") }
+
+func TestStoreSaveIsAtomic(t *testing.T) {
+	config := cfg.ResetTestRoot("state_")
+	defer os.RemoveAll(config.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	stateStore := sm.NewStore(failingBatchDB{db}, sm.StoreOptions{DiscardABCIResponses: false})
+	require.Error(t, stateStore.Save(state))
+	require.Error(t, stateStore.Bootstrap(state))
+	require.Error(t, stateStore.SaveABCIResponses(1, &tmstate.ABCIResponses{}))
+
+	// nothing was written
+	iter, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer iter.Close()
+	assert.False(t, iter.Valid())
+}
+
 func TestPruneStates(t *testing.T) {
 	testcases := map[string]struct {
 		makeHeights  int64
@@ -1,6 +1,7 @@
 package txindex_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
@@ -79,3 +81,58 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
 }
+
+func TestIndexerServiceIndexesTxEvents(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	service.SetLogger(log.TestingLogger())
+	require.NoError(t, service.Start())
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// events built as the indexing contract says are found by queries
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		NumTxs: int64(2),
+	}))
+	for i, amount := range []int64{3, 10} {
+		event := abci.NewEvent("transfer",
+			abci.NewAttribute("sender", "alice"),
+			abci.NewIntAttribute("amount", amount),
+			abci.NewUnindexedAttribute("memo", "not indexed"),
+		)
+		require.NoError(t, abci.ValidateEvent(event))
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: 1,
+			Index:  uint32(i),
+			Tx:     types.Tx{byte(i)},
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{event}},
+		}}))
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	results, err := txIndexer.Search(context.Background(),
+		query.MustParse("transfer.sender = 'alice' AND transfer.amount > 5"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, types.Tx{1}, types.Tx(results[0].Tx))
+
+	results, err = txIndexer.Search(context.Background(), query.MustParse("transfer.memo = 'not indexed'"))
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
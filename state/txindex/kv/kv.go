@@ -26,16 +26,26 @@ var _ txindex.TxIndexer = (*TxIndex)(nil)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
 type TxIndex struct {
-	store dbm.DB
+	store      dbm.DB
+	syncWrites bool
 }
 
 // NewTxIndex creates new KV indexer.
 func NewTxIndex(store dbm.DB) *TxIndex {
 	return &TxIndex{
-		store: store,
+		store:      store,
+		syncWrites: true,
 	}
 }
 
+// SetSyncWrites sets whether the writes of the indexer are flushed to disk
+// before they return (the default). The index can be rebuilt with the
+// reindex-event command, so they need not be when losing the latest entries
+// on a crash is acceptable.
+func (txi *TxIndex) SetSyncWrites(sync bool) {
+	txi.syncWrites = sync
+}
+
 // Get gets transaction from the TxIndex storage and returns it or nil if the
 // transaction is not found.
 func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
@@ -94,7 +104,7 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 		}
 	}
 
-	return storeBatch.WriteSync()
+	return txi.write(storeBatch)
 }
 
 // Index indexes a single transaction using the given list of events. Each key
@@ -147,7 +157,14 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 		return err
 	}
 
-	return b.WriteSync()
+	return txi.write(b)
+}
+
+func (txi *TxIndex) write(batch dbm.Batch) error {
+	if txi.syncWrites {
+		return batch.WriteSync()
+	}
+	return batch.Write()
 }
 
 func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Batch) error {
@@ -65,6 +65,31 @@ func TestTxIndex(t *testing.T) {
 	assert.True(t, proto.Equal(txResult2, loadedTxResult2))
 }
 
+func TestTxIndexUnsyncedWrites(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tx_index_db")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := db.NewGoLevelDB("tx_index", dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	indexer := NewTxIndex(store)
+	indexer.SetSyncWrites(false)
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("number"), Value: []byte("1"), Index: true}}},
+	})
+	require.NoError(t, indexer.Index(txResult))
+
+	loadedTxResult, err := indexer.Get(types.Tx(txResult.Tx).Hash())
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(txResult, loadedTxResult))
+
+	results, err := indexer.Search(context.Background(), query.MustParse("account.number = 1"))
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
 func TestTxSearch(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
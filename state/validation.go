@@ -12,7 +12,7 @@ import (
 //-----------------------------------------------------
 // Validate block
 
-func validateBlock(state State, block *types.Block) error {
+func validateBlock(state State, block *types.Block, sigCache *types.SignatureCache) error {
 	// Validate internal consistency.
 	if err := block.ValidateBasic(); err != nil {
 		return err
@@ -90,8 +90,8 @@ func validateBlock(state State, block *types.Block) error {
 		}
 	} else {
 		// LastCommit.Signatures length is checked in VerifyCommit.
-		if err := state.LastValidators.VerifyCommit(
-			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+		if err := state.LastValidators.VerifyCommitWithCache(
+			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit, sigCache); err != nil {
 			return err
 		}
 	}
@@ -149,3 +149,14 @@ func validateBlock(state State, block *types.Block) error {
 
 	return nil
 }
+
+// ValidateBlockSize returns an error if the block made of the given parts
+// exceeds the Block.MaxBytes consensus param. The size of the parts is used, so
+// that the block isn't encoded again. Proposals are checked as their parts
+// are received, but the blocks of fast sync must be checked with this.
+func ValidateBlockSize(state State, parts *types.PartSet) error {
+	if max, got := state.ConsensusParams.Block.MaxBytes, parts.ByteSize(); got > max {
+		return fmt.Errorf("block size %d exceeds the maximum %d", got, max)
+	}
+	return nil
+}
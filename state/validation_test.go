@@ -211,6 +211,22 @@ func TestValidateBlockCommit(t *testing.T) {
 	}
 }
 
+func TestValidateBlockSize(t *testing.T) {
+	state, _, _ := makeState(1, 1)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	proposerAddr := state.Validators.GetProposer().Address
+
+	block, parts := state.MakeBlock(1, makeTxs(1), lastCommit, nil, proposerAddr)
+	assert.EqualValues(t, block.Size(), parts.ByteSize())
+	state.ConsensusParams.Block.MaxBytes = parts.ByteSize()
+	require.NoError(t, sm.ValidateBlockSize(state, parts))
+
+	_, parts = state.MakeBlock(1, append(makeTxs(1), types.Tx("one more")), lastCommit, nil, proposerAddr)
+	err := sm.ValidateBlockSize(state, parts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the maximum")
+}
+
 func TestValidateBlockEvidence(t *testing.T) {
 	proxyApp := newTestApp()
 	require.NoError(t, proxyApp.Start())
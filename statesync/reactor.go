@@ -112,7 +112,7 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 	err := validateMsg(e.Message)
 	if err != nil {
 		r.Logger.Error("Invalid message", "peer", e.Src, "msg", e.Message, "err", err)
-		r.Switch.StopPeerForError(e.Src, err)
+		r.Switch.StopPeerForMisbehavior(e.Src, err)
 		return
 	}
 
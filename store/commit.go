@@ -0,0 +1,114 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/libs/bits"
+	tmbits "github.com/tendermint/tendermint/proto/tendermint/libs/bits"
+	tmstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// encodeCommit encodes a commit in the compact format of the block store: the
+// height, round and block ID once, bitmaps of the validators who signed, and
+// the address, timestamp and signature of each of them. Absent validators take
+// no more than their bit.
+func encodeCommit(commit *types.Commit) []byte {
+	var (
+		n         = len(commit.Signatures)
+		signed    = bits.NewBitArray(n)
+		signedNil = bits.NewBitArray(n)
+		cc        = &tmstore.CompactCommit{
+			Height:  commit.Height,
+			Round:   commit.Round,
+			BlockID: commit.BlockID.ToProto(),
+		}
+	)
+	for i, sig := range commit.Signatures {
+		if sig.Absent() {
+			continue
+		}
+		signed.SetIndex(i, true)
+		if sig.BlockIDFlag == types.BlockIDFlagNil {
+			signedNil.SetIndex(i, true)
+		}
+		cc.ValidatorAddresses = append(cc.ValidatorAddresses, sig.ValidatorAddress)
+		cc.Timestamps = append(cc.Timestamps, sig.Timestamp)
+		cc.Signatures = append(cc.Signatures, sig.Signature)
+	}
+	if n > 0 {
+		cc.Signed = *signed.ToProto()
+		cc.SignedNil = *signedNil.ToProto()
+	}
+	return mustEncode(cc)
+}
+
+// decodeCommit decodes a commit encoded by encodeCommit.
+func decodeCommit(bz []byte) (*types.Commit, error) {
+	var cc tmstore.CompactCommit
+	if err := proto.Unmarshal(bz, &cc); err != nil {
+		return nil, err
+	}
+
+	for _, ba := range []tmbits.BitArray{cc.Signed, cc.SignedNil} {
+		if ba.Bits < 0 || int64(len(ba.Elems)) != (ba.Bits+63)/64 {
+			return nil, fmt.Errorf("invalid bitmap of %d bits in %d elements", ba.Bits, len(ba.Elems))
+		}
+	}
+	if cc.Signed.Bits != cc.SignedNil.Bits {
+		return nil, fmt.Errorf("mismatched bitmaps of %d and %d validators", cc.Signed.Bits, cc.SignedNil.Bits)
+	}
+	var signed, signedNil bits.BitArray
+	signed.FromProto(&cc.Signed)
+	signedNil.FromProto(&cc.SignedNil)
+	if len(cc.ValidatorAddresses) != len(cc.Signatures) || len(cc.Timestamps) != len(cc.Signatures) {
+		return nil, fmt.Errorf("mismatched %d addresses, %d timestamps and %d signatures",
+			len(cc.ValidatorAddresses), len(cc.Timestamps), len(cc.Signatures))
+	}
+
+	pbc := &tmproto.Commit{
+		Height:     cc.Height,
+		Round:      cc.Round,
+		BlockID:    cc.BlockID,
+		Signatures: make([]tmproto.CommitSig, signed.Size()),
+	}
+	next := 0
+	for i := range pbc.Signatures {
+		if !signed.GetIndex(i) {
+			pbc.Signatures[i] = tmproto.CommitSig{BlockIdFlag: tmproto.BlockIDFlagAbsent}
+			continue
+		}
+		if next == len(cc.Signatures) {
+			return nil, fmt.Errorf("missing signature of validator %d", i)
+		}
+		flag := tmproto.BlockIDFlagCommit
+		if signedNil.GetIndex(i) {
+			flag = tmproto.BlockIDFlagNil
+		}
+		pbc.Signatures[i] = tmproto.CommitSig{
+			BlockIdFlag:      flag,
+			ValidatorAddress: cc.ValidatorAddresses[next],
+			Timestamp:        cc.Timestamps[next],
+			Signature:        cc.Signatures[next],
+		}
+		next++
+	}
+	if next != len(cc.Signatures) {
+		return nil, fmt.Errorf("%d signatures for %d validators who signed", len(cc.Signatures), next)
+	}
+
+	return types.CommitFromProto(pbc)
+}
+
+// decodeLegacyCommit decodes a commit stored as a tmproto.Commit, as the block
+// store did before the compact format.
+func decodeLegacyCommit(bz []byte) (*types.Commit, error) {
+	var pbc tmproto.Commit
+	if err := proto.Unmarshal(bz, &pbc); err != nil {
+		return nil, err
+	}
+	return types.CommitFromProto(&pbc)
+}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+var schemaVersionKey = []byte("schemaVersionKey")
+
+// Migration changes the encoding of the data of the block store DB from the
+// schema version Version-1 to Version.
+type Migration struct {
+	Version     uint64
+	Description string
+	// Migrate rewrites the data of the DB. It must be idempotent: it is run
+	// again if the node stops before the new schema version is saved.
+	Migrate func(db dbm.DB) error
+}
+
+// migrations is the registry of the migrations of the block store DB, in the
+// order of their versions, starting from 1.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "store commits in the compact format, and seen commits only when they differ from block commits",
+		Migrate:     migrateCompactCommits,
+	},
+}
+
+// SchemaVersion returns the schema version of the block store DB. DBs created
+// before the schema was versioned are at version 0.
+func SchemaVersion(db dbm.DB) (uint64, error) {
+	bz, err := db.Get(schemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid schema version %X", bz)
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+// LatestSchemaVersion returns the schema version of the block store DB this
+// version of Tendermint writes.
+func LatestSchemaVersion() uint64 {
+	return uint64(len(migrations))
+}
+
+// PendingMigrations returns the migrations to run on the block store DB to
+// bring it to the latest schema version.
+func PendingMigrations(db dbm.DB) ([]Migration, error) {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	latest := LatestSchemaVersion()
+	if version > latest {
+		return nil, fmt.Errorf("block store DB has schema version %d, but this version of Tendermint only supports up to %d",
+			version, latest)
+	}
+	return migrations[version:], nil
+}
+
+// Migrate runs the pending migrations on the block store DB, saving the schema
+// version after each of them. It is a no-op if the DB is at the latest schema
+// version already.
+//
+// NOTE: the block store still reads the data of DBs which are not migrated.
+func Migrate(db dbm.DB, logger log.Logger) error {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		logger.Info("Migrating block store DB", "version", m.Version, "description", m.Description)
+		if err := m.Migrate(db); err != nil {
+			return fmt.Errorf("failed to migrate block store DB to schema version %d: %w", m.Version, err)
+		}
+		if err := saveSchemaVersion(db, m.Version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveSchemaVersion(db dbm.DB, version uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+	if err := db.SetSync(schemaVersionKey, bz); err != nil {
+		return fmt.Errorf("failed to save block store DB schema version: %w", err)
+	}
+	return nil
+}
+
+// migrateCompactCommits moves the commits stored under the legacy keys to the
+// current ones, in the compact format. Block commits go first, so that the
+// seen commits which are the same can be dropped.
+func migrateCompactCommits(db dbm.DB) error {
+	err := migrateLegacyCommits(db, "C:", func(height int64, bz []byte) ([]byte, []byte, error) {
+		return calcBlockCommitKey(height), bz, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return migrateLegacyCommits(db, "SC:", func(height int64, bz []byte) ([]byte, []byte, error) {
+		blockCommitBytes, err := db.Get(calcBlockCommitKey(height))
+		if err != nil {
+			return nil, nil, err
+		}
+		if bytes.Equal(bz, blockCommitBytes) {
+			return nil, nil, nil
+		}
+		return calcSeenCommitKey(height), bz, nil
+	})
+}
+
+// migrateLegacyCommits re-encodes the legacy commits under prefix, and saves
+// them where convert says, or nowhere if it returns a nil key. The legacy
+// entries are deleted in the same batch, so that the migration can resume.
+func migrateLegacyCommits(
+	db dbm.DB,
+	prefix string,
+	convert func(height int64, bz []byte) (key []byte, value []byte, err error),
+) error {
+	// the iterator must be closed before writing to the DB, so commits are
+	// migrated by chunks
+	const chunkSize = 1000
+
+	type entry struct {
+		key, value []byte
+	}
+	start, end := []byte(prefix), []byte(prefix)
+	end[len(end)-1]++ // the key following all those with the prefix
+
+	for {
+		it, err := db.Iterator(start, end)
+		if err != nil {
+			return err
+		}
+		var chunk []entry
+		for ; it.Valid() && len(chunk) < chunkSize; it.Next() {
+			chunk = append(chunk, entry{key: it.Key(), value: it.Value()})
+		}
+		if err := it.Error(); err != nil {
+			it.Close()
+			return err
+		}
+		it.Close()
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		batch := db.NewBatch()
+		for _, e := range chunk {
+			height, err := strconv.ParseInt(string(e.key[len(prefix):]), 10, 64)
+			if err != nil {
+				batch.Close()
+				return fmt.Errorf("invalid commit key %q: %w", e.key, err)
+			}
+			commit, err := decodeLegacyCommit(e.value)
+			if err != nil {
+				batch.Close()
+				return fmt.Errorf("failed to decode commit at height %d: %w", height, err)
+			}
+			key, value, err := convert(height, encodeCommit(commit))
+			if err != nil {
+				batch.Close()
+				return err
+			}
+			if key != nil {
+				if err := batch.Set(key, value); err != nil {
+					batch.Close()
+					return err
+				}
+			}
+			if err := batch.Delete(e.key); err != nil {
+				batch.Close()
+				return err
+			}
+		}
+		err = batch.WriteSync()
+		batch.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
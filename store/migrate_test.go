@@ -0,0 +1,78 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+func TestMigrationsAreOrdered(t *testing.T) {
+	pending, err := PendingMigrations(dbm.NewMemDB())
+	require.NoError(t, err)
+	for i, m := range pending {
+		assert.EqualValues(t, i+1, m.Version, m.Description)
+		assert.NotNil(t, m.Migrate, m.Description)
+	}
+}
+
+func TestMigrateCompactCommits(t *testing.T) {
+	db := dbm.NewMemDB()
+
+	// a block store predating the compact format, with more commits than a
+	// chunk of the migration
+	const height = 1500
+	for h := int64(1); h <= height; h++ {
+		commit := makeTestCommit(h, tmtime.Now())
+		bz := mustEncode(commit.ToProto())
+		require.NoError(t, db.Set(calcLegacySeenCommitKey(h), bz))
+		switch {
+		case h == height:
+		case h%2 == 0:
+			bz = mustEncode(makeTestCommit(h, tmtime.Now()).ToProto())
+			fallthrough
+		default:
+			require.NoError(t, db.Set(calcLegacyBlockCommitKey(h), bz))
+		}
+	}
+	bs := NewBlockStore(db)
+	seenCommits := make(map[int64][]byte)
+	blockCommits := make(map[int64][]byte)
+	for h := int64(1); h <= height; h++ {
+		seenCommits[h] = mustEncode(bs.LoadSeenCommit(h).ToProto())
+		if h < height {
+			blockCommits[h] = mustEncode(bs.LoadBlockCommit(h).ToProto())
+		}
+	}
+
+	require.NoError(t, Migrate(db, log.TestingLogger()))
+	version, err := SchemaVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), version)
+
+	for h := int64(1); h <= height; h++ {
+		for _, key := range [][]byte{calcLegacySeenCommitKey(h), calcLegacyBlockCommitKey(h)} {
+			has, err := db.Has(key)
+			require.NoError(t, err)
+			assert.False(t, has, "%s", key)
+		}
+		has, err := db.Has(calcSeenCommitKey(h))
+		require.NoError(t, err)
+		assert.Equal(t, h%2 == 0, has, "seen commit at height %d", h)
+
+		assert.Equal(t, seenCommits[h], mustEncode(bs.LoadSeenCommit(h).ToProto()), "seen commit at height %d", h)
+		if h < height {
+			assert.Equal(t, blockCommits[h], mustEncode(bs.LoadBlockCommit(h).ToProto()), "block commit at height %d", h)
+		}
+	}
+
+	// migrating again is a no-op
+	pending, err := PendingMigrations(db)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	require.NoError(t, Migrate(db, log.TestingLogger()))
+}
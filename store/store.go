@@ -21,6 +21,8 @@ There are three types of information stored:
   - Block part:  Parts of each block, aggregated w/ PartSet
   - Commit:      The commit part of each block, for gossiping precommit votes
 
+Commits are stored in a compact format, see encodeCommit, and the seen commit
+of a height is only stored separately while it differs from the block commit.
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
@@ -122,6 +124,39 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 	return block
 }
 
+// LoadBlockRange calls fn with each block of the range of heights [from, to],
+// in order, and the commit for it: the block commit, or the seen commit for
+// the latest block. It stops at the first error returned by fn, and returns it.
+// The range must be within the base and height of the store.
+func (bs *BlockStore) LoadBlockRange(from, to int64, fn func(block *types.Block, commit *types.Commit) error) error {
+	base, height := bs.Base(), bs.Height()
+	switch {
+	case from > to:
+		return fmt.Errorf("invalid range of heights [%d, %d]", from, to)
+	case from < base || to > height:
+		return fmt.Errorf("range of heights [%d, %d] is not within the stored blocks [%d, %d]", from, to, base, height)
+	}
+
+	for h := from; h <= to; h++ {
+		block := bs.LoadBlock(h)
+		if block == nil {
+			// pruned since we checked the base
+			return fmt.Errorf("block at height %d not found", h)
+		}
+		commit := bs.LoadBlockCommit(h)
+		if commit == nil {
+			commit = bs.LoadSeenCommit(h)
+		}
+		if commit == nil {
+			return fmt.Errorf("commit at height %d not found", h)
+		}
+		if err := fn(block, commit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // LoadBlockByHash returns the block with the given hash.
 // If no block is found for that hash, it returns nil.
 // Panics if it fails to parse height associated with the given hash.
@@ -201,47 +236,50 @@ func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 // and it comes from the block.LastCommit for `height+1`.
 // If no commit is found for the given height, it returns nil.
 func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
-	var pbc = new(tmproto.Commit)
-	bz, err := bs.db.Get(calcBlockCommitKey(height))
-	if err != nil {
-		panic(err)
-	}
-	if len(bz) == 0 {
-		return nil
-	}
-	err = proto.Unmarshal(bz, pbc)
+	commit, err := bs.loadCommit(calcBlockCommitKey(height), calcLegacyBlockCommitKey(height))
 	if err != nil {
 		panic(fmt.Errorf("error reading block commit: %w", err))
 	}
-	commit, err := types.CommitFromProto(pbc)
-	if err != nil {
-		panic(fmt.Sprintf("Error reading block commit: %v", err))
-	}
 	return commit
 }
 
 // LoadSeenCommit returns the locally seen Commit for the given height.
 // This is useful when we've seen a commit, but there has not yet been
 // a new block at `height + 1` that includes this commit in its block.LastCommit.
+// Once there is, the seen commit is no longer kept, and the block commit is
+// returned instead.
 func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
-	var pbc = new(tmproto.Commit)
-	bz, err := bs.db.Get(calcSeenCommitKey(height))
+	commit, err := bs.loadCommit(calcSeenCommitKey(height), calcLegacySeenCommitKey(height))
 	if err != nil {
-		panic(err)
+		panic(fmt.Errorf("error reading block seen commit: %w", err))
 	}
-	if len(bz) == 0 {
-		return nil
+	if commit == nil {
+		// the seen commit is only kept until the next block is saved, see
+		// SaveBlock
+		return bs.LoadBlockCommit(height)
 	}
-	err = proto.Unmarshal(bz, pbc)
+	return commit
+}
+
+// loadCommit loads the commit stored under key in the compact format, or
+// under legacyKey by a block store predating it and not migrated yet.
+func (bs *BlockStore) loadCommit(key, legacyKey []byte) (*types.Commit, error) {
+	bz, err := bs.db.Get(key)
 	if err != nil {
-		panic(fmt.Sprintf("error reading block seen commit: %v", err))
+		panic(err)
+	}
+	if len(bz) > 0 {
+		return decodeCommit(bz)
 	}
 
-	commit, err := types.CommitFromProto(pbc)
+	bz, err = bs.db.Get(legacyKey)
 	if err != nil {
-		panic(fmt.Errorf("error from proto commit: %w", err))
+		panic(err)
 	}
-	return commit
+	if len(bz) > 0 {
+		return decodeLegacyCommit(bz)
+	}
+	return nil, nil
 }
 
 // PruneBlocks removes block up to (but not including) a height. It returns number of blocks pruned.
@@ -297,6 +335,12 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
 			return 0, err
 		}
+		if err := batch.Delete(calcLegacyBlockCommitKey(h)); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(calcLegacySeenCommitKey(h)); err != nil {
+			return 0, err
+		}
 		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
 			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
 				return 0, err
@@ -368,17 +412,18 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	}
 
 	// Save block commit (duplicate and separate from the Block)
-	pbc := block.LastCommit.ToProto()
-	blockCommitBytes := mustEncode(pbc)
+	blockCommitBytes := encodeCommit(block.LastCommit)
 	if err := bs.db.Set(calcBlockCommitKey(height-1), blockCommitBytes); err != nil {
 		panic(err)
 	}
+	// The seen commit of the previous block is superseded by its block
+	// commit, which LoadSeenCommit returns from now on.
+	if err := bs.db.Delete(calcSeenCommitKey(height - 1)); err != nil {
+		panic(err)
+	}
 
-	// Save seen commit (seen +2/3 precommits for block)
-	// NOTE: we can delete this at a later height
-	pbsc := seenCommit.ToProto()
-	seenCommitBytes := mustEncode(pbsc)
-	if err := bs.db.Set(calcSeenCommitKey(height), seenCommitBytes); err != nil {
+	// Save seen commit (seen +2/3 precommits for block), until the next block
+	if err := bs.db.Set(calcSeenCommitKey(height), encodeCommit(seenCommit)); err != nil {
 		panic(err)
 	}
 
@@ -417,12 +462,7 @@ func (bs *BlockStore) saveState() {
 
 // SaveSeenCommit saves a seen commit, used by e.g. the state sync reactor when bootstrapping node.
 func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) error {
-	pbc := seenCommit.ToProto()
-	seenCommitBytes, err := proto.Marshal(pbc)
-	if err != nil {
-		return fmt.Errorf("unable to marshal commit: %w", err)
-	}
-	return bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
+	return bs.db.Set(calcSeenCommitKey(height), encodeCommit(seenCommit))
 }
 
 func (bs *BlockStore) Close() error {
@@ -440,10 +480,21 @@ func calcBlockPartKey(height int64, partIndex int) []byte {
 }
 
 func calcBlockCommitKey(height int64) []byte {
-	return []byte(fmt.Sprintf("C:%v", height))
+	return []byte(fmt.Sprintf("CC:%v", height))
 }
 
 func calcSeenCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("SCC:%v", height))
+}
+
+// Commits used to be stored as tmproto.Commit under these keys, see
+// decodeLegacyCommit.
+
+func calcLegacyBlockCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("C:%v", height))
+}
+
+func calcLegacySeenCommitKey(height int64) []byte {
 	return []byte(fmt.Sprintf("SC:%v", height))
 }
 
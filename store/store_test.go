@@ -2,6 +2,7 @@ package store
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -368,6 +369,104 @@ func TestBlockStoreSaveLoadBlock(t *testing.T) {
 	}
 }
 
+func TestCommitEncoding(t *testing.T) {
+	commit := makeTestCommit(10, tmtime.Now())
+	commit.Signatures = append(commit.Signatures,
+		types.NewCommitSigAbsent(),
+		types.CommitSig{
+			BlockIDFlag:      types.BlockIDFlagNil,
+			ValidatorAddress: tmrand.Bytes(crypto.AddressSize),
+			Timestamp:        tmtime.Now(),
+			Signature:        []byte("Signature"),
+		},
+		types.NewCommitSigAbsent(),
+	)
+
+	bz := encodeCommit(commit)
+	assert.Less(t, len(bz), len(mustEncode(commit.ToProto())))
+	decoded, err := decodeCommit(bz)
+	require.NoError(t, err)
+	assert.Equal(t, mustEncode(commit.ToProto()), mustEncode(decoded.ToProto()))
+
+	// the commit of the block at the initial height has no signatures
+	decoded, err = decodeCommit(encodeCommit(new(types.Commit)))
+	require.NoError(t, err)
+	assert.Equal(t, mustEncode(new(types.Commit).ToProto()), mustEncode(decoded.ToProto()))
+
+	// the bitmaps and signatures must match
+	var cc tmstore.CompactCommit
+	require.NoError(t, proto.Unmarshal(bz, &cc))
+	cc.Signatures = cc.Signatures[1:]
+	_, err = decodeCommit(mustEncode(&cc))
+	assert.Error(t, err)
+}
+
+func TestBlockStoreKeepsLatestSeenCommit(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	seenCommit1 := makeTestCommit(1, tmtime.Now())
+	block1 := makeBlock(1, state, new(types.Commit))
+	bs.SaveBlock(block1, block1.MakePartSet(2), seenCommit1)
+	block2 := makeBlock(2, state, makeTestCommit(1, tmtime.Now().Add(time.Second)))
+	bs.SaveBlock(block2, block2.MakePartSet(2), makeTestCommit(2, tmtime.Now()))
+
+	// the seen commit of height 1 was replaced by the block commit of block 2,
+	// even if they differ
+	bz, err := bs.db.Get(calcSeenCommitKey(1))
+	require.NoError(t, err)
+	assert.Empty(t, bz)
+	assert.NotEqual(t, mustEncode(seenCommit1.ToProto()), mustEncode(block2.LastCommit.ToProto()))
+	assert.Equal(t, mustEncode(block2.LastCommit.ToProto()), mustEncode(bs.LoadSeenCommit(1).ToProto()))
+	assert.Equal(t, mustEncode(block2.LastCommit.ToProto()), mustEncode(bs.LoadBlockCommit(1).ToProto()))
+
+	// the seen commit of the latest height is kept
+	bz, err = bs.db.Get(calcSeenCommitKey(2))
+	require.NoError(t, err)
+	assert.NotEmpty(t, bz)
+	assert.NotNil(t, bs.LoadSeenCommit(2))
+	assert.Nil(t, bs.LoadBlockCommit(2))
+	assert.Nil(t, bs.LoadSeenCommit(3))
+}
+
+func TestLoadBlockRange(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= 5; h++ {
+		block := makeBlock(h, state, lastCommit)
+		lastCommit = makeTestCommit(h, tmtime.Now())
+		bs.SaveBlock(block, block.MakePartSet(2), lastCommit)
+	}
+
+	var heights []int64
+	err := bs.LoadBlockRange(2, 5, func(block *types.Block, commit *types.Commit) error {
+		heights = append(heights, block.Height)
+		assert.Equal(t, block.Height, commit.Height)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3, 4, 5}, heights)
+
+	// the callback can stop the iteration
+	heights = nil
+	err = bs.LoadBlockRange(1, 5, func(block *types.Block, commit *types.Commit) error {
+		heights = append(heights, block.Height)
+		if block.Height == 2 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "stop")
+	assert.Equal(t, []int64{1, 2}, heights)
+
+	noop := func(*types.Block, *types.Commit) error { return nil }
+	assert.Error(t, bs.LoadBlockRange(3, 2, noop))
+	assert.Error(t, bs.LoadBlockRange(0, 2, noop))
+	assert.Error(t, bs.LoadBlockRange(4, 6, noop))
+}
+
 func TestLoadBaseMeta(t *testing.T) {
 	config := cfg.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
@@ -22,9 +22,10 @@ func (emptyMempool) SizeBytes() int64 { return 0 }
 func (emptyMempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo) error {
 	return nil
 }
-func (emptyMempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
-func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
-func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (emptyMempool) RemoveTxByKey(txKey types.TxKey) error       { return nil }
+func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs     { return types.Txs{} }
+func (emptyMempool) ReapMaxTxs(n int) types.Txs                  { return types.Txs{} }
+func (emptyMempool) ListTxs(_, _ int, _ mempl.TxOrder) types.Txs { return types.Txs{} }
 func (emptyMempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -1,9 +1,12 @@
 package types
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -569,6 +572,50 @@ func HeaderFromProto(ph *tmproto.Header) (Header, error) {
 	return *h, h.ValidateBasic()
 }
 
+// HeaderFromBlockPrefix decodes the header of a block from the beginning of
+// its protobuf encoding, e.g. read from the first parts of its PartSet with
+// GetPrefixReader, before the rest of the block is available. It returns false
+// if r ends before the header does.
+func HeaderFromBlockPrefix(r io.Reader) (*Header, bool, error) {
+	br := bufio.NewReader(r)
+
+	// the header is the first field of the block
+	key, err := binary.ReadUvarint(br)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	if key != 1<<3|proto.WireBytes {
+		return nil, false, fmt.Errorf("expected the block to start with its header, got key %d", key)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	if size > MaxBlockSizeBytes {
+		return nil, false, fmt.Errorf("header size %d exceeds the maximum block size %d", size, MaxBlockSizeBytes)
+	}
+
+	bz := make([]byte, size)
+	if _, err := io.ReadFull(br, bz); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	ph := new(tmproto.Header)
+	if err := proto.Unmarshal(bz, ph); err != nil {
+		return nil, false, err
+	}
+	h, err := HeaderFromProto(ph)
+	if err != nil {
+		return nil, false, err
+	}
+	return &h, true, nil
+}
+
 //-------------------------------------
 
 // BlockIDFlag indicates which BlockID the signature is for.
@@ -8,6 +8,7 @@ import (
 	"math"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -117,6 +118,41 @@ func TestBlockMakePartSet(t *testing.T) {
 	assert.EqualValues(t, 1, partSet.Total())
 }
 
+func TestHeaderFromBlockPrefix(t *testing.T) {
+	block := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil)
+	block.Header = makeRandHeader()
+	// the header spans several parts
+	partSet := block.MakePartSet(64)
+	require.Greater(t, partSet.Total(), uint32(4))
+
+	received := NewPartSetFromHeader(partSet.Header())
+	for i := int(partSet.Total()) - 1; i > 0; i-- {
+		_, err := received.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+	_, ok := NewPartSetFromHeader(partSet.Header()).GetPrefixReader()
+	assert.False(t, ok)
+
+	prefix := NewPartSetFromHeader(partSet.Header())
+	decoded := false
+	for i := 0; i < int(partSet.Total()) && !decoded; i++ {
+		_, err := prefix.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+		r, ok := prefix.GetPrefixReader()
+		require.True(t, ok)
+		header, ok, err := HeaderFromBlockPrefix(r)
+		require.NoError(t, err)
+		if ok {
+			assert.Equal(t, block.Header.Hash(), header.Hash())
+			decoded = true
+		}
+	}
+	assert.True(t, decoded)
+
+	_, _, err := HeaderFromBlockPrefix(strings.NewReader("\x12\x01\x00"))
+	assert.Error(t, err)
+}
+
 func TestBlockMakePartSetWithEvidence(t *testing.T) {
 	assert.Nil(t, (*Block)(nil).MakePartSet(2))
 
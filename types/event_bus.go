@@ -227,6 +227,10 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventLivenessAlarm(data EventDataLivenessAlarm) error {
+	return b.Publish(EventLivenessAlarm, data)
+}
+
 // -----------------------------------------------------------------------------
 type NopEventBus struct{}
 
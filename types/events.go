@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bits"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
@@ -22,6 +24,10 @@ const (
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
+	// EventLivenessAlarm is fired by the consensus state when no block has
+	// been committed for longer than the configured max block interval.
+	EventLivenessAlarm = "LivenessAlarm"
+
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
@@ -56,6 +62,7 @@ func init() {
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	tmjson.RegisterType(EventDataLivenessAlarm{}, "tendermint/event/LivenessAlarm")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -87,11 +94,18 @@ type EventDataTx struct {
 	abci.TxResult
 }
 
-// NOTE: This goes into the replay WAL
+// NOTE: This goes into the replay WAL, without the votes.
 type EventDataRoundState struct {
 	Height int64  `json:"height"`
 	Round  int32  `json:"round"`
 	Step   string `json:"step"`
+
+	// Validators which prevoted and precommitted in the round, by index, and
+	// the sum of their voting power.
+	Prevotes        *bits.BitArray `json:"prevotes,omitempty"`
+	PrevotesPower   int64          `json:"prevotes_power,omitempty"`
+	Precommits      *bits.BitArray `json:"precommits,omitempty"`
+	PrecommitsPower int64          `json:"precommits_power,omitempty"`
 }
 
 type ValidatorInfo struct {
@@ -125,6 +139,16 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+type EventDataLivenessAlarm struct {
+	// Height which has not been committed yet.
+	Height int64 `json:"height"`
+	// Local time at which the previous height was committed (or consensus
+	// was started).
+	LastCommitTime time.Time `json:"last_commit_time"`
+	// Time elapsed since then.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
 // PUBSUB
 
 const (
@@ -144,6 +168,7 @@ const (
 
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryLivenessAlarm       = QueryForEvent(EventLivenessAlarm)
 	EventQueryLock                = QueryForEvent(EventLock)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
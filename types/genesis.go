@@ -19,6 +19,11 @@ import (
 const (
 	// MaxChainIDLen is a maximum length of the chain ID.
 	MaxChainIDLen = 50
+
+	// MaxAppStateSizeBytes is the maximum size of the app_state of a genesis
+	// doc. It is the maximum size of ABCI messages, as the app_state is sent
+	// to the application with InitChain.
+	MaxAppStateSizeBytes = 104857600 // 100MB
 )
 
 //------------------------------------------------------------
@@ -77,6 +82,13 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	if genDoc.InitialHeight < 0 {
 		return fmt.Errorf("initial_height cannot be negative (got %v)", genDoc.InitialHeight)
 	}
+	if len(genDoc.AppState) > MaxAppStateSizeBytes {
+		return fmt.Errorf("app_state in genesis doc is too big: %d bytes (max: %d)",
+			len(genDoc.AppState), MaxAppStateSizeBytes)
+	}
+	if len(genDoc.AppState) > 0 && !json.Valid(genDoc.AppState) {
+		return errors.New("app_state in genesis doc is not valid JSON")
+	}
 	if genDoc.InitialHeight == 0 {
 		genDoc.InitialHeight = 1
 	}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -146,6 +148,27 @@ func TestGenesisSaveAs(t *testing.T) {
 	assert.Equal(t, genDoc2.Validators, genDoc.Validators)
 }
 
+func TestGenesisAppState(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = json.RawMessage(`{"accounts":[{"name":"alice","balance":"10"}]}`)
+	require.NoError(t, genDoc.ValidateAndComplete())
+
+	genDoc.AppState = json.RawMessage(`{"accounts":`)
+	require.Error(t, genDoc.ValidateAndComplete())
+
+	// the app_state is passed through to the app as is
+	genDoc.AppState = json.RawMessage(`{"accounts":[]}`)
+	file := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, genDoc.SaveAs(file))
+	genDoc2, err := GenesisDocFromFile(file)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(genDoc.AppState), string(genDoc2.AppState))
+
+	// the app_state must fit in an ABCI message
+	genDoc.AppState = make(json.RawMessage, MaxAppStateSizeBytes+1)
+	assert.ErrorContains(t, genDoc.ValidateAndComplete(), "too big")
+}
+
 func TestGenesisValidatorHash(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	assert.NotEmpty(t, genDoc.ValidatorHash())
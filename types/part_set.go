@@ -147,6 +147,13 @@ func PartSetHeaderFromProto(ppsh *tmproto.PartSetHeader) (*PartSetHeader, error)
 
 //-------------------------------------
 
+// PartSpill stores the parts a PartSet keeps out of memory, see
+// PartSet.SetSpill.
+type PartSpill interface {
+	SavePart(header PartSetHeader, part *Part) error
+	LoadPart(header PartSetHeader, index uint32) (*Part, error)
+}
+
 type PartSet struct {
 	total uint32
 	hash  []byte
@@ -158,6 +165,12 @@ type PartSet struct {
 	// a count of the total size (in bytes). Used to ensure that the
 	// part set doesn't exceed the maximum block bytes
 	byteSize int64
+
+	// if set, the parts added beyond memBudget bytes of those in memory are
+	// saved to spill, and only loaded back when needed
+	spill     PartSpill
+	memBudget int64
+	memBytes  int64
 }
 
 // Returns an immutable, full PartSet from the data bytes.
@@ -205,6 +218,16 @@ func NewPartSetFromHeader(header PartSetHeader) *PartSet {
 	}
 }
 
+// SetSpill makes the PartSet keep the parts it is added in memory only up to
+// memBudget bytes, and save the following ones to spill. A part that fails to
+// be saved is kept in memory.
+func (ps *PartSet) SetSpill(spill PartSpill, memBudget int64) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	ps.spill = spill
+	ps.memBudget = memBudget
+}
+
 func (ps *PartSet) Header() PartSetHeader {
 	if ps == nil {
 		return PartSetHeader{}
@@ -276,7 +299,7 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	}
 
 	// If part already exists, return false.
-	if ps.parts[part.Index] != nil {
+	if ps.partsBitArray.GetIndex(int(part.Index)) {
 		return false, nil
 	}
 
@@ -286,17 +309,29 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	}
 
 	// Add part
-	ps.parts[part.Index] = part
+	size := int64(len(part.Bytes))
+	spilled := ps.spill != nil && ps.memBytes+size > ps.memBudget &&
+		ps.spill.SavePart(ps.Header(), part) == nil
+	if !spilled {
+		ps.parts[part.Index] = part
+		ps.memBytes += size
+	}
 	ps.partsBitArray.SetIndex(int(part.Index), true)
 	ps.count++
 	ps.byteSize += int64(len(part.Bytes))
 	return true, nil
 }
 
+// GetPart returns the part at the given index, or nil if it is missing, or
+// spilled and fails to load.
 func (ps *PartSet) GetPart(index int) *Part {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
-	return ps.parts[index]
+	part := ps.parts[index]
+	if part == nil && ps.spill != nil && ps.partsBitArray.GetIndex(index) {
+		part, _ = ps.spill.LoadPart(ps.Header(), uint32(index))
+	}
+	return part
 }
 
 func (ps *PartSet) IsComplete() bool {
@@ -307,12 +342,45 @@ func (ps *PartSet) GetReader() io.Reader {
 	if !ps.IsComplete() {
 		panic("Cannot GetReader() on incomplete PartSet")
 	}
-	return NewPartSetReader(ps.parts)
+	return ps.newReader(len(ps.parts))
+}
+
+// GetPrefixReader returns a reader of the parts received so far, up to the
+// first missing one, or false if the first part is missing. Unlike GetReader,
+// it can be used while the PartSet is incomplete, e.g. to decode the header of
+// a block with HeaderFromBlockPrefix before the rest of its parts arrive.
+func (ps *PartSet) GetPrefixReader() (io.Reader, bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	n := 0
+	for n < len(ps.parts) && ps.partsBitArray.GetIndex(n) {
+		n++
+	}
+	if n == 0 {
+		return nil, false
+	}
+	return ps.newReader(n), true
+}
+
+// newReader returns a reader of the first n parts, which loads the spilled
+// ones as it reaches them.
+func (ps *PartSet) newReader(n int) *PartSetReader {
+	if ps.spill == nil {
+		return NewPartSetReader(ps.parts[:n])
+	}
+	return &PartSetReader{
+		i:      -1,
+		parts:  ps.parts[:n],
+		load:   ps.GetPart,
+		reader: bytes.NewReader(nil),
+	}
 }
 
 type PartSetReader struct {
 	i      int
 	parts  []*Part
+	load   func(index int) *Part
 	reader *bytes.Reader
 }
 
@@ -325,6 +393,9 @@ func NewPartSetReader(parts []*Part) *PartSetReader {
 }
 
 func (psr *PartSetReader) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
 	readerLen := psr.reader.Len()
 	if readerLen >= len(p) {
 		return psr.reader.Read(p)
@@ -341,7 +412,14 @@ func (psr *PartSetReader) Read(p []byte) (n int, err error) {
 	if psr.i >= len(psr.parts) {
 		return 0, io.EOF
 	}
-	psr.reader = bytes.NewReader(psr.parts[psr.i].Bytes)
+	part := psr.parts[psr.i]
+	if part == nil && psr.load != nil {
+		part = psr.load(psr.i)
+	}
+	if part == nil {
+		return 0, fmt.Errorf("part %d is missing", psr.i)
+	}
+	psr.reader = bytes.NewReader(part.Bytes)
 	return psr.Read(p)
 }
 
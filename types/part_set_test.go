@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"io"
 	"testing"
 
@@ -63,6 +64,84 @@ func TestBasicPartSet(t *testing.T) {
 	assert.Equal(t, data, data2)
 }
 
+// mapPartSpill is a PartSpill in memory.
+type mapPartSpill struct {
+	parts map[uint32]*Part
+	fail  bool
+}
+
+func (s *mapPartSpill) SavePart(_ PartSetHeader, part *Part) error {
+	if s.fail {
+		return errors.New("spill failure")
+	}
+	s.parts[part.Index] = part
+	return nil
+}
+
+func (s *mapPartSpill) LoadPart(_ PartSetHeader, index uint32) (*Part, error) {
+	part, ok := s.parts[index]
+	if !ok {
+		return nil, errors.New("part not found")
+	}
+	return part, nil
+}
+
+func TestPartSetSpill(t *testing.T) {
+	nParts := 10
+	data := tmrand.Bytes(testPartSize * nParts)
+	partSet := NewPartSetFromData(data, testPartSize)
+
+	spill := &mapPartSpill{parts: make(map[uint32]*Part)}
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+	partSet2.SetSpill(spill, 3*testPartSize)
+	// the parts are added in reverse order, so that the first ones are spilled
+	for i := nParts - 1; i >= 0; i-- {
+		added, err := partSet2.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+		require.True(t, added)
+		if i == nParts/2 {
+			_, ok := partSet2.GetPrefixReader()
+			assert.False(t, ok)
+		}
+	}
+	assert.Len(t, spill.parts, nParts-3)
+	assert.EqualValues(t, 3*testPartSize, partSet2.memBytes)
+	assert.True(t, partSet2.IsComplete())
+	assert.EqualValues(t, nParts*testPartSize, partSet2.ByteSize())
+
+	// spilled parts are not added twice
+	added, err := partSet2.AddPart(partSet.GetPart(0))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	for i := 0; i < nParts; i++ {
+		assert.Equal(t, partSet.GetPart(i), partSet2.GetPart(i))
+	}
+	data2, err := io.ReadAll(partSet2.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+	r, ok := partSet2.GetPrefixReader()
+	require.True(t, ok)
+	data2, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	// a part which fails to load is missing
+	delete(spill.parts, 0)
+	assert.Nil(t, partSet2.GetPart(0))
+	_, err = io.ReadAll(partSet2.GetReader())
+	assert.Error(t, err)
+
+	// a part which fails to spill is kept in memory
+	spill.fail = true
+	partSet3 := NewPartSetFromHeader(partSet.Header())
+	partSet3.SetSpill(spill, 0)
+	added, err = partSet3.AddPart(partSet.GetPart(0))
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, partSet.GetPart(0), partSet3.GetPart(0))
+}
+
 func TestWrongProof(t *testing.T) {
 	// Construct random data of size partSize * 100
 	data := tmrand.Bytes(testPartSize * 100)
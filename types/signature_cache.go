@@ -0,0 +1,134 @@
+package types
+
+import (
+	"container/list"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// SignatureCache remembers signatures which have been successfully verified,
+// so that the same signed message received again (e.g. a vote gossiped by
+// several peers) does not need to be verified again. Entries are evicted in
+// least recently used order once either the number of entries or the total
+// size of the cached data exceeds its bound.
+//
+// A nil *SignatureCache caches nothing.
+type SignatureCache struct {
+	mtx        tmsync.Mutex
+	maxEntries int
+	maxBytes   int
+	bytes      int
+	ll         *list.List               // front is most recently used
+	entries    map[string]*list.Element // key -> element holding the key
+
+	// observer, if set, is called on every lookup with whether it was a hit
+	observer func(hit bool)
+}
+
+// NewSignatureCache returns a cache holding up to maxEntries signatures and
+// maxBytes bytes of public keys, messages and signatures. If either bound is
+// not positive, nil is returned, which disables caching.
+func NewSignatureCache(maxEntries, maxBytes int) *SignatureCache {
+	if maxEntries <= 0 || maxBytes <= 0 {
+		return nil
+	}
+	return &SignatureCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// SetObserver sets a function called on every lookup, with whether the
+// signature was found in the cache. It is intended for collecting metrics.
+func (c *SignatureCache) SetObserver(observer func(hit bool)) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.observer = observer
+}
+
+// VerifySignature reports whether sig is a valid signature of msg by pubKey,
+// consulting the cache first. Valid signatures are added to the cache;
+// invalid ones never are.
+func (c *SignatureCache) VerifySignature(pubKey crypto.PubKey, msg, sig []byte) bool {
+	if c == nil {
+		return pubKey.VerifySignature(msg, sig)
+	}
+
+	key := signatureCacheKey(pubKey, msg, sig)
+	if c.get(key) {
+		return true
+	}
+	if !pubKey.VerifySignature(msg, sig) {
+		return false
+	}
+	c.add(key)
+	return true
+}
+
+// Size returns the number of cached signatures.
+func (c *SignatureCache) Size() int {
+	if c == nil {
+		return 0
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.ll.Len()
+}
+
+func (c *SignatureCache) get(key string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.entries[key]
+	if ok {
+		c.ll.MoveToFront(e)
+	}
+	if c.observer != nil {
+		c.observer(ok)
+	}
+	return ok
+}
+
+func (c *SignatureCache) add(key string) {
+	if len(key) > c.maxBytes {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.ll.PushFront(key)
+	c.bytes += len(key)
+
+	for c.ll.Len() > c.maxEntries || c.bytes > c.maxBytes {
+		e := c.ll.Back()
+		k := c.ll.Remove(e).(string)
+		delete(c.entries, k)
+		c.bytes -= len(k)
+	}
+}
+
+// signatureCacheKey concatenates the public key, signature and message. The
+// key and signature have a fixed size for a given key type, which is
+// included, so the result is unambiguous.
+func signatureCacheKey(pubKey crypto.PubKey, msg, sig []byte) string {
+	keyType := pubKey.Type()
+	pk := pubKey.Bytes()
+	buf := make([]byte, 0, len(keyType)+len(pk)+len(sig)+len(msg)+2)
+	buf = append(buf, keyType...)
+	buf = append(buf, 0, byte(len(sig)))
+	buf = append(buf, pk...)
+	buf = append(buf, sig...)
+	buf = append(buf, msg...)
+	return string(buf)
+}
@@ -0,0 +1,174 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestSignatureCache(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	pubKey := privKey.PubKey()
+	msg := []byte("message")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	var hits, misses int
+	cache := NewSignatureCache(10, 1024)
+	cache.SetObserver(func(hit bool) {
+		if hit {
+			hits++
+		} else {
+			misses++
+		}
+	})
+
+	// invalid signatures are never cached
+	assert.False(t, cache.VerifySignature(pubKey, []byte("other"), sig))
+	assert.False(t, cache.VerifySignature(pubKey, []byte("other"), sig))
+	assert.Equal(t, 0, cache.Size())
+	assert.Equal(t, 2, misses)
+
+	assert.True(t, cache.VerifySignature(pubKey, msg, sig))
+	assert.True(t, cache.VerifySignature(pubKey, msg, sig))
+	assert.Equal(t, 1, cache.Size())
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 3, misses)
+
+	// a nil cache verifies every time
+	var nilCache *SignatureCache
+	assert.True(t, nilCache.VerifySignature(pubKey, msg, sig))
+	assert.Equal(t, 0, nilCache.Size())
+	assert.Nil(t, NewSignatureCache(0, 1024))
+}
+
+func TestSignatureCacheEviction(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	sign := func(msg []byte) []byte {
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+		return sig
+	}
+	msgs := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	sigs := [][]byte{sign(msgs[0]), sign(msgs[1]), sign(msgs[2])}
+
+	// bounded by number of entries
+	cache := NewSignatureCache(2, 1024)
+	for i := range msgs {
+		require.True(t, cache.VerifySignature(pubKey, msgs[i], sigs[i]))
+	}
+	assert.Equal(t, 2, cache.Size())
+	_, ok := cache.entries[signatureCacheKey(pubKey, msgs[0], sigs[0])]
+	assert.False(t, ok, "least recently used entry should have been evicted")
+
+	// bounded by bytes
+	entrySize := len(signatureCacheKey(pubKey, msgs[0], sigs[0]))
+	cache = NewSignatureCache(10, 2*entrySize)
+	for i := range msgs {
+		require.True(t, cache.VerifySignature(pubKey, msgs[i], sigs[i]))
+	}
+	assert.Equal(t, 2, cache.Size())
+	assert.LessOrEqual(t, cache.bytes, 2*entrySize)
+
+	// entries larger than the cache are not stored
+	cache = NewSignatureCache(10, entrySize-1)
+	require.True(t, cache.VerifySignature(pubKey, msgs[0], sigs[0]))
+	assert.Equal(t, 0, cache.Size())
+}
+
+func TestVerifyCommitWithCache(t *testing.T) {
+	h := int64(3)
+	blockID := makeBlockIDRandom()
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+
+	var hits, misses int
+	cache := NewSignatureCache(10, 4096)
+	cache.SetObserver(func(hit bool) {
+		if hit {
+			hits++
+		} else {
+			misses++
+		}
+	})
+	voteSet.SetSignatureCache(cache)
+
+	// the precommits are verified as they are added
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 4, misses)
+	assert.Equal(t, 4, cache.Size())
+
+	// and found in the cache when the commit is verified
+	require.NoError(t, valSet.VerifyCommitWithCache(voteSet.ChainID(), blockID, h, commit, cache))
+	assert.Equal(t, 4, hits)
+	assert.Equal(t, 4, misses)
+
+	// a wrong signature is still caught
+	commit.Signatures[3].Signature = commit.Signatures[2].Signature
+	err = valSet.VerifyCommitWithCache(voteSet.ChainID(), blockID, h, commit, cache)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#3)")
+	}
+}
+
+// BenchmarkVerifyCommitWithCache measures a height of consensus as far as
+// signatures are concerned: the precommits are verified as they are received,
+// then the commit made of them is verified twice as the LastCommit of the next
+// block, when it is validated and when it is executed.
+func BenchmarkVerifyCommitWithCache(b *testing.B) {
+	const numValidators = 100
+	h := int64(3)
+	blockID := makeBlockIDRandom()
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, numValidators, 10)
+	_, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(b, err)
+	votes := make([]*Vote, numValidators)
+	for i := range votes {
+		votes[i] = voteSet.GetByIndex(int32(i))
+	}
+
+	for _, cached := range []bool{false, true} {
+		name := "no cache"
+		if cached {
+			name = "cache"
+		}
+		b.Run(name, func(b *testing.B) {
+			var hits, lookups int
+			for i := 0; i < b.N; i++ {
+				var cache *SignatureCache
+				if cached {
+					cache = NewSignatureCache(10000, 4194304)
+					cache.SetObserver(func(hit bool) {
+						lookups++
+						if hit {
+							hits++
+						}
+					})
+				}
+				vs := NewVoteSet(voteSet.ChainID(), h, 0, tmproto.PrecommitType, valSet)
+				vs.SetSignatureCache(cache)
+				for _, vote := range votes {
+					if _, err := vs.AddVote(vote); err != nil {
+						b.Fatal(err)
+					}
+				}
+				commit := vs.MakeCommit()
+				for j := 0; j < 2; j++ {
+					if err := valSet.VerifyCommitWithCache(vs.ChainID(), blockID, h, commit, cache); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			if lookups > 0 {
+				b.ReportMetric(float64(hits)/float64(lookups), "hits/lookup")
+			}
+		})
+	}
+}
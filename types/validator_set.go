@@ -55,6 +55,9 @@ type ValidatorSet struct {
 
 	// cached (unexported)
 	totalVotingPower int64
+	// index in Validators by address, rebuilt whenever the validators change.
+	// It is never modified in place, so copies of the set can share it.
+	addressIndex map[string]int32
 }
 
 // NewValidatorSet initializes a ValidatorSet by copying over the values from
@@ -251,29 +254,53 @@ func (vals *ValidatorSet) Copy() *ValidatorSet {
 		Validators:       validatorListCopy(vals.Validators),
 		Proposer:         vals.Proposer,
 		totalVotingPower: vals.totalVotingPower,
+		addressIndex:     vals.addressIndex,
 	}
 }
 
 // HasAddress returns true if address given is in the validator set, false -
 // otherwise.
 func (vals *ValidatorSet) HasAddress(address []byte) bool {
-	for _, val := range vals.Validators {
-		if bytes.Equal(val.Address, address) {
-			return true
-		}
-	}
-	return false
+	return vals.indexOf(address) >= 0
 }
 
 // GetByAddress returns an index of the validator with address and validator
 // itself (copy) if found. Otherwise, -1 and nil are returned.
 func (vals *ValidatorSet) GetByAddress(address []byte) (index int32, val *Validator) {
+	idx := vals.indexOf(address)
+	if idx < 0 {
+		return -1, nil
+	}
+	return idx, vals.Validators[idx].Copy()
+}
+
+// indexOf returns the index of the validator with address, or -1. Validators
+// are found through the address index, which makes the lookups of votes
+// constant time, and otherwise by scanning the set.
+func (vals *ValidatorSet) indexOf(address []byte) int32 {
+	if idx, ok := vals.addressIndex[string(address)]; ok &&
+		int(idx) < len(vals.Validators) && bytes.Equal(vals.Validators[idx].Address, address) {
+		return idx
+	}
+
+	// The index is missing, for sets not built by the functions of this
+	// package, or stale, if Validators was modified directly.
 	for idx, val := range vals.Validators {
 		if bytes.Equal(val.Address, address) {
-			return int32(idx), val.Copy()
+			return int32(idx)
 		}
 	}
-	return -1, nil
+	return -1
+}
+
+// updateAddressIndex rebuilds the index of the validators by address. It must
+// be called whenever the validators change.
+func (vals *ValidatorSet) updateAddressIndex() {
+	index := make(map[string]int32, len(vals.Validators))
+	for idx, val := range vals.Validators {
+		index[string(val.Address)] = int32(idx)
+	}
+	vals.addressIndex = index
 }
 
 // GetByIndex returns the validator's address and validator itself (copy) by
@@ -636,6 +663,7 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 	vals.shiftByAvgProposerPriority()
 
 	sort.Sort(ValidatorsByVotingPower(vals.Validators))
+	vals.updateAddressIndex()
 
 	return nil
 }
@@ -666,6 +694,14 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 // with a bonus for including more than +2/3 of the signatures.
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	height int64, commit *Commit) error {
+	return vals.VerifyCommitWithCache(chainID, blockID, height, commit, nil)
+}
+
+// VerifyCommitWithCache is like VerifyCommit, but skips verifying the
+// signatures found in the given cache, such as those of the precommits a node
+// verified when it received them, and caches the others. The cache may be nil.
+func (vals *ValidatorSet) VerifyCommitWithCache(chainID string, blockID BlockID,
+	height int64, commit *Commit, cache *SignatureCache) error {
 
 	if vals.Size() != len(commit.Signatures) {
 		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
@@ -693,7 +729,7 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+		if !cache.VerifySignature(val.PubKey, voteSignBytes, commitSig.Signature) {
 			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
 		}
 		// Good!
@@ -976,6 +1012,7 @@ func ValidatorSetFromProto(vp *tmproto.ValidatorSet) (*ValidatorSet, error) {
 		valsProto[i] = v
 	}
 	vals.Validators = valsProto
+	vals.updateAddressIndex()
 
 	p, err := ValidatorFromProto(vp.GetProposer())
 	if err != nil {
@@ -1015,6 +1052,7 @@ func ValidatorSetFromExistingValidators(valz []*Validator) (*ValidatorSet, error
 	vals.Proposer = vals.findPreviousProposer()
 	vals.updateTotalVotingPower()
 	sort.Sort(ValidatorsByVotingPower(vals.Validators))
+	vals.updateAddressIndex()
 	return vals, nil
 }
 
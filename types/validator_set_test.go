@@ -164,6 +164,53 @@ func TestIncrementProposerPriorityPositiveTimes(t *testing.T) {
 	vset.IncrementProposerPriority(1)
 }
 
+func TestValidatorSetGetByAddress(t *testing.T) {
+	vset := randValidatorSet(10)
+	assertFound := func(vset *ValidatorSet) {
+		for idx, val := range vset.Validators {
+			i, v := vset.GetByAddress(val.Address)
+			assert.EqualValues(t, idx, i)
+			assert.Equal(t, val, v)
+			assert.True(t, vset.HasAddress(val.Address))
+		}
+		i, v := vset.GetByAddress([]byte("unknown"))
+		assert.EqualValues(t, -1, i)
+		assert.Nil(t, v)
+		assert.False(t, vset.HasAddress([]byte("unknown")))
+	}
+	assertFound(vset)
+	assertFound(vset.Copy())
+
+	// the index follows the changes of the set
+	require.NoError(t, vset.UpdateWithChangeSet([]*Validator{
+		newValidator(vset.Validators[0].Address, 0),
+		randValidator(vset.TotalVotingPower()),
+	}))
+	assertFound(vset)
+
+	pb, err := vset.ToProto()
+	require.NoError(t, err)
+	fromProto, err := ValidatorSetFromProto(pb)
+	require.NoError(t, err)
+	assertFound(fromProto)
+
+	// and the validators can still be found when modified directly
+	val := randValidator(10)
+	vset.Validators[1] = val
+	assertFound(vset)
+	assertFound(&ValidatorSet{Validators: vset.Validators})
+}
+
+func BenchmarkValidatorSetGetByAddress(b *testing.B) {
+	vset, _ := RandValidatorSet(1000, 10)
+	address := vset.Validators[999].Address
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		vset.GetByAddress(address)
+	}
+}
+
 func BenchmarkValidatorSetCopy(b *testing.B) {
 	b.StopTimer()
 	vset := NewValidatorSet([]*Validator{})
@@ -145,11 +145,17 @@ func (vote *Vote) String() string {
 }
 
 func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
+	return vote.verifyWithCache(chainID, pubKey, nil)
+}
+
+// verifyWithCache is like Verify, but skips verifying signatures found in the
+// given cache. The cache may be nil.
+func (vote *Vote) verifyWithCache(chainID string, pubKey crypto.PubKey, cache *SignatureCache) error {
 	if !bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
 		return ErrVoteInvalidValidatorAddress
 	}
 	v := vote.ToProto()
-	if !pubKey.VerifySignature(VoteSignBytes(chainID, v), vote.Signature) {
+	if !cache.VerifySignature(pubKey, VoteSignBytes(chainID, v), vote.Signature) {
 		return ErrVoteInvalidSignature
 	}
 	return nil
@@ -72,6 +72,7 @@ type VoteSet struct {
 	maj23         *BlockID               // First 2/3 majority seen
 	votesByBlock  map[string]*blockVotes // string(blockHash|blockParts) -> blockVotes
 	peerMaj23s    map[P2PID]BlockID      // Maj23 for each peer
+	sigCache      *SignatureCache        // Verified signatures, may be nil
 }
 
 // Constructs a new VoteSet struct used to accumulate votes for given height/round.
@@ -95,6 +96,16 @@ func NewVoteSet(chainID string, height int64, round int32,
 	}
 }
 
+// SetSignatureCache sets a cache of verified signatures, to which the
+// signatures of the added votes are added. It may be shared with the block
+// executor, so that a commit made of these votes is not verified again (see
+// ValidatorSet.VerifyCommitWithCache).
+func (voteSet *VoteSet) SetSignatureCache(cache *SignatureCache) {
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	voteSet.sigCache = cache
+}
+
 func (voteSet *VoteSet) ChainID() string {
 	return voteSet.chainID
 }
@@ -202,7 +213,7 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	}
 
 	// Check signature.
-	if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
+	if err := vote.verifyWithCache(voteSet.chainID, val.PubKey, voteSet.sigCache); err != nil {
 		return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
 	}
 
@@ -353,6 +364,16 @@ func (voteSet *VoteSet) BitArray() *bits.BitArray {
 	return voteSet.votesBitArray.Copy()
 }
 
+// SumVotingPower returns the voting power of the validators which voted.
+func (voteSet *VoteSet) SumVotingPower() int64 {
+	if voteSet == nil {
+		return 0
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	return voteSet.sum
+}
+
 func (voteSet *VoteSet) BitArrayByBlockID(blockID BlockID) *bits.BitArray {
 	if voteSet == nil {
 		return nil